@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"darulabror/internal/utils"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	echomw "github.com/labstack/echo/v4/middleware"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+// RateLimit allows each client IP perMinute requests per minute (with bursts of
+// up to burst), read from the environment variable env ("0" disables it).
+// Clients over the limit get 429 with Retry-After.
+func RateLimit(env string, perMinute, burst int) echo.MiddlewareFunc {
+	if v := os.Getenv(env); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			logrus.WithField(env, v).Warn("invalid rate limit, using the default")
+		} else {
+			perMinute = n
+		}
+	}
+	if perMinute == 0 {
+		return func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+	}
+	if burst > perMinute {
+		burst = perMinute
+	}
+
+	store := echomw.NewRateLimiterMemoryStoreWithConfig(echomw.RateLimiterMemoryStoreConfig{
+		Rate:      rate.Limit(float64(perMinute) / 60),
+		Burst:     burst,
+		ExpiresIn: 10 * time.Minute,
+	})
+	retryAfter := strconv.Itoa(int((time.Minute / time.Duration(perMinute)).Seconds()) + 1)
+
+	return echomw.RateLimiterWithConfig(echomw.RateLimiterConfig{
+		Store: store,
+		IdentifierExtractor: func(c echo.Context) (string, error) {
+			return c.RealIP(), nil
+		},
+		DenyHandler: func(c echo.Context, identifier string, err error) error {
+			logrus.WithFields(logrus.Fields{"ip": identifier, "path": c.Path()}).Warn("rate limit exceeded")
+			c.Response().Header().Set("Retry-After", retryAfter)
+			return utils.TooManyRequestsResponse(c, "too many requests, try again later")
+		},
+	})
+}
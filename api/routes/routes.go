@@ -13,6 +13,15 @@ type Handlers struct {
 	Registration *handler.RegistrationHandler
 	Contact      *handler.ContactHandler
 	Admin        *handler.AdminHandler
+	Taxonomy     *handler.TaxonomyHandler
+	Trash        *handler.TrashHandler
+	Feed         *handler.FeedHandler
+	Sitemap      *handler.SitemapHandler
+	Media        *handler.MediaHandler
+	File         *handler.FileHandler
+	Document     *handler.RegistrationDocumentHandler
+	Admission    *handler.AdmissionPeriodHandler
+	Notification *handler.NotificationHandler
 }
 
 func Register(e *echo.Echo, h Handlers) {
@@ -20,10 +29,29 @@ func Register(e *echo.Echo, h Handlers) {
 	// Public routes
 	// ======================
 	e.GET("/articles", h.Article.ListPublished)
+	e.GET("/articles/search", h.Article.SearchPublished)
 	e.GET("/articles/:id", h.Article.GetPublishedByID)
+	e.GET("/articles/slug/:slug", h.Article.GetPublishedBySlug)
+	e.GET("/articles/preview/:token", h.Article.GetPreview)
+	e.GET("/categories", h.Taxonomy.ListCategories)
+	e.GET("/tags", h.Taxonomy.ListTags)
+	e.GET("/feeds/articles.rss", h.Feed.ArticlesRSS)
+	e.GET("/feeds/articles.atom", h.Feed.ArticlesAtom)
+	e.GET("/sitemap.xml", h.Sitemap.Sitemap)
+	e.GET("/sitemaps/:page", h.Sitemap.SitemapPage)
+	e.GET("/robots.txt", h.Sitemap.Robots)
+	// signed links to private files on the local disk backend
+	e.GET("/files/*", h.File.ServeSigned)
 
+	e.GET("/admission-periods/current", h.Admission.Current)
 	e.POST("/registrations", h.Registration.Create)
-	e.POST("/contacts", h.Contact.Create)
+	// reference number / NISN + date of birth; rate limited against NISN enumeration
+	e.POST("/registrations/status-lookup", h.Registration.StatusLookup, middleware.RateLimit("STATUS_LOOKUP_RATE_LIMIT", 10, 5))
+	// applicant documents, authorized by the X-Registration-Token header
+	e.GET("/registrations/documents", h.Document.Checklist)
+	e.POST("/registrations/documents", h.Document.Upload)
+	// acknowledged by mail to the given address: rate limited against abuse
+	e.POST("/contacts", h.Contact.Create, middleware.RateLimit("CONTACT_RATE_LIMIT", 5, 3))
 
 	// Admin login (public)
 	e.POST("/admin/login", h.Admin.Login)
@@ -41,12 +69,42 @@ func Register(e *echo.Echo, h Handlers) {
 	admin.POST("/articles", h.Article.AdminCreate)
 	admin.PUT("/articles/:id", h.Article.AdminUpdate)
 	admin.DELETE("/articles/:id", h.Article.AdminDelete)
+	admin.GET("/articles/:id/revisions", h.Article.AdminListRevisions)
+	admin.GET("/articles/:id/revisions/diff", h.Article.AdminDiffRevisions)
+	admin.GET("/articles/:id/revisions/:rev", h.Article.AdminGetRevision)
+	admin.POST("/articles/:id/revisions/:rev/restore", h.Article.AdminRestoreRevision)
+	admin.GET("/articles/:id/preview-links", h.Article.AdminListPreviewLinks)
+	admin.POST("/articles/:id/preview-links", h.Article.AdminCreatePreviewLink)
+	admin.DELETE("/articles/:id/preview-links/:linkId", h.Article.AdminRevokePreviewLink)
+
+	// manage categories & tags
+	admin.GET("/categories", h.Taxonomy.AdminListCategories)
+	admin.POST("/categories", h.Taxonomy.AdminCreateCategory)
+	admin.PUT("/categories/:id", h.Taxonomy.AdminUpdateCategory)
+	admin.DELETE("/categories/:id", h.Taxonomy.AdminDeleteCategory)
+	admin.GET("/tags", h.Taxonomy.AdminListTags)
+	admin.POST("/tags", h.Taxonomy.AdminCreateTag)
+	admin.PUT("/tags/:id", h.Taxonomy.AdminUpdateTag)
+	admin.DELETE("/tags/:id", h.Taxonomy.AdminDeleteTag)
+
+	// manage admission periods (gelombang)
+	admin.GET("/admission-periods", h.Admission.AdminList)
+	admin.POST("/admission-periods", h.Admission.AdminCreate)
+	admin.GET("/admission-periods/:id", h.Admission.AdminGet)
+	admin.PUT("/admission-periods/:id", h.Admission.AdminUpdate)
+	admin.DELETE("/admission-periods/:id", h.Admission.AdminDelete)
 
 	// manage registrations
 	admin.GET("/registrations", h.Registration.AdminList)
+	admin.GET("/registrations/export", h.Registration.AdminExport)
+	admin.POST("/registrations/import", h.Registration.AdminImport)
 	admin.GET("/registrations/:id", h.Registration.AdminGetByID)
 	admin.PATCH("/registrations/:id/status", h.Registration.AdminUpdateStatus)
+	admin.GET("/registrations/:id/timeline", h.Registration.AdminTimeline)
 	admin.DELETE("/registrations/:id", h.Registration.AdminDelete)
+	admin.GET("/registrations/:id/documents", h.Document.AdminList)
+	admin.GET("/registrations/:id/documents/:docId/download", h.Document.AdminDownload)
+	admin.PATCH("/registrations/:id/documents/:docId", h.Document.AdminReview)
 
 	// manage contacts
 	admin.GET("/contacts", h.Contact.AdminList)
@@ -55,6 +113,24 @@ func Register(e *echo.Echo, h Handlers) {
 	admin.PATCH("/contacts/:id/status", h.Contact.AdminUpdateStatus)
 	admin.DELETE("/contacts/:id", h.Contact.AdminDelete)
 
+	// WhatsApp/SMS delivery log
+	admin.GET("/notifications/deliveries", h.Notification.AdminListDeliveries)
+	admin.POST("/notifications/deliveries/:id/retry", h.Notification.AdminRetryDelivery)
+
+	// trash (soft-deleted articles, registrations, contacts)
+	admin.GET("/trash/:type", h.Trash.List)
+	admin.POST("/trash/:type/:id/restore", h.Trash.Restore)
+
+	// media library
+	admin.GET("/media", h.Media.List)
+	admin.POST("/media", h.Media.Upload)
+	admin.GET("/media/:id", h.Media.Get)
+	admin.GET("/media/:id/usage", h.Media.Usage)
+	admin.DELETE("/media/:id", h.Media.Delete)
+
+	// private files (short-lived signed links)
+	admin.GET("/files/signed-url", h.File.AdminSignedURL)
+
 	// ======================
 	// Superadmin-only routes
 	// ======================
@@ -63,4 +139,6 @@ func Register(e *echo.Echo, h Handlers) {
 	super.GET("/admins", h.Admin.List)
 	super.PUT("/admins/:id", h.Admin.Update)
 	super.DELETE("/admins/:id", h.Admin.Delete)
+	super.DELETE("/trash/:type/:id", h.Trash.Purge)
+	super.GET("/media/orphans", h.Media.Orphans)
 }
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"darulabror/internal/mailer"
+	"darulabror/internal/messaging"
+	"darulabror/internal/service"
+	"log"
+	"net/mail"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// envMailSender builds the SMTP sender from SMTP_* and MAIL_FROM. Without
+// SMTP_HOST mails are only logged.
+func envMailSender() mailer.Sender {
+	host := strings.TrimSpace(os.Getenv("SMTP_HOST"))
+	if host == "" {
+		log.Printf("SMTP_HOST not set: mails are logged, not sent")
+		return mailer.NewLogSender()
+	}
+
+	port, err := strconv.Atoi(envOr("SMTP_PORT", "587"))
+	if err != nil || port <= 0 {
+		log.Fatalf("SMTP_PORT is invalid (expected a port number like 587)")
+	}
+	from, err := mail.ParseAddress(strings.TrimSpace(os.Getenv("MAIL_FROM")))
+	if err != nil {
+		log.Fatalf("MAIL_FROM is required with SMTP_HOST, e.g. \"Darul Abror <noreply@darulabror.com>\": %v", err)
+	}
+
+	return mailer.NewSMTPSender(mailer.SMTPConfig{
+		Host:     host,
+		Port:     port,
+		Username: strings.TrimSpace(os.Getenv("SMTP_USERNAME")),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     *from,
+	})
+}
+
+// envMessageChannel builds the WhatsApp/SMS gateway from MESSAGE_GATEWAY_*.
+// Without MESSAGE_GATEWAY_URL no messages are sent (nor logged as deliveries).
+func envMessageChannel() messaging.Channel {
+	endpoint := strings.TrimSpace(os.Getenv("MESSAGE_GATEWAY_URL"))
+	if endpoint == "" {
+		log.Printf("MESSAGE_GATEWAY_URL not set: WhatsApp/SMS messages are disabled")
+		return nil
+	}
+	if u, err := url.Parse(endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		log.Fatalf("MESSAGE_GATEWAY_URL is invalid (expected an http(s) URL like https://gateway.example.com/send)")
+	}
+
+	return messaging.NewHTTPGateway(messaging.GatewayConfig{
+		Channel:    strings.ToLower(envOr("MESSAGE_GATEWAY_CHANNEL", "whatsapp")),
+		URL:        endpoint,
+		AuthHeader: envOr("MESSAGE_GATEWAY_AUTH_HEADER", "Authorization"),
+		AuthToken:  strings.TrimSpace(os.Getenv("MESSAGE_GATEWAY_TOKEN")),
+		Timeout:    envDuration("MESSAGE_GATEWAY_TIMEOUT", 15*time.Second),
+	})
+}
+
+// envMessageRecipients reads which registration phone numbers get messages
+// (MESSAGE_RECIPIENTS, default all of them).
+func envMessageRecipients() []string {
+	fields := splitList(strings.ToLower(envOr("MESSAGE_RECIPIENTS", strings.Join(service.RegistrationPhoneFields, ","))))
+	for _, f := range fields {
+		if !slices.Contains(service.RegistrationPhoneFields, f) {
+			log.Fatalf("MESSAGE_RECIPIENTS is invalid: %q (expected a comma-separated list of %s)", f, strings.Join(service.RegistrationPhoneFields, ", "))
+		}
+	}
+	return fields
+}
@@ -16,18 +16,22 @@ import (
 	"darulabror/api/routes"
 	"darulabror/config"
 	_ "darulabror/docs"
+	"darulabror/internal/content"
 	"darulabror/internal/handler"
+	"darulabror/internal/jobs"
+	"darulabror/internal/mailer"
 	"darulabror/internal/repository"
 	"darulabror/internal/service"
 	"log"
 	"os"
 	"reflect"
 	"strings"
+	"time"
 
-	"cloud.google.com/go/storage"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	echomw "github.com/labstack/echo/v4/middleware"
+	"github.com/labstack/gommon/bytes"
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
@@ -52,6 +56,9 @@ func main() {
 	e := echo.New()
 	e.HideBanner = true
 	e.Logger.SetOutput(os.Stdout)
+	// Client IP (rate limits) from X-Forwarded-For, trusting only private/loopback
+	// proxies (load balancer), so clients can't pick their own IP
+	e.IPExtractor = echo.ExtractIPFromXFFHeader()
 
 	e.Use(echomw.RequestID())
 	e.Use(echomw.Recover())
@@ -91,6 +98,7 @@ func main() {
 			echo.HeaderContentType,
 			echo.HeaderAccept,
 			echo.HeaderAuthorization,
+			handler.RegistrationTokenHeader,
 		},
 		AllowCredentials: false,
 	}))
@@ -123,21 +131,41 @@ func main() {
 	}
 
 	// ======================
-	// GCS (bucket)
+	// File storage (STORAGE_BACKEND: gcs, s3 or local)
 	// ======================
+	files := newFileStorage(ctx, jwtSecret)
 	publicBucket := os.Getenv("PUBLIC_BUCKET")
+	privateBucket := os.Getenv("PRIVATE_BUCKET")
 
-	var gcsClient *storage.Client
-	if publicBucket != "" {
-		var err error
-		gcsClient, err = storage.NewClient(ctx)
-		if err != nil {
-			log.Fatalf("failed to init gcs client: %v", err)
-		}
+	// Always inject (repo will return ErrStorageNotConfigured if not configured)
+	publicStore := files.store(publicBucket, true)
+	// Sensitive files (e.g. registration documents): only reachable through signed URLs
+	privateStore := files.store(privateBucket, false)
+	uploadsEnabled := files.backend == "local" || publicBucket != ""
+	privateFilesEnabled := files.backend == "local" || privateBucket != ""
+	if files.backend == "local" {
+		// local uploads are served by the API itself (private ones by GET /files/*, signed)
+		e.Static(files.localURLPath(), files.localRoot(true))
 	}
 
-	// Always inject (repo will return ErrStorageNotConfigured if not configured)
-	publicStore := repository.NewGCPStorageRepo(gcsClient, publicBucket, true)
+	// ======================
+	// Public website (links in feeds and sitemap)
+	// ======================
+	// SITE_URL is the website, where article links point. Feed self links and
+	// sitemap files are on API_PUBLIC_URL, this API's public address; leave it
+	// unset only when the website proxies /feeds/*, /sitemap*.xml and
+	// /robots.txt to the API.
+	site := service.SiteConfig{
+		BaseURL:     strings.TrimRight(strings.TrimSpace(os.Getenv("SITE_URL")), "/"),
+		Name:        envOr("SITE_NAME", "Darul Abror"),
+		Description: envOr("SITE_DESCRIPTION", "Berita dan kegiatan Darul Abror"),
+		APIBaseURL:  strings.TrimRight(strings.TrimSpace(os.Getenv("API_PUBLIC_URL")), "/"),
+	}
+	if !site.Configured() {
+		log.Printf("SITE_URL not set: feeds and sitemap are disabled")
+	}
+	// Website pages listed in the sitemap besides articles
+	sitemapPages := splitList(envOr("SITEMAP_STATIC_PATHS", "/,/articles"))
 
 	// ======================
 	// Repositories
@@ -146,23 +174,104 @@ func main() {
 	regRepo := repository.NewRegistrationRepo(db)
 	contactRepo := repository.NewContactRepository(db)
 	adminRepo := repository.NewAdminRepository(db)
+	taxonomyRepo := repository.NewTaxonomyRepo(db)
+	mediaRepo := repository.NewMediaRepo(db)
+	docRepo := repository.NewRegistrationDocumentRepo(db)
+	periodRepo := repository.NewAdmissionPeriodRepo(db)
+	notificationRepo := repository.NewNotificationRepo(db)
+
+	// ======================
+	// Mail (SMTP_HOST unset: mails are only logged)
+	// ======================
+	mailQueue := mailer.NewQueue(envMailSender(), 500)
+	go mailQueue.Run(ctx, 2)
 
 	// ======================
 	// Services
 	// ======================
-	articleSvc := service.NewArticleService(articleRepo, publicStore)
-	regSvc := service.NewRegistrationService(regRepo)
-	contactSvc := service.NewContactService(contactRepo)
+	// Applicants and contact senders get confirmations; admins (ADMIN_NOTIFY_EMAILS) a periodic digest
+	digestInterval := envDuration("ADMIN_DIGEST_INTERVAL", 24*time.Hour)
+	// WhatsApp/SMS through the HTTP gateway (MESSAGE_GATEWAY_URL); logged and retried as deliveries
+	deliverySvc := service.NewDeliveryService(notificationRepo, envMessageChannel(), envMessageRecipients())
+	notifySvc := service.NewNotificationService(mailQueue, deliverySvc, regRepo, contactRepo, notificationRepo, site, splitList(os.Getenv("ADMIN_NOTIFY_EMAILS")), digestInterval)
+	articleSvc := service.NewArticleService(articleRepo, taxonomyRepo, mediaRepo, site)
+	// Unreferenced article uploads younger than this are never collected (covers saves in progress)
+	uploadPolicy := envUploadPolicy()
+	mediaSvc := service.NewMediaService(mediaRepo, articleRepo, publicStore, uploadPolicy, envDuration("MEDIA_GC_GRACE", 7*24*time.Hour))
+	// Signed links to private files stay valid this long
+	fileSvc := service.NewFileService(privateStore, envDuration("PRIVATE_URL_TTL", 15*time.Minute))
+	// Applicants upload their documents with the token returned on registration, until it expires
+	regSvc := service.NewRegistrationService(regRepo, periodRepo, docRepo, notifySvc, envDuration("REGISTRATION_UPLOAD_TOKEN_TTL", 30*24*time.Hour))
+	periodSvc := service.NewAdmissionPeriodService(periodRepo)
+	docSvc := service.NewRegistrationDocumentService(regRepo, docRepo, privateStore, fileSvc, uploadPolicy)
+	contactSvc := service.NewContactService(contactRepo, notifySvc)
 	adminSvc := service.NewAdminService(adminRepo, jwtSecret)
+	taxonomySvc := service.NewTaxonomyService(taxonomyRepo)
+	feedSvc := service.NewFeedService(articleRepo, site)
+	sitemapSvc := service.NewSitemapService(articleRepo, site, sitemapPages)
+	// Deleted articles/registrations/contacts stay in the trash this long (0 = until purged by hand)
+	trashSvc := service.NewTrashService(articleRepo, regRepo, contactRepo, envDuration("TRASH_RETENTION", 30*24*time.Hour))
+
+	// ======================
+	// Background jobs
+	// ======================
+	// Scheduled publishing: flips due "scheduled" articles to "published" and expired ones back to "draft".
+	// Visibility is already time-based in queries, this just materializes the status.
+	go jobs.Every(ctx, "article-scheduler", envDuration("ARTICLE_SCHEDULER_INTERVAL", time.Minute), func(ctx context.Context) error {
+		_, _, err := articleSvc.ProcessSchedule(time.Now())
+		return err
+	})
+	go jobs.Every(ctx, "trash-purge", envDuration("TRASH_PURGE_INTERVAL", time.Hour), func(ctx context.Context) error {
+		_, err := trashSvc.PurgeExpired(time.Now())
+		return err
+	})
+	// Orphaned media: deletes article uploads no article references anymore (see GET /admin/media/orphans).
+	// Skipped while uploads aren't configured.
+	go jobs.Every(ctx, "media-gc", envDuration("MEDIA_GC_INTERVAL", 24*time.Hour), func(ctx context.Context) error {
+		if !uploadsEnabled {
+			return nil
+		}
+		_, err := mediaSvc.CollectOrphans(ctx, time.Now(), false)
+		return err
+	})
+	// Registration documents left behind by purged registrations (their rows cascade, the files don't).
+	go jobs.Every(ctx, "registration-documents-gc", envDuration("DOCUMENT_GC_INTERVAL", 24*time.Hour), func(ctx context.Context) error {
+		if !privateFilesEnabled {
+			return nil
+		}
+		_, err := docSvc.CollectOrphans(ctx, time.Now())
+		return err
+	})
+	// WhatsApp/SMS deliveries due (new ones and retries).
+	go jobs.Every(ctx, "message-delivery", envDuration("MESSAGE_DELIVERY_INTERVAL", 30*time.Second), func(ctx context.Context) error {
+		_, err := deliverySvc.ProcessDue(ctx, time.Now())
+		return err
+	})
+	// Admin digest: checked every 10 minutes (or each interval when shorter), sent once per window.
+	go jobs.Every(ctx, "admin-digest", min(digestInterval, 10*time.Minute), func(ctx context.Context) error {
+		return notifySvc.SendAdminDigest(ctx, time.Now())
+	})
 
 	// ======================
 	// Handlers
 	// ======================
+	// Article content blocks: unknown block types are rejected unless CONTENT_UNKNOWN_BLOCKS=allow
+	contentBlocks := content.NewRegistry(envUnknownBlocks())
+
 	h := routes.Handlers{
-		Article:      handler.NewArticleHandler(articleSvc),
+		Article:      handler.NewArticleHandler(articleSvc, mediaSvc, contentBlocks),
 		Registration: handler.NewRegistrationHandler(regSvc),
 		Contact:      handler.NewContactHandler(contactSvc),
 		Admin:        handler.NewAdminHandler(adminSvc),
+		Taxonomy:     handler.NewTaxonomyHandler(taxonomySvc),
+		Trash:        handler.NewTrashHandler(trashSvc),
+		Feed:         handler.NewFeedHandler(feedSvc),
+		Sitemap:      handler.NewSitemapHandler(sitemapSvc),
+		Media:        handler.NewMediaHandler(mediaSvc),
+		File:         handler.NewFileHandler(fileSvc),
+		Document:     handler.NewRegistrationDocumentHandler(docSvc),
+		Admission:    handler.NewAdmissionPeriodHandler(periodSvc),
+		Notification: handler.NewNotificationHandler(deliverySvc),
 	}
 
 	// ======================
@@ -202,5 +311,82 @@ func main() {
 	if err := e.Start(":" + port); err != nil {
 		log.Fatalf("failed to start server: %v", err)
 	}
-	_ = ctx
+}
+
+// envOr returns the trimmed env value, or def when unset/empty.
+func envOr(key, def string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	return def
+}
+
+// splitList splits a comma-separated value, dropping empty entries.
+func splitList(raw string) []string {
+	out := make([]string, 0)
+	for _, v := range strings.Split(raw, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// envDuration parses a Go duration (e.g. "30s", "5m") from env, falling back to def.
+func envDuration(key string, def time.Duration) time.Duration {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return def
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Fatalf("%s is invalid (expected duration like 30s, 5m): %v", key, err)
+	}
+	return d
+}
+
+// envBytes parses a size (e.g. "512K", "10M") from env, falling back to def.
+func envBytes(key string, def int64) int64 {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return def
+	}
+	n, err := bytes.Parse(raw)
+	if err != nil || n < 0 {
+		log.Fatalf("%s is invalid (expected size like 512K, 10M)", key)
+	}
+	return n
+}
+
+// envUploadPolicy reads the upload allowlists (comma-separated MIME types, "image/*"
+// matches a family) and size limits, keeping the defaults for unset variables.
+func envUploadPolicy() service.UploadPolicy {
+	policy := service.DefaultUploadPolicy()
+	for slot, key := range map[service.UploadSlot]string{
+		service.UploadSlotArticleHeader:  "UPLOAD_ALLOWED_HEADER",
+		service.UploadSlotArticleContent: "UPLOAD_ALLOWED_CONTENT",
+		service.UploadSlotLibrary:        "UPLOAD_ALLOWED_MEDIA",
+		service.UploadSlotRegistration:   "UPLOAD_ALLOWED_DOCUMENT",
+	} {
+		if raw := strings.TrimSpace(os.Getenv(key)); raw != "" {
+			policy.Allowed[slot] = splitList(strings.ToLower(raw))
+		}
+	}
+	policy.MaxImageSize = envBytes("UPLOAD_MAX_IMAGE_SIZE", policy.MaxImageSize)
+	policy.MaxVideoSize = envBytes("UPLOAD_MAX_VIDEO_SIZE", policy.MaxVideoSize)
+	policy.MaxFileSize = envBytes("UPLOAD_MAX_FILE_SIZE", policy.MaxFileSize)
+	return policy
+}
+
+// envUnknownBlocks reads CONTENT_UNKNOWN_BLOCKS: "reject" (default) or "allow".
+func envUnknownBlocks() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("CONTENT_UNKNOWN_BLOCKS"))) {
+	case "", "reject":
+		return false
+	case "allow":
+		return true
+	default:
+		log.Fatalf("CONTENT_UNKNOWN_BLOCKS must be reject or allow")
+		return false
+	}
 }
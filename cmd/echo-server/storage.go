@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"darulabror/internal/repository"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// fileStorage builds the file stores for the configured STORAGE_BACKEND:
+//
+//   - gcs (default): Google Cloud Storage, credentials from the environment (ADC);
+//     private URLs are signed with GCS_SIGNING_KEY_FILE / GCS_SIGNING_KEY when set
+//   - s3: any S3-compatible service (AWS S3, MinIO, ...), see S3_* variables
+//   - local: files on disk under LOCAL_STORAGE_DIR; public files are served by the
+//     API, private ones through HMAC-signed links on GET /files/*
+type fileStorage struct {
+	backend string
+
+	gcsClient *storage.Client
+	gcsSigner *repository.GCSSigner
+	s3Client  *minio.Client
+	s3URL     *url.URL
+
+	localDir        string
+	localURL        string
+	localSigningKey []byte
+}
+
+func newFileStorage(ctx context.Context, jwtSecret string) *fileStorage {
+	st := &fileStorage{backend: strings.ToLower(envOr("STORAGE_BACKEND", "gcs"))}
+
+	switch st.backend {
+	case "gcs":
+		// without a bucket the stores just report ErrStorageNotConfigured
+		if os.Getenv("PUBLIC_BUCKET") == "" && os.Getenv("PRIVATE_BUCKET") == "" {
+			return st
+		}
+		client, err := storage.NewClient(ctx)
+		if err != nil {
+			log.Fatalf("failed to init gcs client: %v", err)
+		}
+		st.gcsClient = client
+		st.gcsSigner = envGCSSigner()
+
+	case "s3":
+		raw := strings.TrimSpace(os.Getenv("S3_ENDPOINT"))
+		if raw == "" {
+			log.Fatal("S3_ENDPOINT is required for STORAGE_BACKEND=s3, e.g. https://s3.ap-southeast-1.amazonaws.com or http://localhost:9000")
+		}
+		if !strings.Contains(raw, "://") {
+			raw = "https://" + raw
+		}
+		endpoint, err := url.Parse(raw)
+		if err != nil || endpoint.Host == "" {
+			log.Fatalf("S3_ENDPOINT is invalid: %q", raw)
+		}
+		client, err := minio.New(endpoint.Host, &minio.Options{
+			Creds:  credentials.NewStaticV4(os.Getenv("S3_ACCESS_KEY"), os.Getenv("S3_SECRET_KEY"), ""),
+			Secure: endpoint.Scheme == "https",
+			Region: os.Getenv("S3_REGION"),
+		})
+		if err != nil {
+			log.Fatalf("failed to init s3 client: %v", err)
+		}
+		st.s3Client, st.s3URL = client, endpoint
+
+	case "local":
+		st.localDir = envOr("LOCAL_STORAGE_DIR", "uploads")
+		st.localURL = strings.TrimRight(envOr("LOCAL_STORAGE_URL", "/uploads"), "/")
+		if _, err := url.Parse(st.localURL); err != nil || st.localURLPath() == "" {
+			log.Fatalf("LOCAL_STORAGE_URL is invalid: %q (expected a path like /uploads or a URL ending in one)", st.localURL)
+		}
+		if err := os.MkdirAll(st.localRoot(true), 0o755); err != nil {
+			log.Fatalf("failed to create LOCAL_STORAGE_DIR: %v", err)
+		}
+		// a key of its own, so file links can't be turned into tokens or vice versa
+		mac := hmac.New(sha256.New, []byte(jwtSecret))
+		mac.Write([]byte("local-storage-links"))
+		st.localSigningKey = mac.Sum(nil)
+
+	default:
+		log.Fatalf("STORAGE_BACKEND must be gcs, s3 or local")
+	}
+	return st
+}
+
+// store returns a store for bucket (ignored by the local backend, which keeps
+// public and private files in separate directories).
+func (st *fileStorage) store(bucket string, isPublic bool) repository.StorageRepo {
+	switch st.backend {
+	case "s3":
+		// public objects: S3_PUBLIC_URL (e.g. a CDN) or path-style <endpoint>/<bucket>
+		publicURL := envOr("S3_PUBLIC_URL", st.s3URL.Scheme+"://"+st.s3URL.Host+"/"+bucket)
+		return repository.NewS3StorageRepo(st.s3Client, bucket, publicURL, isPublic)
+	case "local":
+		if isPublic {
+			return repository.NewLocalStorageRepo(st.localRoot(true), st.localURL, true, nil)
+		}
+		return repository.NewLocalStorageRepo(st.localRoot(false), st.localPrivateURL(), false, st.localSigningKey)
+	default:
+		return repository.NewGCPStorageRepo(st.gcsClient, bucket, isPublic, st.gcsSigner)
+	}
+}
+
+// localRoot is the directory of the local public or private files.
+func (st *fileStorage) localRoot(isPublic bool) string {
+	if isPublic {
+		return filepath.Join(st.localDir, "public")
+	}
+	return filepath.Join(st.localDir, "private")
+}
+
+// localPrivateURL is where signed links to private local files point: GET /files/*
+// on the same host as LOCAL_STORAGE_URL.
+func (st *fileStorage) localPrivateURL() string {
+	u, err := url.Parse(st.localURL)
+	if err != nil || u.Host == "" {
+		return "/files"
+	}
+	return u.Scheme + "://" + u.Host + "/files"
+}
+
+// localURLPath is the route public local files are served under.
+func (st *fileStorage) localURLPath() string {
+	u, err := url.Parse(st.localURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(u.Path, "/")
+}
+
+// envGCSSigner reads the service account used to sign private GCS URLs from
+// GCS_SIGNING_KEY_FILE (path to a JSON key) or GCS_SIGNING_KEY (the JSON itself).
+// Without one, signing uses the client's credentials or the metadata server.
+func envGCSSigner() *repository.GCSSigner {
+	data := []byte(os.Getenv("GCS_SIGNING_KEY"))
+	if path := strings.TrimSpace(os.Getenv("GCS_SIGNING_KEY_FILE")); path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			log.Fatalf("failed to read GCS_SIGNING_KEY_FILE: %v", err)
+		}
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil
+	}
+
+	signer, err := repository.ParseGCSServiceAccountKey(data)
+	if err != nil {
+		log.Fatalf("GCS signing key is invalid (expected a service account JSON key): %v", err)
+	}
+	return signer
+}
@@ -271,34 +271,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/articles": {
+        "/admin/admission-periods": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns draft + published.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Articles (Admin)"
+                    "Admission Periods (Admin)"
                 ],
-                "summary": "Admin list all articles",
+                "summary": "Admin list admission periods",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Page size",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "Filter by academic year, e.g. 2025/2026",
+                        "name": "academic_year",
                         "in": "query"
                     }
                 ],
@@ -306,7 +297,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.ArticleListResponse"
+                            "$ref": "#/definitions/internal_handler.AdmissionPeriodListResponse"
                         }
                     },
                     "401": {
@@ -335,72 +326,33 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
+                "description": "Periods can't overlap. Quotas are optional (omitted or null = no limit).",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Articles (Admin)"
+                    "Admission Periods (Admin)"
                 ],
-                "summary": "Admin create article (multipart)",
+                "summary": "Admin create admission period",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Title",
-                        "name": "title",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Author",
-                        "name": "author",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "draft",
-                            "published"
-                        ],
-                        "type": "string",
-                        "description": "draft|published",
-                        "name": "status",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "JSON string (flexible)",
-                        "name": "content",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Optional header URL (ignored if photo_header_file is provided)",
-                        "name": "photo_header",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Optional header image file (uploaded and set to photo_header)",
-                        "name": "photo_header_file",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Inline media files. Use field name: content_files[\u003cupload_key\u003e] (repeatable). Example: content_files[img1], content_files[vid1]",
-                        "name": "content_files",
-                        "in": "formData"
+                        "description": "Admission period payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/darulabror_internal_dto.AdmissionPeriodDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_AdmissionPeriodDTO"
                         }
                     },
                     "400": {
@@ -421,6 +373,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
@@ -436,87 +394,104 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/articles/{id}": {
-            "put": {
+        "/admin/admission-periods/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "multipart/form-data"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Articles (Admin)"
+                    "Admission Periods (Admin)"
                 ],
-                "summary": "Admin update article (multipart)",
+                "summary": "Admin get admission period",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Article ID",
+                        "description": "Admission period ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_AdmissionPeriodDTO"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Title",
-                        "name": "title",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Author",
-                        "name": "author",
-                        "in": "formData",
-                        "required": true
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
                     },
-                    {
-                        "enum": [
-                            "draft",
-                            "published"
-                        ],
-                        "type": "string",
-                        "description": "draft|published",
-                        "name": "status",
-                        "in": "formData"
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "JSON string (flexible)",
-                        "name": "content",
-                        "in": "formData",
-                        "required": true
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Optional header URL (ignored if photo_header_file is provided)",
-                        "name": "photo_header",
-                        "in": "formData"
-                    },
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lowering a quota below the seats already taken only stops new registrations; existing ones are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admission Periods (Admin)"
+                ],
+                "summary": "Admin update admission period",
+                "parameters": [
                     {
-                        "type": "file",
-                        "description": "Optional header image file (uploaded and set to photo_header)",
-                        "name": "photo_header_file",
-                        "in": "formData"
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Admission period ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Inline media files. Use field name: content_files[\u003cupload_key\u003e] (repeatable). Example: content_files[img1], content_files[vid1]",
-                        "name": "content_files",
-                        "in": "formData"
+                        "description": "Admission period payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/darulabror_internal_dto.AdmissionPeriodDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_AdmissionPeriodDTO"
                         }
                     },
                     "400": {
@@ -537,6 +512,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
@@ -557,18 +544,19 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
+                "description": "Only periods without registrations (trashed ones included) can be deleted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Articles (Admin)"
+                    "Admission Periods (Admin)"
                 ],
-                "summary": "Admin delete article",
+                "summary": "Admin delete admission period",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Article ID",
+                        "description": "Admission period ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -599,6 +587,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -608,20 +608,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/contacts": {
+        "/admin/articles": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Returns draft + published + scheduled.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Contacts (Admin)"
+                    "Articles (Admin)"
                 ],
-                "summary": "Admin list contacts",
+                "summary": "Admin list all articles",
                 "parameters": [
                     {
                         "type": "integer",
@@ -638,14 +639,21 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "enum": [
-                            "new",
-                            "in_progress",
-                            "done"
-                        ],
                         "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
+                        "description": "Filter by category slug",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by tag slug",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search; results are ordered by relevance",
+                        "name": "q",
                         "in": "query"
                     }
                 ],
@@ -653,7 +661,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.ContactListResponse"
+                            "$ref": "#/definitions/internal_handler.ArticleListResponse"
                         }
                     },
                     "401": {
@@ -675,44 +683,123 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/contacts/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Contacts (Admin)"
+                    "Articles (Admin)"
                 ],
-                "summary": "Admin get contact by ID",
+                "summary": "Admin create article (multipart)",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "Contact ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Title",
+                        "name": "title",
+                        "in": "formData",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/internal_handler.SuccessResponse-internal_handler_ContactListItem"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/internal_handler.ErrorResponse"
-                        }
+                    {
+                        "type": "string",
+                        "description": "Optional custom slug (auto-generated from title if empty)",
+                        "name": "slug",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Author",
+                        "name": "author",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "published",
+                            "scheduled"
+                        ],
+                        "type": "string",
+                        "description": "draft|published|scheduled (defaults to scheduled when publish_at is set)",
+                        "name": "status",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Go-live time for scheduled articles: RFC3339 (2025-01-01T07:00:00+07:00) or unix seconds",
+                        "name": "publish_at",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional expiry (article goes back to draft): RFC3339 or unix seconds",
+                        "name": "unpublish_at",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON string with a blocks array; each block is validated against its type (422 lists every problem, e.g. blocks[3].url is required)",
+                        "name": "content",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated category IDs (on update: omit to keep, send empty to clear)",
+                        "name": "category_ids",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag IDs (on update: omit to keep, send empty to clear)",
+                        "name": "tag_ids",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional header URL (ignored if photo_header_file is provided)",
+                        "name": "photo_header",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Optional header image file (uploaded to the media library and set to photo_header). Images only: 415 for other types, 413 over the size limit",
+                        "name": "photo_header_file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Optional media library item to use as header (ignored if photo_header_file is provided)",
+                        "name": "photo_header_media_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Inline media files, added to the media library. Use field name: content_files[\u003cupload_key\u003e] (repeatable). Example: content_files[img1], content_files[vid1]. Existing library items are referenced with media_id in the block instead. Types are sniffed from the content: images, video and PDF (image blocks take images, video blocks video); 415/413 before anything is uploaded",
+                        "name": "content_files",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -726,14 +813,34 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/articles/{id}": {
             "put": {
                 "security": [
                     {
@@ -741,32 +848,115 @@ const docTemplate = `{
                     }
                 ],
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Contacts (Admin)"
+                    "Articles (Admin)"
                 ],
-                "summary": "Admin update contact",
+                "summary": "Admin update article (multipart)",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Contact ID",
+                        "description": "Article ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Contact payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_handler.ContactUpdateRequest"
-                        }
+                        "type": "string",
+                        "description": "Title",
+                        "name": "title",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional custom slug (auto-generated from title if empty)",
+                        "name": "slug",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Author",
+                        "name": "author",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "published",
+                            "scheduled"
+                        ],
+                        "type": "string",
+                        "description": "draft|published|scheduled (defaults to scheduled when publish_at is set)",
+                        "name": "status",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Go-live time for scheduled articles: RFC3339 (2025-01-01T07:00:00+07:00) or unix seconds (omit to keep; dropped when the status isn't scheduled)",
+                        "name": "publish_at",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional expiry (article goes back to draft): RFC3339 or unix seconds (omit to keep)",
+                        "name": "unpublish_at",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Remove the expiry",
+                        "name": "clear_unpublish_at",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON string with a blocks array; each block is validated against its type (422 lists every problem, e.g. blocks[3].url is required)",
+                        "name": "content",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated category IDs (on update: omit to keep, send empty to clear)",
+                        "name": "category_ids",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag IDs (on update: omit to keep, send empty to clear)",
+                        "name": "tag_ids",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional header URL (ignored if photo_header_file is provided)",
+                        "name": "photo_header",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Optional header image file (uploaded to the media library and set to photo_header). Images only: 415 for other types, 413 over the size limit",
+                        "name": "photo_header_file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Optional media library item to use as header (ignored if photo_header_file is provided)",
+                        "name": "photo_header_media_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Inline media files, added to the media library. Use field name: content_files[\u003cupload_key\u003e] (repeatable). Example: content_files[img1], content_files[vid1]. Existing library items are referenced with media_id in the block instead. Types are sniffed from the content: images, video and PDF (image blocks take images, video blocks video); 415/413 before anything is uploaded",
+                        "name": "content_files",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
@@ -794,6 +984,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
@@ -818,14 +1026,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Contacts (Admin)"
+                    "Articles (Admin)"
                 ],
-                "summary": "Admin delete contact",
+                "summary": "Admin delete article",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Contact ID",
+                        "description": "Article ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -865,47 +1073,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/contacts/{id}/status": {
-            "patch": {
+        "/admin/articles/{id}/preview-links": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Newest first, including expired and revoked links (tokens are not shown).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Contacts (Admin)"
+                    "Articles (Admin)"
                 ],
-                "summary": "Admin update contact status",
+                "summary": "Admin list article preview links",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Contact ID",
+                        "description": "Article ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Status payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_handler.ContactStatusUpdateRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-array_darulabror_internal_dto_PreviewLinkDTO"
                         }
                     },
                     "400": {
@@ -932,12 +1129,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "$ref": "#/definitions/internal_handler.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -945,11 +1136,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/login": {
+            },
             "post": {
-                "description": "Returns JWT token for accessing /admin endpoints.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mints an expiring token that lets anyone read the article (draft included) via GET /articles/preview/{token}.\nThe token is only returned here; store it or share the url right away.",
                 "consumes": [
                     "application/json"
                 ],
@@ -957,25 +1151,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth (Admin)"
+                    "Articles (Admin)"
                 ],
-                "summary": "Admin login",
+                "summary": "Admin create article preview link",
                 "parameters": [
                     {
-                        "description": "Login payload",
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Article ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional lifetime (hours, default 72, max 720) and note",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.AdminLoginRequest"
+                            "$ref": "#/definitions/darulabror_internal_dto.PreviewLinkCreateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.AdminLoginResponse"
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_PreviewLinkDTO"
                         }
                     },
                     "400": {
@@ -996,50 +1197,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "$ref": "#/definitions/internal_handler.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/admin/profile": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Admins (Admin)"
-                ],
-                "summary": "Get admin profile (from JWT)",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_AdminDTO"
-                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
@@ -1047,32 +1218,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/profile/password": {
-            "patch": {
+        "/admin/articles/{id}/preview-links/{linkId}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admins (Admin)"
+                    "Articles (Admin)"
                 ],
-                "summary": "Admin change own password",
+                "summary": "Admin revoke article preview link",
                 "parameters": [
                     {
-                        "description": "Password change payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_handler.AdminChangePasswordRequest"
-                        }
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Article ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Preview link ID",
+                        "name": "linkId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1094,8 +1269,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
@@ -1109,21 +1290,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/registrations": {
+        "/admin/articles/{id}/revisions": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Newest first; content is omitted (fetch a single revision for it).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Registrations (Admin)"
+                    "Articles (Admin)"
                 ],
-                "summary": "Admin list registrations",
+                "summary": "Admin list article revisions",
                 "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Article ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -1137,25 +1327,19 @@ const docTemplate = `{
                         "description": "Page size",
                         "name": "limit",
                         "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "new",
-                            "validate",
-                            "process",
-                            "done"
-                        ],
-                        "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.RegistrationListResponse"
+                            "$ref": "#/definitions/internal_handler.ArticleRevisionListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
                     "401": {
@@ -1170,6 +1354,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1179,35 +1369,52 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/registrations/{id}": {
+        "/admin/articles/{id}/revisions/diff": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Changed fields plus a block-by-block diff of content (op: equal, added, removed, changed).\nContent blocks are read from content.blocks, or content itself when it is an array.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Registrations (Admin)"
+                    "Articles (Admin)"
                 ],
-                "summary": "Admin get registration by ID",
+                "summary": "Admin diff two article revisions",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Registration ID",
+                        "description": "Article ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Older revision number",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Newer revision number",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_RegistrationDTO"
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_ArticleRevisionDiffDTO"
                         }
                     },
                     "400": {
@@ -1233,10 +1440,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/articles/{id}/revisions/{rev}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -1246,24 +1461,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Registrations (Admin)"
+                    "Articles (Admin)"
                 ],
-                "summary": "Admin delete registration",
+                "summary": "Admin get article revision",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Registration ID",
+                        "description": "Article ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "rev",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_ArticleRevisionDTO"
                         }
                     },
                     "400": {
@@ -1284,6 +1507,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1293,45 +1522,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/registrations/{id}/status": {
-            "patch": {
+        "/admin/articles/{id}/revisions/{rev}/restore": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Makes the revision's title, photo header, content and author current, recorded as a new revision.\nSlug, status, schedule and categories/tags are kept as they are.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Registrations (Admin)"
+                    "Articles (Admin)"
                 ],
-                "summary": "Admin update registration status",
+                "summary": "Admin restore article revision",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Registration ID",
+                        "description": "Article ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Status payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_handler.RegistrationStatusUpdateRequest"
-                        }
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "rev",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
@@ -1360,12 +1586,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "$ref": "#/definitions/internal_handler.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1375,37 +1595,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/articles": {
+        "/admin/categories": {
             "get": {
-                "description": "Returns only articles with status \"published\".",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "article_count includes drafts.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Articles (Public)"
-                ],
-                "summary": "List published articles",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Page size",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                    "Categories (Admin)"
                 ],
+                "summary": "Admin list categories",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.ArticleListResponse"
+                            "$ref": "#/definitions/internal_handler.CategoryListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -1415,32 +1636,39 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/articles/{id}": {
-            "get": {
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Articles (Public)"
+                    "Categories (Admin)"
                 ],
-                "summary": "Get published article by ID",
+                "summary": "Admin create category",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "Article ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Category payload (slug optional)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/darulabror_internal_dto.CategoryDTO"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_ArticleDTO"
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_CategoryDTO"
                         }
                     },
                     "400": {
@@ -1449,8 +1677,32 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
@@ -1458,8 +1710,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/contacts": {
-            "post": {
+        "/admin/categories/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -1467,23 +1724,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Contacts (Public)"
+                    "Categories (Admin)"
                 ],
-                "summary": "Create contact message",
+                "summary": "Admin update category",
                 "parameters": [
                     {
-                        "description": "Contact payload",
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Category payload (slug optional)",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.ContactCreateRequest"
+                            "$ref": "#/definitions/darulabror_internal_dto.CategoryDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
@@ -1494,6 +1759,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
@@ -1507,34 +1796,34 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/registrations": {
-            "post": {
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Articles in the category are kept, only unlinked.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Registrations (Public)"
+                    "Categories (Admin)"
                 ],
-                "summary": "Create registration",
+                "summary": "Admin delete category",
                 "parameters": [
                     {
-                        "description": "Registration payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/darulabror_internal_dto.RegistrationDTO"
-                        }
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
                             "type": "string"
                         }
@@ -1545,8 +1834,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
@@ -1559,285 +1860,5208 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "darulabror_internal_dto.AdminDTO": {
-            "type": "object",
-            "required": [
-                "email",
-                "role",
-                "username"
-            ],
-            "properties": {
-                "created_at": {
-                    "type": "integer"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "password": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 6
-                },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "admin",
-                        "superadmin"
-                    ]
-                },
-                "updated_at": {
-                    "type": "integer"
-                },
-                "username": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 3
+        },
+        "/admin/contacts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contacts (Admin)"
+                ],
+                "summary": "Admin list contacts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "new",
+                            "in_progress",
+                            "done"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ContactListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "darulabror_internal_dto.ArticleDTO": {
-            "type": "object",
-            "required": [
-                "author",
-                "content",
-                "photo_header",
-                "title"
-            ],
-            "properties": {
-                "author": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 3
-                },
-                "content": {
-                    "type": "array",
+        "/admin/contacts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contacts (Admin)"
+                ],
+                "summary": "Admin get contact by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Contact ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-internal_handler_ContactListItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contacts (Admin)"
+                ],
+                "summary": "Admin update contact",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Contact ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Contact payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ContactUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contacts (Admin)"
+                ],
+                "summary": "Admin delete contact",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Contact ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/contacts/{id}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contacts (Admin)"
+                ],
+                "summary": "Admin update contact status",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Contact ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ContactStatusUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/files/signed-url": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Private files (PRIVATE_BUCKET, or the local disk backend) are never public. This returns a short-lived link (PRIVATE_URL_TTL, default 15m) to one of them; every issued link is logged with the admin ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files (Admin)"
+                ],
+                "summary": "Admin get a signed URL for a private file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Object name, e.g. registrations/12/akta.pdf",
+                        "name": "object",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-dto_SignedURLDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/login": {
+            "post": {
+                "description": "Returns JWT token for accessing /admin endpoints.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth (Admin)"
+                ],
+                "summary": "Admin login",
+                "parameters": [
+                    {
+                        "description": "Login payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.AdminLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.AdminLoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/media": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Newest first, each item with usage_count (articles using it, trashed ones included).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media (Admin)"
+                ],
+                "summary": "Admin list media library",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search in file name",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "image",
+                            "video",
+                            "audio",
+                            "document"
+                        ],
+                        "type": "string",
+                        "description": "Filter by kind",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.MediaListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "JPEG, PNG and WebP images are auto-rotated, stripped of EXIF/GPS metadata and stored as resized variants (srcset, widest = url); the original is not kept.\nOther files (GIF included) are stored unchanged. The returned id can be used as media_id in article content blocks or as photo_header_media_id.\nThe type is sniffed from the file content (images, video and PDF by default); other types get 415, files over the per-type size limit 413.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media (Admin)"
+                ],
+                "summary": "Admin upload to media library (multipart)",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File to upload",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-dto_MediaDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/media/orphans": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists article uploads (objects under articles/) that no article, trashed ones included, references as photo_header or in content.\nOnly objects older than MEDIA_GC_GRACE are listed as orphans; nothing is deleted. The media-gc job deletes exactly these.\nReferences are matched by object name, so URLs saved under an older bucket or public URL still count. 409 when articles reference uploads but none through the store's current base URL; the job then deletes nothing.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media (Admin)"
+                ],
+                "summary": "Superadmin orphaned media report (dry run)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-dto_MediaGCReportDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/media/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media (Admin)"
+                ],
+                "summary": "Admin get media item",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Media ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-dto_MediaDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the item and its file. Items still used by an article (see /usage) are refused with 409.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media (Admin)"
+                ],
+                "summary": "Admin delete media item",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Media ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/media/{id}/usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Articles whose content (by media_id or URL) or header use the item, most recently updated first. Trashed articles are included with trashed=true.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media (Admin)"
+                ],
+                "summary": "Admin list articles using a media item",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Media ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.MediaUsageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Every message sent (or to be sent) to a registration's phone numbers, newest first, with its retry state: pending deliveries are retried with a growing delay until they are sent or fail.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications (Admin)"
+                ],
+                "summary": "Admin list WhatsApp/SMS deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "sent",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by registration ID",
+                        "name": "registration_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.NotificationDeliveryListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/deliveries/{id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queues a failed (or pending) delivery for a fresh round of attempts, starting with the next delivery run.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications (Admin)"
+                ],
+                "summary": "Admin retry a WhatsApp/SMS delivery",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-dto_NotificationDeliveryDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admins (Admin)"
+                ],
+                "summary": "Get admin profile (from JWT)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_AdminDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/profile/password": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admins (Admin)"
+                ],
+                "summary": "Admin change own password",
+                "parameters": [
+                    {
+                        "description": "Password change payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.AdminChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/registrations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registrations (Admin)"
+                ],
+                "summary": "Admin list registrations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "new",
+                            "validate",
+                            "process",
+                            "done",
+                            "rejected",
+                            "withdrawn"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by admission period ID",
+                        "name": "period_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by academic year of the admission period, e.g. 2025/2026",
+                        "name": "academic_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered on or after this day (WIB), YYYY-MM-DD",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered on or before this day (WIB), YYYY-MM-DD",
+                        "name": "created_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.RegistrationListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/registrations/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "All registrations matching the filters of GET /admin/registrations (no pagination), with Indonesian column headers, as a download. Rows are streamed from the database in batches.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Registrations (Admin)"
+                ],
+                "summary": "Admin export registrations (CSV / XLSX)",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "new",
+                            "validate",
+                            "process",
+                            "done",
+                            "rejected",
+                            "withdrawn"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by admission period ID",
+                        "name": "period_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by academic year of the admission period, e.g. 2025/2026",
+                        "name": "academic_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered on or after this day (WIB), YYYY-MM-DD",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered on or before this day (WIB), YYYY-MM-DD",
+                        "name": "created_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Spreadsheet",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/registrations/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registrations from a spreadsheet, e.g. paper forms typed in by the office. The first row holds the column headers: those of GET /admin/registrations/export or the JSON field names of dto.RegistrationDTO (other columns are ignored), so an export can be edited and imported again.\nEvery row is checked like POST /registrations (same field rules, email and NISN not used yet, also not by an earlier row) and reported on. Dates may be YYYY-MM-DD or DD/MM/YYYY. Imported registrations join the given admission period (default: the open one) within its quota; they get no upload token and no notifications.\ndry_run only checks the rows. atomic stores all rows or none: a single invalid row cancels the import. Otherwise the valid rows are stored and the invalid ones reported.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registrations (Admin)"
+                ],
+                "summary": "Admin import registrations (CSV / XLSX)",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Spreadsheet (.csv or .xlsx, at most 2000 rows)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Admission period ID (default: the open period)",
+                        "name": "period_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Only check the rows",
+                        "name": "dry_run",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Store all rows or none",
+                        "name": "atomic",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_RegistrationImportReportDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/registrations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registrations (Admin)"
+                ],
+                "summary": "Admin get registration by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_RegistrationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registrations (Admin)"
+                ],
+                "summary": "Admin delete registration",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/registrations/{id}/documents": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registrations (Admin)"
+                ],
+                "summary": "Admin list documents of a registration",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_RegistrationDocumentChecklistDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/registrations/{id}/documents/{docId}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "A rejected document needs a reason; the applicant sees it and uploads the document again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registrations (Admin)"
+                ],
+                "summary": "Admin verify or reject a registration document",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Document ID",
+                        "name": "docId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/darulabror_internal_dto.RegistrationDocumentReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_RegistrationDocumentDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/registrations/{id}/documents/{docId}/download": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a short-lived signed URL (PRIVATE_URL_TTL); every issued link is logged with the admin ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registrations (Admin)"
+                ],
+                "summary": "Admin get a download link for a registration document",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Document ID",
+                        "name": "docId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_SignedURLDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/registrations/{id}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Allowed transitions: new → validate → process → done; rejected from new/validate/process; withdrawn from new/validate/process/done. rejected and withdrawn are final; other changes get 409. Every change is recorded in the timeline with the admin and the note.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registrations (Admin)"
+                ],
+                "summary": "Admin update registration status",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.RegistrationStatusUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/registrations/{id}/timeline": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Every status change, oldest first: from/to status, the admin who made it and their note. The first entry is the creation.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registrations (Admin)"
+                ],
+                "summary": "Admin get the status timeline of a registration",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.RegistrationTimelineResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "article_count includes drafts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags (Admin)"
+                ],
+                "summary": "Admin list tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.TagListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags (Admin)"
+                ],
+                "summary": "Admin create tag",
+                "parameters": [
+                    {
+                        "description": "Tag payload (slug optional)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/darulabror_internal_dto.TagDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_TagDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tags/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags (Admin)"
+                ],
+                "summary": "Admin update tag",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag payload (slug optional)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/darulabror_internal_dto.TagDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags (Admin)"
+                ],
+                "summary": "Admin delete tag",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/trash/{type}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft-deleted items of one type, most recently deleted first. purge_at is when the item is deleted for good.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash (Admin)"
+                ],
+                "summary": "Admin list trashed items",
+                "parameters": [
+                    {
+                        "enum": [
+                            "articles",
+                            "registrations",
+                            "contacts"
+                        ],
+                        "type": "string",
+                        "description": "Entity type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.TrashListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/trash/{type}/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Only items already in the trash can be purged. This cannot be undone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash (Superadmin)"
+                ],
+                "summary": "Superadmin permanently delete trashed item",
+                "parameters": [
+                    {
+                        "enum": [
+                            "articles",
+                            "registrations",
+                            "contacts"
+                        ],
+                        "type": "string",
+                        "description": "Entity type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/trash/{type}/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash (Admin)"
+                ],
+                "summary": "Admin restore trashed item",
+                "parameters": [
+                    {
+                        "enum": [
+                            "articles",
+                            "registrations",
+                            "contacts"
+                        ],
+                        "type": "string",
+                        "description": "Entity type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admission-periods/current": {
+            "get": {
+                "description": "The period open now, with the seats left per quota (null = no limit). Between periods, the next one to open (is_open false, no remaining). server_time is the API's clock, for countdowns.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admission Periods"
+                ],
+                "summary": "Get the current admission period",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_AdmissionPeriodDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles": {
+            "get": {
+                "description": "Returns only articles with status \"published\".",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Articles (Public)"
+                ],
+                "summary": "List published articles",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category slug",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by tag slug",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ArticleListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/preview/{token}": {
+            "get": {
+                "description": "Returns the article (any status) exactly like the public detail endpoint, while the link is neither expired nor revoked.\nResponses are marked noindex and not cacheable.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Articles (Public)"
+                ],
+                "summary": "Preview article through a preview link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Preview token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "html",
+                            "text"
+                        ],
+                        "type": "string",
+                        "description": "Also render content as sanitized HTML (content_html) or plain text (content_text)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_ArticleDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/search": {
+            "get": {
+                "description": "Full-text search over title (weighted higher) and content text blocks, ranked by relevance.\nSupports web-search syntax: \"exact phrase\", -exclude, OR. Matches in title_highlight / snippet are wrapped in \u003cmark\u003e.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Articles (Public)"
+                ],
+                "summary": "Search published articles",
+                "parameters": [
+                    {
+                        "maxLength": 200,
+                        "minLength": 2,
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category slug",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by tag slug",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ArticleSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/slug/{slug}": {
+            "get": {
+                "description": "Old slugs (before a rename) answer with 301 to the current slug.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Articles (Public)"
+                ],
+                "summary": "Get published article by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Article slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "html",
+                            "text"
+                        ],
+                        "type": "string",
+                        "description": "Also render content as sanitized HTML (content_html) or plain text (content_text)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_ArticleDTO"
+                        }
+                    },
+                    "301": {
+                        "description": "Moved Permanently",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Articles (Public)"
+                ],
+                "summary": "Get published article by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Article ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "html",
+                            "text"
+                        ],
+                        "type": "string",
+                        "description": "Also render content as sanitized HTML (content_html) or plain text (content_text)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_ArticleDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "description": "Each category includes article_count = number of published articles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories (Public)"
+                ],
+                "summary": "List categories",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.CategoryListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/contacts": {
+            "post": {
+                "description": "The sender gets an acknowledgement mail. Rate limited per IP (CONTACT_RATE_LIMIT, 429 with Retry-After).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contacts (Public)"
+                ],
+                "summary": "Create contact message",
+                "parameters": [
+                    {
+                        "description": "Contact payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ContactCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/articles.atom": {
+            "get": {
+                "description": "Latest 50 published articles. Supports conditional GET (If-None-Match / If-Modified-Since → 304).",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "Feeds (Public)"
+                ],
+                "summary": "Atom feed of published articles",
+                "responses": {
+                    "200": {
+                        "description": "Atom document",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/articles.rss": {
+            "get": {
+                "description": "Latest 50 published articles. Supports conditional GET (If-None-Match / If-Modified-Since → 304).",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "Feeds (Public)"
+                ],
+                "summary": "RSS 2.0 feed of published articles",
+                "responses": {
+                    "200": {
+                        "description": "RSS document",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{path}": {
+            "get": {
+                "description": "Only used by the local disk storage backend: links from /admin/files/signed-url point here. GCS and S3 links go to the bucket directly.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Download a private file through a signed link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Object name",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Expiry (unix seconds)",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link signature",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/registrations": {
+            "post": {
+                "description": "Only accepted while an admission period is open (409 when none is, or its quota for the gender / student type is full); the registration is assigned to that period.\nReturns the reference number (for the office and POST /registrations/status-lookup) and the upload token for the registration's documents (POST /registrations/documents). The token is shown only once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registrations (Public)"
+                ],
+                "summary": "Create registration",
+                "parameters": [
+                    {
+                        "description": "Registration payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/darulabror_internal_dto.RegistrationDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_RegistrationCreatedDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/registrations/documents": {
+            "get": {
+                "description": "Lists the uploaded documents with their review status, and the document types still missing (never uploaded, or rejected: upload those again).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registrations (Public)"
+                ],
+                "summary": "Get the documents of my registration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload token returned by POST /registrations",
+                        "name": "X-Registration-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_RegistrationDocumentChecklistDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "One document per type; uploading a type again replaces it (and resets its review), unless it was already verified.\nFiles are stored privately. Scans must be JPEG, PNG or PDF (UPLOAD_ALLOWED_DOCUMENT), pas_foto an image.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registrations (Public)"
+                ],
+                "summary": "Upload a registration document (multipart)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload token returned by POST /registrations",
+                        "name": "X-Registration-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "akta_kelahiran",
+                            "kartu_keluarga",
+                            "rapor",
+                            "pas_foto"
+                        ],
+                        "type": "string",
+                        "description": "Document type",
+                        "name": "type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Document file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_RegistrationDocumentDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/registrations/status-lookup": {
+            "post": {
+                "description": "Identify the registration by reference_number (or nisn) plus date_of_birth. Returns the status, its timeline and the documents still to upload or rejected.\nUnknown identifiers and wrong dates of birth get the same 404. Rate limited per IP (STATUS_LOOKUP_RATE_LIMIT, 429 with Retry-After).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registrations (Public)"
+                ],
+                "summary": "Look up the status of my registration",
+                "parameters": [
+                    {
+                        "description": "Lookup payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/darulabror_internal_dto.RegistrationStatusLookupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse-darulabror_internal_dto_RegistrationStatusLookupDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/robots.txt": {
+            "get": {
+                "description": "Keeps crawlers out of /admin/ and points them to the sitemap.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "SEO (Public)"
+                ],
+                "summary": "robots.txt",
+                "responses": {
+                    "200": {
+                        "description": "robots.txt",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/sitemap.xml": {
+            "get": {
+                "description": "Static pages + every published article (lastmod = updated_at), with URLs on SITE_URL.\nBeyond 50,000 URLs this is a sitemap index pointing to /sitemaps/{n}.xml.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "SEO (Public)"
+                ],
+                "summary": "XML sitemap",
+                "responses": {
+                    "200": {
+                        "description": "urlset or sitemapindex document",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sitemaps/{page}": {
+            "get": {
+                "description": "One part of a split sitemap, e.g. /sitemaps/2.xml.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "SEO (Public)"
+                ],
+                "summary": "XML sitemap part",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Part file name, e.g. 2.xml",
+                        "name": "page",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "urlset document",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Each tag includes article_count = number of published articles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags (Public)"
+                ],
+                "summary": "List tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.TagListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "darulabror_internal_dto.AdminDTO": {
+            "type": "object",
+            "required": [
+                "email",
+                "role",
+                "username"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 6
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "superadmin"
+                    ]
+                },
+                "updated_at": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3
+                }
+            }
+        },
+        "darulabror_internal_dto.AdmissionPeriodDTO": {
+            "type": "object",
+            "required": [
+                "academic_year",
+                "closes_at",
+                "opens_at",
+                "wave_name"
+            ],
+            "properties": {
+                "academic_year": {
+                    "type": "string",
+                    "example": "2025/2026"
+                },
+                "closes_at": {
+                    "type": "string",
+                    "example": "2025-03-31T23:59:59+07:00"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_open": {
+                    "description": "Read-only",
+                    "type": "boolean"
+                },
+                "opens_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00+07:00"
+                },
+                "quota_female": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "quota_male": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "quota_new": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "quota_transfer": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "remaining": {
+                    "description": "seats left (current period only)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/darulabror_internal_dto.AdmissionSeatsDTO"
+                        }
+                    ]
+                },
+                "server_time": {
+                    "description": "for countdowns (current period only)",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "wave_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Gelombang 1"
+                }
+            }
+        },
+        "darulabror_internal_dto.AdmissionSeatsDTO": {
+            "type": "object",
+            "properties": {
+                "female": {
+                    "type": "integer"
+                },
+                "male": {
+                    "type": "integer"
+                },
+                "new": {
+                    "type": "integer"
+                },
+                "transfer": {
+                    "type": "integer"
+                }
+            }
+        },
+        "darulabror_internal_dto.ArticleDTO": {
+            "type": "object",
+            "required": [
+                "author",
+                "content",
+                "photo_header",
+                "title"
+            ],
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.CategoryDTO"
+                    }
+                },
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "content_html": {
+                    "description": "Rendered content, only filled when requested with ?format=html|text",
+                    "type": "string"
+                },
+                "content_text": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "excerpt": {
+                    "description": "read-only, generated from content",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "photo_header": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "photo_header_srcset": {
+                    "description": "Read-only: resized renditions of the header (widest = photo_header), empty when it isn't a processed image.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_models.ImageSource"
+                    }
+                },
+                "publish_at": {
+                    "description": "unix seconds, required for scheduled",
+                    "type": "integer",
+                    "example": 1735693200
+                },
+                "slug": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "published",
+                        "scheduled"
+                    ]
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.TagDTO"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "unpublish_at": {
+                    "description": "unix seconds, optional",
+                    "type": "integer",
+                    "example": 1736298000
+                },
+                "updated_at": {
+                    "type": "integer"
+                }
+            }
+        },
+        "darulabror_internal_dto.ArticleRevisionDTO": {
+            "type": "object",
+            "properties": {
+                "admin_id": {
+                    "type": "integer"
+                },
+                "article_id": {
+                    "type": "integer"
+                },
+                "author": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "photo_header": {
+                    "type": "string"
+                },
+                "restored_from": {
+                    "type": "integer"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "darulabror_internal_dto.ArticleRevisionDiffDTO": {
+            "type": "object",
+            "properties": {
+                "article_id": {
+                    "type": "integer"
+                },
+                "blocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.BlockChangeDTO"
+                    }
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.FieldChangeDTO"
+                    }
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "darulabror_internal_dto.ArticleSearchResultDTO": {
+            "type": "object",
+            "required": [
+                "author",
+                "content",
+                "photo_header",
+                "title"
+            ],
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.CategoryDTO"
+                    }
+                },
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "content_html": {
+                    "description": "Rendered content, only filled when requested with ?format=html|text",
+                    "type": "string"
+                },
+                "content_text": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "excerpt": {
+                    "description": "read-only, generated from content",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "photo_header": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "photo_header_srcset": {
+                    "description": "Read-only: resized renditions of the header (widest = photo_header), empty when it isn't a processed image.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_models.ImageSource"
+                    }
+                },
+                "publish_at": {
+                    "description": "unix seconds, required for scheduled",
+                    "type": "integer",
+                    "example": 1735693200
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "slug": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "snippet": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "published",
+                        "scheduled"
+                    ]
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.TagDTO"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "title_highlight": {
+                    "type": "string"
+                },
+                "unpublish_at": {
+                    "description": "unix seconds, optional",
+                    "type": "integer",
+                    "example": 1736298000
+                },
+                "updated_at": {
+                    "type": "integer"
+                }
+            }
+        },
+        "darulabror_internal_dto.BlockChangeDTO": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "changed_keys": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "from_index": {
+                    "type": "integer"
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "equal",
+                        "added",
+                        "removed",
+                        "changed"
+                    ]
+                },
+                "to_index": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "darulabror_internal_dto.CategoryDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "article_count": {
+                    "description": "Number of published articles (public listing) or all articles (admin listing).",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2
+                },
+                "slug": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "updated_at": {
+                    "type": "integer"
+                }
+            }
+        },
+        "darulabror_internal_dto.FieldChangeDTO": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "darulabror_internal_dto.MediaDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mime_type": {
+                    "type": "string"
+                },
+                "object_name": {
+                    "type": "string"
+                },
+                "size": {
+                    "description": "bytes",
+                    "type": "integer"
+                },
+                "srcset": {
+                    "description": "Processed images: resized renditions, narrowest first (empty for other files).",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_models.ImageSource"
+                    }
+                },
+                "uploaded_by": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "usage_count": {
+                    "description": "Number of articles using the item (listing only).",
+                    "type": "integer"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "darulabror_internal_dto.MediaGCObjectDTO": {
+            "type": "object",
+            "properties": {
+                "media_id": {
+                    "description": "media library item for the object, if any",
+                    "type": "integer"
+                },
+                "object_name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "darulabror_internal_dto.MediaGCReportDTO": {
+            "type": "object",
+            "properties": {
+                "base_url": {
+                    "description": "what the store puts before object names in URLs (\"\" for private stores)",
+                    "type": "string"
+                },
+                "cutoff": {
+                    "description": "unix seconds; only objects last written before this are collected",
+                    "type": "integer"
+                },
+                "deleted": {
+                    "description": "objects actually deleted (0 on dry run)",
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "grace_period": {
+                    "description": "e.g. \"168h0m0s\"",
+                    "type": "string"
+                },
+                "items": {
+                    "description": "the orphans",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.MediaGCObjectDTO"
+                    }
+                },
+                "media_deleted": {
+                    "description": "media library rows removed with them",
+                    "type": "integer"
+                },
+                "orphan_bytes": {
+                    "description": "total size of the orphans",
+                    "type": "integer"
+                },
+                "orphans": {
+                    "description": "unreferenced and older than the cutoff",
+                    "type": "integer"
+                },
+                "prefix": {
+                    "description": "storage prefix that was scanned",
+                    "type": "string"
+                },
+                "referenced": {
+                    "description": "used by an article (trashed included)",
+                    "type": "integer"
+                },
+                "scanned": {
+                    "description": "objects under the prefix",
+                    "type": "integer"
+                },
+                "within_grace": {
+                    "description": "unreferenced but too recent to collect",
+                    "type": "integer"
+                }
+            }
+        },
+        "darulabror_internal_dto.MediaUsageDTO": {
+            "type": "object",
+            "properties": {
+                "article_id": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "trashed": {
+                    "description": "article is in the trash but may still be restored",
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "integer"
+                }
+            }
+        },
+        "darulabror_internal_dto.NotificationDeliveryDTO": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "channel": {
+                    "type": "string",
+                    "example": "whatsapp"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string",
+                    "example": "registration_received"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "description": "RFC3339; empty once sent or failed",
+                    "type": "string"
+                },
+                "recipient": {
+                    "type": "string",
+                    "example": "+6281234567890"
+                },
+                "registration_id": {
+                    "type": "integer"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/darulabror_internal_models.DeliveryStatus"
+                        }
+                    ],
+                    "example": "pending"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "darulabror_internal_dto.PreviewLinkCreateRequest": {
+            "type": "object",
+            "properties": {
+                "expires_in_hours": {
+                    "description": "default 72",
+                    "type": "integer",
+                    "maximum": 720,
+                    "minimum": 1,
+                    "example": 72
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Kepala sekolah"
+                }
+            }
+        },
+        "darulabror_internal_dto.PreviewLinkDTO": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "not revoked and not expired",
+                    "type": "boolean"
+                },
+                "article_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "integer"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "website preview page, when SITE_URL is set",
+                    "type": "string"
+                }
+            }
+        },
+        "darulabror_internal_dto.RegistrationCreatedDTO": {
+            "type": "object",
+            "properties": {
+                "reference_number": {
+                    "description": "Quoted to the office and used for the status lookup (POST /registrations/status-lookup)",
+                    "type": "string"
+                },
+                "upload_token": {
+                    "description": "Authorizes document uploads (POST /registrations/documents, header\nX-Registration-Token). Shown once; keep it.",
+                    "type": "string"
+                },
+                "upload_token_expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "darulabror_internal_dto.RegistrationDTO": {
+            "type": "object",
+            "required": [
+                "address",
+                "date_of_birth",
+                "date_of_birth_father",
+                "date_of_birth_mother",
+                "email",
+                "father_name",
+                "father_occupation",
+                "full_name",
+                "gender",
+                "mother_name",
+                "mother_occupation",
+                "nisn",
+                "origin_school",
+                "phone",
+                "phone_father",
+                "phone_mother",
+                "place_of_birth",
+                "student_type"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "admission_period_id": {
+                    "description": "Read-only: assigned on create",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "date_of_birth_father": {
+                    "type": "string"
+                },
+                "date_of_birth_mother": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "father_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "father_occupation": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "full_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "gender": {
+                    "enum": [
+                        "male",
+                        "female"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/darulabror_internal_models.Gender"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mother_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "mother_occupation": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "nisn": {
+                    "type": "string"
+                },
+                "origin_school": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 13,
+                    "minLength": 10
+                },
+                "phone_father": {
+                    "type": "string",
+                    "maxLength": 13,
+                    "minLength": 10
+                },
+                "phone_mother": {
+                    "type": "string",
+                    "maxLength": 13,
+                    "minLength": 10
+                },
+                "place_of_birth": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "reference_number": {
+                    "description": "read-only",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/darulabror_internal_models.RegistrationStatus"
+                },
+                "student_type": {
+                    "enum": [
+                        "new",
+                        "transfer"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/darulabror_internal_models.StudentType"
+                        }
+                    ]
+                }
+            }
+        },
+        "darulabror_internal_dto.RegistrationDocumentChecklistDTO": {
+            "type": "object",
+            "properties": {
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.RegistrationDocumentDTO"
+                    }
+                },
+                "missing": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_models.RegistrationDocumentType"
+                    }
+                }
+            }
+        },
+        "darulabror_internal_dto.RegistrationDocumentDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mime_type": {
+                    "type": "string"
+                },
+                "registration_id": {
+                    "type": "integer"
+                },
+                "reject_reason": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "bytes",
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/darulabror_internal_models.DocumentStatus"
+                },
+                "type": {
+                    "$ref": "#/definitions/darulabror_internal_models.RegistrationDocumentType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "darulabror_internal_dto.RegistrationDocumentReviewRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "required when rejected",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "verified",
+                        "rejected"
+                    ]
+                }
+            }
+        },
+        "darulabror_internal_dto.RegistrationImportReportDTO": {
+            "type": "object",
+            "properties": {
+                "admission_period_id": {
+                    "type": "integer"
+                },
+                "atomic": {
+                    "type": "boolean"
+                },
+                "committed": {
+                    "description": "false when nothing was stored",
+                    "type": "boolean"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "invalid": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.RegistrationImportRowDTO"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "description": "rows that passed the checks, stored or not",
+                    "type": "integer"
+                }
+            }
+        },
+        "darulabror_internal_dto.RegistrationImportRowDTO": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "nisn": {
+                    "type": "string"
+                },
+                "reference_number": {
+                    "type": "string"
+                },
+                "row": {
+                    "description": "line in the spreadsheet; the header is row 1",
+                    "type": "integer",
+                    "example": 2
+                },
+                "status": {
+                    "type": "string",
+                    "example": "created"
+                }
+            }
+        },
+        "darulabror_internal_dto.RegistrationStatusHistoryDTO": {
+            "type": "object",
+            "properties": {
+                "admin_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "$ref": "#/definitions/darulabror_internal_models.RegistrationStatus"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "$ref": "#/definitions/darulabror_internal_models.RegistrationStatus"
+                }
+            }
+        },
+        "darulabror_internal_dto.RegistrationStatusLookupDTO": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "missing_documents": {
+                    "description": "Documents still to upload: never uploaded, or rejected (with the reason)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_models.RegistrationDocumentType"
+                    }
+                },
+                "reference_number": {
+                    "type": "string"
+                },
+                "rejected_documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.RejectedDocumentDTO"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/darulabror_internal_models.RegistrationStatus"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "timeline": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.RegistrationStatusStepDTO"
+                    }
+                },
+                "wave_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "darulabror_internal_dto.RegistrationStatusLookupRequest": {
+            "type": "object",
+            "required": [
+                "date_of_birth"
+            ],
+            "properties": {
+                "date_of_birth": {
+                    "type": "string",
+                    "example": "2010-05-17"
+                },
+                "nisn": {
+                    "type": "string",
+                    "example": "1234567890"
+                },
+                "reference_number": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "DA25-7K3M9Q"
+                }
+            }
+        },
+        "darulabror_internal_dto.RegistrationStatusStepDTO": {
+            "type": "object",
+            "properties": {
+                "changed_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/darulabror_internal_models.RegistrationStatus"
+                }
+            }
+        },
+        "darulabror_internal_dto.RejectedDocumentDTO": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/darulabror_internal_models.RegistrationDocumentType"
+                }
+            }
+        },
+        "darulabror_internal_dto.SignedURLDTO": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "unix seconds",
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "darulabror_internal_dto.TagDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "article_count": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2
+                },
+                "slug": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "updated_at": {
+                    "type": "integer"
+                }
+            }
+        },
+        "darulabror_internal_dto.TrashItemDTO": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "item": {
+                    "type": "object"
+                },
+                "label": {
+                    "description": "title / full name / subject",
+                    "type": "string",
+                    "example": "Ahmad Fauzi"
+                },
+                "purge_at": {
+                    "description": "when it is purged automatically; nil when retention is off",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "registrations"
+                }
+            }
+        },
+        "darulabror_internal_models.DeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "sent",
+                "failed"
+            ],
+            "x-enum-comments": {
+                "DeliveryFailed": "gave up; admins can retry it",
+                "DeliveryPending": "waiting for its (next) attempt",
+                "DeliverySent": "accepted by the gateway"
+            },
+            "x-enum-descriptions": [
+                "waiting for its (next) attempt",
+                "accepted by the gateway",
+                "gave up; admins can retry it"
+            ],
+            "x-enum-varnames": [
+                "DeliveryPending",
+                "DeliverySent",
+                "DeliveryFailed"
+            ]
+        },
+        "darulabror_internal_models.DocumentStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "verified",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "DocumentPending",
+                "DocumentVerified",
+                "DocumentRejected"
+            ]
+        },
+        "darulabror_internal_models.Gender": {
+            "type": "string",
+            "enum": [
+                "male",
+                "female"
+            ],
+            "x-enum-varnames": [
+                "Male",
+                "Female"
+            ]
+        },
+        "darulabror_internal_models.ImageSource": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "darulabror_internal_models.RegistrationDocumentType": {
+            "type": "string",
+            "enum": [
+                "akta_kelahiran",
+                "kartu_keluarga",
+                "rapor",
+                "pas_foto"
+            ],
+            "x-enum-varnames": [
+                "DocumentBirthCertificate",
+                "DocumentFamilyCard",
+                "DocumentReportCard",
+                "DocumentPhoto"
+            ]
+        },
+        "darulabror_internal_models.RegistrationStatus": {
+            "type": "string",
+            "enum": [
+                "new",
+                "validate",
+                "process",
+                "done",
+                "rejected",
+                "withdrawn"
+            ],
+            "x-enum-varnames": [
+                "RegistrationStatusNew",
+                "RegistrationStatusValidate",
+                "RegistrationStatusProcess",
+                "RegistrationStatusDone",
+                "RegistrationStatusRejected",
+                "RegistrationStatusWithdrawn"
+            ]
+        },
+        "darulabror_internal_models.StudentType": {
+            "type": "string",
+            "enum": [
+                "new",
+                "transfer"
+            ],
+            "x-enum-varnames": [
+                "StudentNew",
+                "StudentTransfer"
+            ]
+        },
+        "internal_handler.AdminChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "OldPassword123"
+                },
+                "new_password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "NewPassword456"
+                }
+            }
+        },
+        "internal_handler.AdminListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_handler.ListResponseData-darulabror_internal_dto_AdminDTO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_handler.AdminLoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "admin@darulabror.com"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 6,
+                    "example": "StrongPassword123"
+                }
+            }
+        },
+        "internal_handler.AdminLoginResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_handler.AdminLoginResponseData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_handler.AdminLoginResponseData": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "$ref": "#/definitions/darulabror_internal_dto.AdminDTO"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "internal_handler.AdmissionPeriodListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.AdmissionPeriodDTO"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_handler.ArticleListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_handler.ListResponseData-darulabror_internal_dto_ArticleDTO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_handler.ArticleRevisionListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_handler.ListResponseData-darulabror_internal_dto_ArticleRevisionDTO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_handler.ArticleSearchResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_handler.ListResponseData-darulabror_internal_dto_ArticleSearchResultDTO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_handler.CategoryListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.CategoryDTO"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_handler.ContactCreateRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "message",
+                "subject"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "message": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "minLength": 3,
+                    "example": "Hello, I would like to ask..."
+                },
+                "subject": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "minLength": 3,
+                    "example": "Question about registration"
+                }
+            }
+        },
+        "internal_handler.ContactListItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer",
+                    "example": 1734567890
+                },
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Hello..."
+                },
+                "status": {
+                    "type": "string",
+                    "example": "new"
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "Question"
+                }
+            }
+        },
+        "internal_handler.ContactListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_handler.ListResponseData-internal_handler_ContactListItem"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_handler.ContactStatusUpdateRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "new",
+                        "in_progress",
+                        "done"
+                    ],
+                    "example": "in_progress"
+                }
+            }
+        },
+        "internal_handler.ContactUpdateRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "message",
+                "subject"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "message": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "minLength": 3,
+                    "example": "Hello, I would like to ask..."
+                },
+                "subject": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "minLength": 3,
+                    "example": "Question about registration"
+                }
+            }
+        },
+        "internal_handler.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "something went wrong"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "internal_handler.ListResponseData-darulabror_internal_dto_AdminDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.AdminDTO"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/internal_handler.PaginationMeta"
+                }
+            }
+        },
+        "internal_handler.ListResponseData-darulabror_internal_dto_ArticleDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.ArticleDTO"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/internal_handler.PaginationMeta"
+                }
+            }
+        },
+        "internal_handler.ListResponseData-darulabror_internal_dto_ArticleRevisionDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.ArticleRevisionDTO"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/internal_handler.PaginationMeta"
+                }
+            }
+        },
+        "internal_handler.ListResponseData-darulabror_internal_dto_ArticleSearchResultDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.ArticleSearchResultDTO"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/internal_handler.PaginationMeta"
+                }
+            }
+        },
+        "internal_handler.ListResponseData-darulabror_internal_dto_MediaDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.MediaDTO"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/internal_handler.PaginationMeta"
+                }
+            }
+        },
+        "internal_handler.ListResponseData-darulabror_internal_dto_NotificationDeliveryDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.NotificationDeliveryDTO"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/internal_handler.PaginationMeta"
+                }
+            }
+        },
+        "internal_handler.ListResponseData-darulabror_internal_dto_RegistrationDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.RegistrationDTO"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/internal_handler.PaginationMeta"
+                }
+            }
+        },
+        "internal_handler.ListResponseData-darulabror_internal_dto_TrashItemDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/darulabror_internal_dto.TrashItemDTO"
                     }
                 },
-                "created_at": {
-                    "type": "integer"
+                "meta": {
+                    "$ref": "#/definitions/internal_handler.PaginationMeta"
+                }
+            }
+        },
+        "internal_handler.ListResponseData-internal_handler_ContactListItem": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_handler.ContactListItem"
+                    }
                 },
-                "id": {
-                    "type": "integer"
+                "meta": {
+                    "$ref": "#/definitions/internal_handler.PaginationMeta"
+                }
+            }
+        },
+        "internal_handler.MediaListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_handler.ListResponseData-darulabror_internal_dto_MediaDTO"
                 },
-                "photo_header": {
+                "message": {
                     "type": "string",
-                    "maxLength": 2000
+                    "example": "OK"
                 },
                 "status": {
                     "type": "string",
-                    "enum": [
-                        "draft",
-                        "published"
-                    ]
-                },
-                "title": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
-                },
-                "updated_at": {
-                    "type": "integer"
+                    "example": "success"
                 }
             }
         },
-        "darulabror_internal_dto.RegistrationDTO": {
+        "internal_handler.MediaUsageResponse": {
             "type": "object",
-            "required": [
-                "address",
-                "date_of_birth",
-                "date_of_birth_father",
-                "date_of_birth_mother",
-                "email",
-                "father_name",
-                "father_occupation",
-                "full_name",
-                "gender",
-                "mother_name",
-                "mother_occupation",
-                "nisn",
-                "origin_school",
-                "phone",
-                "phone_father",
-                "phone_mother",
-                "place_of_birth",
-                "student_type"
-            ],
             "properties": {
-                "address": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "date_of_birth": {
-                    "type": "string"
-                },
-                "date_of_birth_father": {
-                    "type": "string"
-                },
-                "date_of_birth_mother": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "father_name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.MediaUsageDTO"
+                    }
                 },
-                "father_occupation": {
+                "message": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
+                    "example": "OK"
                 },
-                "full_name": {
+                "status": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
-                },
-                "gender": {
-                    "enum": [
-                        "male",
-                        "female"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/darulabror_internal_models.Gender"
-                        }
-                    ]
-                },
-                "id": {
-                    "type": "integer"
+                    "example": "success"
+                }
+            }
+        },
+        "internal_handler.NotificationDeliveryListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_handler.ListResponseData-darulabror_internal_dto_NotificationDeliveryDTO"
                 },
-                "mother_name": {
+                "message": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
+                    "example": "OK"
                 },
-                "mother_occupation": {
+                "status": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
-                },
-                "nisn": {
-                    "type": "string"
+                    "example": "success"
+                }
+            }
+        },
+        "internal_handler.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "origin_school": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
+                "page": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "phone": {
-                    "type": "string",
-                    "maxLength": 13,
-                    "minLength": 10
+                "total": {
+                    "type": "integer",
+                    "example": 123
+                }
+            }
+        },
+        "internal_handler.RegistrationListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_handler.ListResponseData-darulabror_internal_dto_RegistrationDTO"
                 },
-                "phone_father": {
+                "message": {
                     "type": "string",
-                    "maxLength": 13,
-                    "minLength": 10
+                    "example": "OK"
                 },
-                "phone_mother": {
+                "status": {
                     "type": "string",
-                    "maxLength": 13,
-                    "minLength": 10
-                },
-                "place_of_birth": {
+                    "example": "success"
+                }
+            }
+        },
+        "internal_handler.RegistrationStatusUpdateRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "note": {
+                    "description": "recorded in the timeline",
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
+                    "maxLength": 1000,
+                    "example": "Berkas lengkap"
                 },
                 "status": {
-                    "$ref": "#/definitions/darulabror_internal_models.RegistrationStatus"
-                },
-                "student_type": {
+                    "type": "string",
                     "enum": [
                         "new",
-                        "transfer"
+                        "validate",
+                        "process",
+                        "done",
+                        "rejected",
+                        "withdrawn"
                     ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/darulabror_internal_models.StudentType"
-                        }
-                    ]
+                    "example": "validate"
                 }
             }
         },
-        "darulabror_internal_models.Gender": {
-            "type": "string",
-            "enum": [
-                "male",
-                "female"
-            ],
-            "x-enum-varnames": [
-                "Male",
-                "Female"
-            ]
-        },
-        "darulabror_internal_models.RegistrationStatus": {
-            "type": "string",
-            "enum": [
-                "new",
-                "validate",
-                "process",
-                "done"
-            ],
-            "x-enum-varnames": [
-                "RegistrationStatusNew",
-                "RegistrationStatusValidate",
-                "RegistrationStatusProcess",
-                "RegistrationStatusDone"
-            ]
-        },
-        "darulabror_internal_models.StudentType": {
-            "type": "string",
-            "enum": [
-                "new",
-                "transfer"
-            ],
-            "x-enum-varnames": [
-                "StudentNew",
-                "StudentTransfer"
-            ]
-        },
-        "internal_handler.AdminChangePasswordRequest": {
+        "internal_handler.RegistrationTimelineResponse": {
             "type": "object",
-            "required": [
-                "current_password",
-                "new_password"
-            ],
             "properties": {
-                "current_password": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.RegistrationStatusHistoryDTO"
+                    }
+                },
+                "message": {
                     "type": "string",
-                    "minLength": 6,
-                    "example": "OldPassword123"
+                    "example": "OK"
                 },
-                "new_password": {
+                "status": {
                     "type": "string",
-                    "minLength": 6,
-                    "example": "NewPassword456"
+                    "example": "success"
                 }
             }
         },
-        "internal_handler.AdminListResponse": {
+        "internal_handler.SuccessResponse-array_darulabror_internal_dto_PreviewLinkDTO": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/internal_handler.ListResponseData-darulabror_internal_dto_AdminDTO"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.PreviewLinkDTO"
+                    }
                 },
                 "message": {
                     "type": "string",
@@ -1849,30 +7073,27 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_handler.AdminLoginRequest": {
+        "internal_handler.SuccessResponse-darulabror_internal_dto_AdminDTO": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "data": {
+                    "$ref": "#/definitions/darulabror_internal_dto.AdminDTO"
+                },
+                "message": {
                     "type": "string",
-                    "example": "admin@darulabror.com"
+                    "example": "OK"
                 },
-                "password": {
+                "status": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 6,
-                    "example": "StrongPassword123"
+                    "example": "success"
                 }
             }
         },
-        "internal_handler.AdminLoginResponse": {
+        "internal_handler.SuccessResponse-darulabror_internal_dto_AdmissionPeriodDTO": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/internal_handler.AdminLoginResponseData"
+                    "$ref": "#/definitions/darulabror_internal_dto.AdmissionPeriodDTO"
                 },
                 "message": {
                     "type": "string",
@@ -1884,23 +7105,27 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_handler.AdminLoginResponseData": {
+        "internal_handler.SuccessResponse-darulabror_internal_dto_ArticleDTO": {
             "type": "object",
             "properties": {
-                "admin": {
-                    "$ref": "#/definitions/darulabror_internal_dto.AdminDTO"
+                "data": {
+                    "$ref": "#/definitions/darulabror_internal_dto.ArticleDTO"
                 },
-                "token": {
+                "message": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "OK"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
                 }
             }
         },
-        "internal_handler.ArticleListResponse": {
+        "internal_handler.SuccessResponse-darulabror_internal_dto_ArticleRevisionDTO": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/internal_handler.ListResponseData-darulabror_internal_dto_ArticleDTO"
+                    "$ref": "#/definitions/darulabror_internal_dto.ArticleRevisionDTO"
                 },
                 "message": {
                     "type": "string",
@@ -1912,66 +7137,59 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_handler.ContactCreateRequest": {
+        "internal_handler.SuccessResponse-darulabror_internal_dto_ArticleRevisionDiffDTO": {
             "type": "object",
-            "required": [
-                "email",
-                "message",
-                "subject"
-            ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
+                "data": {
+                    "$ref": "#/definitions/darulabror_internal_dto.ArticleRevisionDiffDTO"
                 },
                 "message": {
                     "type": "string",
-                    "maxLength": 2000,
-                    "minLength": 3,
-                    "example": "Hello, I would like to ask..."
+                    "example": "OK"
                 },
-                "subject": {
+                "status": {
                     "type": "string",
-                    "maxLength": 150,
-                    "minLength": 3,
-                    "example": "Question about registration"
+                    "example": "success"
                 }
             }
         },
-        "internal_handler.ContactListItem": {
+        "internal_handler.SuccessResponse-darulabror_internal_dto_CategoryDTO": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "integer",
-                    "example": 1734567890
+                "data": {
+                    "$ref": "#/definitions/darulabror_internal_dto.CategoryDTO"
                 },
-                "email": {
+                "message": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": "OK"
                 },
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_handler.SuccessResponse-darulabror_internal_dto_PreviewLinkDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/darulabror_internal_dto.PreviewLinkDTO"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Hello..."
+                    "example": "OK"
                 },
                 "status": {
                     "type": "string",
-                    "example": "new"
-                },
-                "subject": {
-                    "type": "string",
-                    "example": "Question"
+                    "example": "success"
                 }
             }
         },
-        "internal_handler.ContactListResponse": {
+        "internal_handler.SuccessResponse-darulabror_internal_dto_RegistrationCreatedDTO": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/internal_handler.ListResponseData-internal_handler_ContactListItem"
+                    "$ref": "#/definitions/darulabror_internal_dto.RegistrationCreatedDTO"
                 },
                 "message": {
                     "type": "string",
@@ -1983,140 +7201,139 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_handler.ContactStatusUpdateRequest": {
+        "internal_handler.SuccessResponse-darulabror_internal_dto_RegistrationDTO": {
             "type": "object",
-            "required": [
-                "status"
-            ],
             "properties": {
+                "data": {
+                    "$ref": "#/definitions/darulabror_internal_dto.RegistrationDTO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                },
                 "status": {
                     "type": "string",
-                    "enum": [
-                        "new",
-                        "in_progress",
-                        "done"
-                    ],
-                    "example": "in_progress"
+                    "example": "success"
                 }
             }
         },
-        "internal_handler.ContactUpdateRequest": {
+        "internal_handler.SuccessResponse-darulabror_internal_dto_RegistrationDocumentChecklistDTO": {
             "type": "object",
-            "required": [
-                "email",
-                "message",
-                "subject"
-            ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
+                "data": {
+                    "$ref": "#/definitions/darulabror_internal_dto.RegistrationDocumentChecklistDTO"
                 },
                 "message": {
                     "type": "string",
-                    "maxLength": 2000,
-                    "minLength": 3,
-                    "example": "Hello, I would like to ask..."
+                    "example": "OK"
                 },
-                "subject": {
+                "status": {
                     "type": "string",
-                    "maxLength": 150,
-                    "minLength": 3,
-                    "example": "Question about registration"
+                    "example": "success"
                 }
             }
         },
-        "internal_handler.ErrorResponse": {
+        "internal_handler.SuccessResponse-darulabror_internal_dto_RegistrationDocumentDTO": {
             "type": "object",
             "properties": {
+                "data": {
+                    "$ref": "#/definitions/darulabror_internal_dto.RegistrationDocumentDTO"
+                },
                 "message": {
                     "type": "string",
-                    "example": "something went wrong"
+                    "example": "OK"
                 },
                 "status": {
                     "type": "string",
-                    "example": "error"
+                    "example": "success"
                 }
             }
         },
-        "internal_handler.ListResponseData-darulabror_internal_dto_AdminDTO": {
+        "internal_handler.SuccessResponse-darulabror_internal_dto_RegistrationImportReportDTO": {
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/darulabror_internal_dto.AdminDTO"
-                    }
+                "data": {
+                    "$ref": "#/definitions/darulabror_internal_dto.RegistrationImportReportDTO"
                 },
-                "meta": {
-                    "$ref": "#/definitions/internal_handler.PaginationMeta"
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
                 }
             }
         },
-        "internal_handler.ListResponseData-darulabror_internal_dto_ArticleDTO": {
+        "internal_handler.SuccessResponse-darulabror_internal_dto_RegistrationStatusLookupDTO": {
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/darulabror_internal_dto.ArticleDTO"
-                    }
+                "data": {
+                    "$ref": "#/definitions/darulabror_internal_dto.RegistrationStatusLookupDTO"
                 },
-                "meta": {
-                    "$ref": "#/definitions/internal_handler.PaginationMeta"
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
                 }
             }
         },
-        "internal_handler.ListResponseData-darulabror_internal_dto_RegistrationDTO": {
+        "internal_handler.SuccessResponse-darulabror_internal_dto_SignedURLDTO": {
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/darulabror_internal_dto.RegistrationDTO"
-                    }
+                "data": {
+                    "$ref": "#/definitions/darulabror_internal_dto.SignedURLDTO"
                 },
-                "meta": {
-                    "$ref": "#/definitions/internal_handler.PaginationMeta"
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
                 }
             }
         },
-        "internal_handler.ListResponseData-internal_handler_ContactListItem": {
+        "internal_handler.SuccessResponse-darulabror_internal_dto_TagDTO": {
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/internal_handler.ContactListItem"
-                    }
+                "data": {
+                    "$ref": "#/definitions/darulabror_internal_dto.TagDTO"
                 },
-                "meta": {
-                    "$ref": "#/definitions/internal_handler.PaginationMeta"
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
                 }
             }
         },
-        "internal_handler.PaginationMeta": {
+        "internal_handler.SuccessResponse-dto_MediaDTO": {
             "type": "object",
             "properties": {
-                "limit": {
-                    "type": "integer",
-                    "example": 10
+                "data": {
+                    "$ref": "#/definitions/darulabror_internal_dto.MediaDTO"
                 },
-                "page": {
-                    "type": "integer",
-                    "example": 1
+                "message": {
+                    "type": "string",
+                    "example": "OK"
                 },
-                "total": {
-                    "type": "integer",
-                    "example": 123
+                "status": {
+                    "type": "string",
+                    "example": "success"
                 }
             }
         },
-        "internal_handler.RegistrationListResponse": {
+        "internal_handler.SuccessResponse-dto_MediaGCReportDTO": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/internal_handler.ListResponseData-darulabror_internal_dto_RegistrationDTO"
+                    "$ref": "#/definitions/darulabror_internal_dto.MediaGCReportDTO"
                 },
                 "message": {
                     "type": "string",
@@ -2128,29 +7345,27 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_handler.RegistrationStatusUpdateRequest": {
+        "internal_handler.SuccessResponse-dto_NotificationDeliveryDTO": {
             "type": "object",
-            "required": [
-                "status"
-            ],
             "properties": {
+                "data": {
+                    "$ref": "#/definitions/darulabror_internal_dto.NotificationDeliveryDTO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                },
                 "status": {
                     "type": "string",
-                    "enum": [
-                        "new",
-                        "validate",
-                        "process",
-                        "done"
-                    ],
-                    "example": "validate"
+                    "example": "success"
                 }
             }
         },
-        "internal_handler.SuccessResponse-darulabror_internal_dto_AdminDTO": {
+        "internal_handler.SuccessResponse-dto_SignedURLDTO": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/darulabror_internal_dto.AdminDTO"
+                    "$ref": "#/definitions/darulabror_internal_dto.SignedURLDTO"
                 },
                 "message": {
                     "type": "string",
@@ -2162,11 +7377,11 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_handler.SuccessResponse-darulabror_internal_dto_ArticleDTO": {
+        "internal_handler.SuccessResponse-internal_handler_ContactListItem": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/darulabror_internal_dto.ArticleDTO"
+                    "$ref": "#/definitions/internal_handler.ContactListItem"
                 },
                 "message": {
                     "type": "string",
@@ -2178,11 +7393,14 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_handler.SuccessResponse-darulabror_internal_dto_RegistrationDTO": {
+        "internal_handler.TagListResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/darulabror_internal_dto.RegistrationDTO"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/darulabror_internal_dto.TagDTO"
+                    }
                 },
                 "message": {
                     "type": "string",
@@ -2194,11 +7412,11 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_handler.SuccessResponse-internal_handler_ContactListItem": {
+        "internal_handler.TrashListResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/internal_handler.ContactListItem"
+                    "$ref": "#/definitions/internal_handler.ListResponseData-darulabror_internal_dto_TrashItemDTO"
                 },
                 "message": {
                     "type": "string",
@@ -0,0 +1,99 @@
+package content
+
+import (
+	"darulabror/internal/models"
+	"encoding/json"
+	"math"
+	"strings"
+)
+
+// MediaIDField is the block field that references a media library item instead
+// of (or alongside) its url.
+const MediaIDField = "media_id"
+
+// MediaRefs returns the media IDs and URLs used anywhere in the content. Invalid
+// content has no references.
+func MediaRefs(raw []byte) (ids []uint, urls []string) {
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, nil
+	}
+
+	seenID := map[uint]bool{}
+	seenURL := map[string]bool{}
+	walkObjects(doc, func(obj map[string]any) {
+		if id, ok := mediaID(obj[MediaIDField]); ok && !seenID[id] {
+			seenID[id] = true
+			ids = append(ids, id)
+		}
+		if u, ok := obj["url"].(string); ok {
+			if u = strings.TrimSpace(u); u != "" && !seenURL[u] {
+				seenURL[u] = true
+				urls = append(urls, u)
+			}
+		}
+	})
+	return ids, urls
+}
+
+// MediaRef is what a block referencing a library item gets filled in with.
+type MediaRef struct {
+	URL    string
+	Width  int // 0 when unknown (non-images)
+	Height int
+	Srcset []models.ImageSource // processed images only
+}
+
+// ResolveMedia fills every object with a media_id found in refs with the item's
+// current "url" and, for processed images, "width", "height" and "srcset" (a list
+// of {url, width, height}, narrowest first). media_id is kept.
+func ResolveMedia(raw []byte, refs map[uint]MediaRef) ([]byte, error) {
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	walkObjects(doc, func(obj map[string]any) {
+		id, ok := mediaID(obj[MediaIDField])
+		if !ok {
+			return
+		}
+		ref, exists := refs[id]
+		if !exists {
+			return
+		}
+		obj["url"] = ref.URL
+		if ref.Width > 0 && ref.Height > 0 {
+			obj["width"], obj["height"] = ref.Width, ref.Height
+		}
+		if len(ref.Srcset) > 0 {
+			obj["srcset"] = ref.Srcset
+		} else {
+			delete(obj, "srcset")
+		}
+	})
+	return json.Marshal(doc)
+}
+
+// walkObjects calls fn for every JSON object in node, depth first.
+func walkObjects(node any, fn func(map[string]any)) {
+	switch v := node.(type) {
+	case map[string]any:
+		fn(v)
+		for _, child := range v {
+			walkObjects(child, fn)
+		}
+	case []any:
+		for _, child := range v {
+			walkObjects(child, fn)
+		}
+	}
+}
+
+// mediaID reads a positive whole-number media_id (as decoded by encoding/json).
+func mediaID(raw any) (uint, bool) {
+	n, ok := raw.(float64)
+	if !ok || n < 1 || n != math.Trunc(n) || n > math.MaxUint32 {
+		return 0, false
+	}
+	return uint(n), true
+}
@@ -0,0 +1,313 @@
+package content
+
+import (
+	"encoding/json"
+	"html"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ExcerptLength is the default excerpt size in characters.
+const ExcerptLength = 200
+
+// RenderHTML converts content into an HTML fragment. All text is escaped and only
+// http(s) or root-relative URLs are emitted; blocks without a usable URL (e.g. an
+// upload_key that was never replaced) are skipped. Embeds become plain links, no iframes.
+func RenderHTML(raw []byte) string {
+	var b strings.Builder
+	for _, block := range parseBlocks(raw) {
+		renderBlockHTML(&b, block)
+	}
+	return b.String()
+}
+
+// RenderText converts content into plain text, one block per paragraph.
+func RenderText(raw []byte) string {
+	parts := make([]string, 0)
+	for _, block := range parseBlocks(raw) {
+		if t := blockText(block, true); t != "" {
+			parts = append(parts, t)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// Excerpt returns the first maxRunes characters of the content's text (paragraphs,
+// headings, quotes and lists), cut at a word boundary with "…" appended when truncated.
+func Excerpt(raw []byte, maxRunes int) string {
+	parts := make([]string, 0)
+	for _, block := range parseBlocks(raw) {
+		if t := blockText(block, false); t != "" {
+			parts = append(parts, t)
+		}
+	}
+	text := strings.Join(strings.Fields(strings.Join(parts, " ")), " ")
+
+	if utf8.RuneCountInString(text) <= maxRunes {
+		return text
+	}
+	cut := string([]rune(text)[:maxRunes])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:-") + "…"
+}
+
+// parseBlocks reads {"blocks": [...]} (or a bare array); anything else has no blocks.
+func parseBlocks(raw []byte) []map[string]any {
+	var doc struct {
+		Blocks []json.RawMessage `json:"blocks"`
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			return nil
+		}
+		items = doc.Blocks
+	}
+
+	blocks := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		var block map[string]any
+		if json.Unmarshal(item, &block) == nil && block != nil {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+func renderBlockHTML(b *strings.Builder, block map[string]any) {
+	switch str(block, "type") {
+	case BlockParagraph:
+		if text := str(block, "text"); text != "" {
+			b.WriteString("<p>" + escapeText(text) + "</p>\n")
+		}
+
+	case BlockHeading:
+		if text := str(block, "text"); text != "" {
+			tag := "h" + strconv.Itoa(headingLevel(block))
+			b.WriteString("<" + tag + ">" + escapeText(text) + "</" + tag + ">\n")
+		}
+
+	case BlockImage:
+		src, ok := safeURL(block)
+		if !ok {
+			return
+		}
+		caption := str(block, "caption")
+		alt := str(block, "alt")
+		if alt == "" {
+			alt = caption
+		}
+		b.WriteString(`<figure><img src="` + html.EscapeString(src) + `"`)
+		if srcset := srcsetAttr(block); srcset != "" {
+			b.WriteString(` srcset="` + html.EscapeString(srcset) + `" sizes="100vw"`)
+		}
+		if w, h := dimension(block, "width"), dimension(block, "height"); w > 0 && h > 0 {
+			b.WriteString(` width="` + strconv.Itoa(w) + `" height="` + strconv.Itoa(h) + `"`)
+		}
+		b.WriteString(` alt="` + html.EscapeString(alt) + `" loading="lazy">`)
+		writeCaption(b, caption)
+		b.WriteString("</figure>\n")
+
+	case BlockVideo:
+		src, ok := safeURL(block)
+		if !ok {
+			return
+		}
+		b.WriteString(`<figure><video src="` + html.EscapeString(src) + `" controls preload="metadata"></video>`)
+		writeCaption(b, str(block, "caption"))
+		b.WriteString("</figure>\n")
+
+	case BlockQuote:
+		text := str(block, "text")
+		if text == "" {
+			return
+		}
+		b.WriteString("<blockquote><p>" + escapeText(text) + "</p>")
+		if cite := str(block, "cite"); cite != "" {
+			b.WriteString("<cite>" + html.EscapeString(cite) + "</cite>")
+		}
+		b.WriteString("</blockquote>\n")
+
+	case BlockList:
+		items := strs(block, "items")
+		if len(items) == 0 {
+			return
+		}
+		tag := "ul"
+		if str(block, "style") == "ordered" {
+			tag = "ol"
+		}
+		b.WriteString("<" + tag + ">")
+		for _, item := range items {
+			b.WriteString("<li>" + escapeText(item) + "</li>")
+		}
+		b.WriteString("</" + tag + ">\n")
+
+	case BlockEmbed:
+		href, ok := safeURL(block)
+		if !ok {
+			return
+		}
+		label := firstNonEmpty(str(block, "caption"), str(block, "provider"), href)
+		b.WriteString(`<p><a href="` + html.EscapeString(href) + `" rel="noopener noreferrer nofollow" target="_blank">` +
+			html.EscapeString(label) + "</a></p>\n")
+
+	case BlockFile:
+		href, ok := safeURL(block)
+		if !ok {
+			return
+		}
+		label := firstNonEmpty(str(block, "title"), str(block, "caption"), href)
+		b.WriteString(`<p><a href="` + html.EscapeString(href) + `" download>` + html.EscapeString(label) + "</a></p>\n")
+
+	case BlockDivider:
+		b.WriteString("<hr>\n")
+
+	default:
+		// unknown block types: keep their text, drop everything else
+		if text := str(block, "text"); text != "" {
+			b.WriteString("<p>" + escapeText(text) + "</p>\n")
+		}
+	}
+}
+
+// blockText is the plain text of a block. media adds image/video captions and embed/file links
+// (full text rendering); excerpts only use the written text.
+func blockText(block map[string]any, media bool) string {
+	switch str(block, "type") {
+	case BlockList:
+		items := strs(block, "items")
+		ordered := str(block, "style") == "ordered"
+		lines := make([]string, 0, len(items))
+		for i, item := range items {
+			if ordered {
+				lines = append(lines, strconv.Itoa(i+1)+". "+item)
+			} else {
+				lines = append(lines, "- "+item)
+			}
+		}
+		return strings.Join(lines, "\n")
+
+	case BlockQuote:
+		text := str(block, "text")
+		if text == "" {
+			return ""
+		}
+		if cite := str(block, "cite"); cite != "" && media {
+			return "“" + text + "” — " + cite
+		}
+		return "“" + text + "”"
+
+	case BlockImage, BlockVideo:
+		if media {
+			return str(block, "caption")
+		}
+		return ""
+
+	case BlockEmbed, BlockFile:
+		if !media {
+			return ""
+		}
+		href, ok := safeURL(block)
+		if !ok {
+			return ""
+		}
+		label := str(block, "caption")
+		if str(block, "type") == BlockFile {
+			label = firstNonEmpty(str(block, "title"), label)
+		}
+		if label != "" {
+			return label + ": " + href
+		}
+		return href
+
+	case BlockDivider:
+		return ""
+
+	default: // paragraph, heading and unknown types with text
+		return str(block, "text")
+	}
+}
+
+func writeCaption(b *strings.Builder, caption string) {
+	if caption != "" {
+		b.WriteString("<figcaption>" + html.EscapeString(caption) + "</figcaption>")
+	}
+}
+
+func headingLevel(block map[string]any) int {
+	level, ok := block["level"].(float64)
+	if !ok || level < 1 || level > 6 {
+		return 2
+	}
+	return int(level)
+}
+
+// srcsetAttr builds "url 320w, url 640w" from the block's srcset entries, skipping unsafe ones.
+func srcsetAttr(block map[string]any) string {
+	entries, _ := block["srcset"].([]any)
+	parts := make([]string, 0, len(entries))
+	for _, e := range entries {
+		entry, ok := e.(map[string]any)
+		if !ok {
+			continue
+		}
+		u := str(entry, "url")
+		w := dimension(entry, "width")
+		if u == "" || w <= 0 || !validURL(u) || strings.ContainsAny(u, " ,") {
+			continue
+		}
+		parts = append(parts, u+" "+strconv.Itoa(w)+"w")
+	}
+	return strings.Join(parts, ", ")
+}
+
+func dimension(obj map[string]any, key string) int {
+	n, ok := obj[key].(float64)
+	if !ok || n < 1 || n > 100000 {
+		return 0
+	}
+	return int(n)
+}
+
+// safeURL returns the block's url if it is safe to emit.
+func safeURL(block map[string]any) (string, bool) {
+	u := strings.TrimSpace(str(block, "url"))
+	if u == "" || !validURL(u) {
+		return "", false
+	}
+	return u, true
+}
+
+// escapeText escapes s for HTML and keeps line breaks.
+func escapeText(s string) string {
+	return strings.ReplaceAll(html.EscapeString(s), "\n", "<br>")
+}
+
+func str(block map[string]any, key string) string {
+	s, _ := block[key].(string)
+	return strings.TrimSpace(s)
+}
+
+func strs(block map[string]any, key string) []string {
+	raw, _ := block[key].([]any)
+	out := make([]string, 0, len(raw))
+	for _, item := range raw {
+		if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+			out = append(out, strings.TrimSpace(s))
+		}
+	}
+	return out
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
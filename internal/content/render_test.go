@@ -0,0 +1,120 @@
+package content
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		block string
+		want  string
+	}{
+		{"paragraph", `{"type":"paragraph","text":"Baris 1\nBaris 2"}`, "<p>Baris 1<br>Baris 2</p>\n"},
+		{"paragraph escaped", `{"type":"paragraph","text":"<script>alert('x')</script> & co"}`,
+			"<p>&lt;script&gt;alert(&#39;x&#39;)&lt;/script&gt; &amp; co</p>\n"},
+		{"empty paragraph", `{"type":"paragraph","text":"  "}`, ""},
+		{"heading", `{"type":"heading","text":"Jadwal","level":3}`, "<h3>Jadwal</h3>\n"},
+		{"heading bad level", `{"type":"heading","text":"Jadwal","level":9}`, "<h2>Jadwal</h2>\n"},
+		{"image", `{"type":"image","url":"/uploads/a.jpg","caption":"Wisuda \"2025\""}`,
+			`<figure><img src="/uploads/a.jpg" alt="Wisuda &#34;2025&#34;" loading="lazy"><figcaption>Wisuda &#34;2025&#34;</figcaption></figure>` + "\n"},
+		{"image attribute escaped", `{"type":"image","url":"https://example.com/a.jpg?x=\"onerror=\"alert(1)","alt":"a"}`,
+			`<figure><img src="https://example.com/a.jpg?x=&#34;onerror=&#34;alert(1)" alt="a" loading="lazy"></figure>` + "\n"},
+		{"image javascript url", `{"type":"image","url":"javascript:alert(1)"}`, ""},
+		{"image protocol-relative url", `{"type":"image","url":"//evil.example/a.jpg"}`, ""},
+		{"image backslash url", `{"type":"image","url":"/\\evil.example/a.jpg"}`, ""},
+		{"image upload placeholder", `{"type":"image","upload_key":"foto1"}`, ""},
+		{"video", `{"type":"video","url":"/uploads/v.mp4"}`,
+			`<figure><video src="/uploads/v.mp4" controls preload="metadata"></video></figure>` + "\n"},
+		{"video data url", `{"type":"video","url":"data:video/mp4;base64,AAAA"}`, ""},
+		{"quote", `{"type":"quote","text":"Ilmu <b>","cite":"Imam & Syafi'i"}`,
+			"<blockquote><p>Ilmu &lt;b&gt;</p><cite>Imam &amp; Syafi&#39;i</cite></blockquote>\n"},
+		{"ordered list", `{"type":"list","style":"ordered","items":["a<",""," b "]}`, "<ol><li>a&lt;</li><li>b</li></ol>\n"},
+		{"unordered list", `{"type":"list","items":["a"]}`, "<ul><li>a</li></ul>\n"},
+		{"embed is a link", `{"type":"embed","url":"https://youtube.com/watch?v=1&t=2","provider":"YouTube"}`,
+			`<p><a href="https://youtube.com/watch?v=1&amp;t=2" rel="noopener noreferrer nofollow" target="_blank">YouTube</a></p>` + "\n"},
+		{"embed vbscript", `{"type":"embed","url":"vbscript:msgbox"}`, ""},
+		{"file", `{"type":"file","url":"/uploads/brosur.pdf","title":"Brosur"}`,
+			`<p><a href="/uploads/brosur.pdf" download>Brosur</a></p>` + "\n"},
+		{"file without title", `{"type":"file","url":"/uploads/brosur.pdf"}`,
+			`<p><a href="/uploads/brosur.pdf" download>/uploads/brosur.pdf</a></p>` + "\n"},
+		{"divider", `{"type":"divider"}`, "<hr>\n"},
+		{"unknown type keeps text only", `{"type":"callout","text":"<i>Catatan</i>","html":"<iframe>"}`,
+			"<p>&lt;i&gt;Catatan&lt;/i&gt;</p>\n"},
+	}
+	for _, tt := range tests {
+		if got := RenderHTML([]byte(`{"blocks":[` + tt.block + `]}`)); got != tt.want {
+			t.Errorf("%s:\n got %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRenderHTMLDocument(t *testing.T) {
+	tests := []struct {
+		name, doc, want string
+	}{
+		{"legacy bare array", `[{"type":"paragraph","text":"a"}]`, "<p>a</p>\n"},
+		{"non-object blocks skipped", `{"blocks":["x",null,{"type":"divider"}]}`, "<hr>\n"},
+		{"not content", `"teks"`, ""},
+		{"invalid JSON", `{`, ""},
+	}
+	for _, tt := range tests {
+		if got := RenderHTML([]byte(tt.doc)); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	doc := `{"blocks":[
+		{"type":"heading","text":"Pengumuman"},
+		{"type":"paragraph","text":"Isi <b>tetap</b> apa adanya."},
+		{"type":"image","url":"/a.jpg","caption":"Foto kegiatan"},
+		{"type":"image","url":"/b.jpg"},
+		{"type":"list","style":"ordered","items":["Satu","Dua"]},
+		{"type":"list","items":["Tiga"]},
+		{"type":"quote","text":"Tuntutlah ilmu","cite":"Hadis"},
+		{"type":"file","url":"/uploads/brosur.pdf","title":"Brosur"},
+		{"type":"embed","url":"javascript:alert(1)","caption":"Jahat"},
+		{"type":"divider"}
+	]}`
+	want := strings.Join([]string{
+		"Pengumuman",
+		"Isi <b>tetap</b> apa adanya.",
+		"Foto kegiatan",
+		"1. Satu\n2. Dua",
+		"- Tiga",
+		"“Tuntutlah ilmu” — Hadis",
+		"Brosur: /uploads/brosur.pdf",
+	}, "\n\n")
+	if got := RenderText([]byte(doc)); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		maxRunes int
+		want     string
+	}{
+		{"short", `{"blocks":[{"type":"heading","text":"Judul"},{"type":"paragraph","text":"Isi  singkat\nsekali."}]}`, 200,
+			"Judul Isi singkat sekali."},
+		{"media and links left out", `{"blocks":[{"type":"image","url":"/a.jpg","caption":"Foto"},
+			{"type":"file","url":"/b.pdf","title":"Brosur"},{"type":"quote","text":"Ilmu","cite":"Hadis"}]}`, 200,
+			"“Ilmu”"},
+		{"cut at a word", `{"blocks":[{"type":"paragraph","text":"Pendaftaran santri baru dibuka, silakan daftar"}]}`, 32,
+			"Pendaftaran santri baru dibuka…"},
+		{"counts characters, not bytes", `{"blocks":[{"type":"paragraph","text":"ṣalāt ṣalāt ṣalāt"}]}`, 12,
+			"ṣalāt ṣalāt…"},
+		{"exact length", `{"blocks":[{"type":"paragraph","text":"abc def"}]}`, 7, "abc def"},
+		{"no content", `{"blocks":[]}`, 10, ""},
+	}
+	for _, tt := range tests {
+		if got := Excerpt([]byte(tt.doc), tt.maxRunes); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
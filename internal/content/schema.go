@@ -0,0 +1,370 @@
+// Package content describes the block structure of article content, validates it
+// and renders it to HTML / plain text.
+//
+// Article content is a JSON object with a "blocks" array; every block is an object
+// with a "type" and type-specific fields, e.g.
+//
+//	{"blocks": [{"type": "heading", "text": "Judul", "level": 2}, {"type": "paragraph", "text": "..."}]}
+//
+// Other top-level keys and unknown fields inside a block are kept as-is.
+package content
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+)
+
+// Block types known to the default registry.
+const (
+	BlockParagraph = "paragraph"
+	BlockHeading   = "heading"
+	BlockImage     = "image"
+	BlockVideo     = "video"
+	BlockQuote     = "quote"
+	BlockList      = "list"
+	BlockEmbed     = "embed"
+	BlockDivider   = "divider"
+	BlockFile      = "file"
+)
+
+// FieldKind is the JSON shape a block field must have.
+type FieldKind int
+
+const (
+	KindString     FieldKind = iota // non-empty string
+	KindInt                         // whole number, see Min/Max
+	KindURL                         // http(s) URL or root-relative path
+	KindStringList                  // non-empty array of non-empty strings
+)
+
+// FieldSpec describes one field of a block.
+type FieldSpec struct {
+	Name     string
+	Kind     FieldKind
+	Required bool
+	OneOf    []string // KindString only: allowed values
+	Min, Max int      // KindInt only: inclusive bounds
+	MaxLen   int      // KindString only: max length in characters (0 = no limit)
+
+	// Uploadable lets a KindURL field be replaced by an "upload_key" placeholder
+	// that is swapped for the uploaded file's URL before saving, or by a
+	// "media_id" referencing the media library.
+	Uploadable bool
+	// Accept limits the MIME types an uploaded file may have ("image/*" matches a
+	// family); empty accepts anything the upload slot allows.
+	Accept []string
+}
+
+// BlockSpec describes one block type.
+type BlockSpec struct {
+	Fields []FieldSpec
+}
+
+// Registry holds the known block types.
+type Registry struct {
+	blocks       map[string]BlockSpec
+	allowUnknown bool
+}
+
+// NewRegistry returns a registry with the default block types. With allowUnknown,
+// blocks of unregistered types only need a "type" and are otherwise accepted.
+func NewRegistry(allowUnknown bool) *Registry {
+	r := &Registry{
+		blocks:       map[string]BlockSpec{},
+		allowUnknown: allowUnknown,
+	}
+
+	r.Register(BlockParagraph, BlockSpec{Fields: []FieldSpec{
+		{Name: "text", Kind: KindString, Required: true},
+	}})
+	r.Register(BlockHeading, BlockSpec{Fields: []FieldSpec{
+		{Name: "text", Kind: KindString, Required: true, MaxLen: 300},
+		{Name: "level", Kind: KindInt, Min: 1, Max: 6},
+	}})
+	r.Register(BlockImage, BlockSpec{Fields: []FieldSpec{
+		{Name: "url", Kind: KindURL, Required: true, Uploadable: true, Accept: []string{"image/*"}},
+		{Name: "caption", Kind: KindString},
+		{Name: "alt", Kind: KindString, MaxLen: 300},
+	}})
+	r.Register(BlockVideo, BlockSpec{Fields: []FieldSpec{
+		{Name: "url", Kind: KindURL, Required: true, Uploadable: true, Accept: []string{"video/*"}},
+		{Name: "caption", Kind: KindString},
+	}})
+	r.Register(BlockQuote, BlockSpec{Fields: []FieldSpec{
+		{Name: "text", Kind: KindString, Required: true},
+		{Name: "cite", Kind: KindString, MaxLen: 300},
+	}})
+	r.Register(BlockList, BlockSpec{Fields: []FieldSpec{
+		{Name: "items", Kind: KindStringList, Required: true},
+		{Name: "style", Kind: KindString, OneOf: []string{"ordered", "unordered"}},
+	}})
+	r.Register(BlockEmbed, BlockSpec{Fields: []FieldSpec{
+		{Name: "url", Kind: KindURL, Required: true},
+		{Name: "provider", Kind: KindString, MaxLen: 50},
+		{Name: "caption", Kind: KindString},
+	}})
+	r.Register(BlockDivider, BlockSpec{})
+	r.Register(BlockFile, BlockSpec{Fields: []FieldSpec{
+		{Name: "url", Kind: KindURL, Required: true, Uploadable: true},
+		{Name: "title", Kind: KindString, MaxLen: 300},
+		{Name: "caption", Kind: KindString},
+	}})
+
+	return r
+}
+
+// Register adds or replaces a block type.
+func (r *Registry) Register(blockType string, spec BlockSpec) {
+	r.blocks[blockType] = spec
+}
+
+// ValidationError lists every problem found in a document, each prefixed with
+// its path (e.g. "blocks[3].url is required").
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Problems, "; ")
+}
+
+// Normalize returns doc in the object form {"blocks": [...]}. Content stored
+// before validation existed may be a bare blocks array or null, which the
+// renderer still reads; updates of such articles must not be refused.
+func Normalize(doc any) any {
+	switch d := doc.(type) {
+	case nil:
+		return map[string]any{"blocks": []any{}}
+	case []any:
+		return map[string]any{"blocks": d}
+	}
+	return doc
+}
+
+// Validate checks doc (decoded JSON) against the registry; legacy shapes are
+// accepted as Normalize reads them. uploadKeys are the
+// upload_key placeholders that have a file in the request; a placeholder
+// without a file is reported. Returns *ValidationError or nil.
+func (r *Registry) Validate(doc any, uploadKeys map[string]bool) error {
+	v := &validator{registry: r, uploadKeys: uploadKeys}
+	v.document(Normalize(doc))
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+type validator struct {
+	registry   *Registry
+	uploadKeys map[string]bool
+	problems   []string
+}
+
+func (v *validator) fail(path, format string, args ...any) {
+	v.problems = append(v.problems, path+" "+fmt.Sprintf(format, args...))
+}
+
+func (v *validator) document(doc any) {
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		v.fail("content", "must be an object with a blocks array")
+		return
+	}
+	raw, ok := obj["blocks"]
+	if !ok {
+		v.fail("blocks", "is required")
+		return
+	}
+	blocks, ok := raw.([]any)
+	if !ok {
+		v.fail("blocks", "must be an array")
+		return
+	}
+	for i, b := range blocks {
+		v.block(fmt.Sprintf("blocks[%d]", i), b)
+	}
+}
+
+func (v *validator) block(path string, raw any) {
+	block, ok := raw.(map[string]any)
+	if !ok {
+		v.fail(path, "must be an object")
+		return
+	}
+
+	blockType, ok := block["type"].(string)
+	if !ok || blockType == "" {
+		v.fail(path+".type", "is required")
+		return
+	}
+
+	spec, known := v.registry.blocks[blockType]
+	if !known {
+		if !v.registry.allowUnknown {
+			v.fail(path+".type", "%q is not a supported block type", blockType)
+		}
+		return
+	}
+
+	for _, f := range spec.Fields {
+		v.field(path, block, f)
+	}
+}
+
+func (v *validator) field(blockPath string, block map[string]any, f FieldSpec) {
+	path := blockPath + "." + f.Name
+	val, present := block[f.Name]
+
+	if !present || val == nil {
+		if f.Uploadable {
+			if key, ok := block["upload_key"]; ok {
+				v.uploadKey(blockPath+".upload_key", key)
+				return
+			}
+			if id, ok := block[MediaIDField]; ok {
+				if _, valid := mediaID(id); !valid {
+					v.fail(blockPath+"."+MediaIDField, "must be a positive whole number")
+				}
+				return
+			}
+		}
+		if f.Required {
+			v.fail(path, "is required")
+		}
+		return
+	}
+
+	switch f.Kind {
+	case KindString:
+		s, ok := val.(string)
+		if !ok {
+			v.fail(path, "must be a string")
+			return
+		}
+		if strings.TrimSpace(s) == "" {
+			if f.Required {
+				v.fail(path, "is required")
+			}
+			return
+		}
+		if f.MaxLen > 0 && len([]rune(s)) > f.MaxLen {
+			v.fail(path, "must be at most %d characters", f.MaxLen)
+		}
+		if len(f.OneOf) > 0 && !contains(f.OneOf, s) {
+			v.fail(path, "must be one of: %s", strings.Join(f.OneOf, ", "))
+		}
+
+	case KindInt:
+		n, ok := val.(float64)
+		if !ok || n != math.Trunc(n) {
+			v.fail(path, "must be a whole number")
+			return
+		}
+		if int(n) < f.Min || int(n) > f.Max {
+			v.fail(path, "must be between %d and %d", f.Min, f.Max)
+		}
+
+	case KindURL:
+		s, ok := val.(string)
+		if !ok {
+			v.fail(path, "must be a string")
+			return
+		}
+		if strings.TrimSpace(s) == "" {
+			if f.Required {
+				v.fail(path, "is required")
+			}
+			return
+		}
+		if !validURL(s) {
+			v.fail(path, "must be an http(s) URL")
+		}
+
+	case KindStringList:
+		items, ok := val.([]any)
+		if !ok {
+			v.fail(path, "must be an array of strings")
+			return
+		}
+		if len(items) == 0 && f.Required {
+			v.fail(path, "must not be empty")
+		}
+		for i, item := range items {
+			if s, ok := item.(string); !ok || strings.TrimSpace(s) == "" {
+				v.fail(fmt.Sprintf("%s[%d]", path, i), "must be a non-empty string")
+			}
+		}
+	}
+}
+
+func (v *validator) uploadKey(path string, raw any) {
+	key, ok := raw.(string)
+	if !ok || strings.TrimSpace(key) == "" {
+		v.fail(path, "must be a non-empty string")
+		return
+	}
+	if v.uploadKeys != nil && !v.uploadKeys[key] {
+		v.fail(path, "%q has no matching content_files[%s] file", key, key)
+	}
+}
+
+// UploadAccepts maps every upload_key placeholder in doc to the MIME types its
+// field accepts (see FieldSpec.Accept). Keys of fields without a limit map to nil.
+func (r *Registry) UploadAccepts(doc any) map[string][]string {
+	accepts := map[string][]string{}
+	walkObjects(doc, func(block map[string]any) {
+		key, ok := block["upload_key"].(string)
+		if !ok {
+			return
+		}
+		blockType, _ := block["type"].(string)
+		for _, f := range r.blocks[blockType].Fields {
+			if f.Uploadable {
+				accepts[key] = f.Accept
+				return
+			}
+		}
+	})
+	return accepts
+}
+
+// AcceptsMIME reports whether mimeType matches one of the patterns: an exact type
+// or a family wildcard like "image/*". An empty pattern list matches nothing.
+func AcceptsMIME(patterns []string, mimeType string) bool {
+	mimeType = strings.ToLower(mimeType)
+	for _, p := range patterns {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if p == mimeType || (strings.HasSuffix(p, "/*") && strings.HasPrefix(mimeType, strings.TrimSuffix(p, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// validURL accepts absolute http(s) URLs and root-relative paths ("/uploads/...").
+// Backslashes and control characters are refused: browsers read "/\evil.example"
+// as "//evil.example", another host.
+func validURL(s string) bool {
+	if strings.ContainsRune(s, '\\') || strings.IndexFunc(s, func(r rune) bool { return r < 0x20 || r == 0x7f }) >= 0 {
+		return false
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" && u.Host == "" {
+		return strings.HasPrefix(s, "/") && !strings.HasPrefix(s, "//")
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
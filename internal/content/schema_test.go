@@ -0,0 +1,195 @@
+package content
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func decode(t *testing.T, s string) any {
+	t.Helper()
+	var doc any
+	if err := json.Unmarshal([]byte(s), &doc); err != nil {
+		t.Fatalf("bad test JSON %s: %v", s, err)
+	}
+	return doc
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		doc        string
+		uploadKeys map[string]bool
+		want       []string // problems, in order
+	}{
+		{"valid document", `{"blocks":[
+			{"type":"heading","text":"Jadwal","level":2},
+			{"type":"paragraph","text":"Isi"},
+			{"type":"list","items":["a","b"],"style":"ordered"},
+			{"type":"image","url":"https://cdn.example.com/a.jpg","alt":"Santri"},
+			{"type":"file","url":"/uploads/brosur.pdf"},
+			{"type":"divider"}
+		],"version":1}`, nil, nil},
+		{"unknown fields kept", `{"blocks":[{"type":"paragraph","text":"x","align":"center"}]}`, nil, nil},
+		{"legacy bare array", `[{"type":"paragraph","text":"x"}]`, nil, nil},
+		{"legacy null", `null`, nil, nil},
+		{"legacy bare array, invalid block", `[{"type":"paragraph"}]`, nil,
+			[]string{"blocks[0].text is required"}},
+		{"not an object", `"teks"`, nil, []string{"content must be an object with a blocks array"}},
+		{"no blocks", `{"body":[]}`, nil, []string{"blocks is required"}},
+		{"blocks not an array", `{"blocks":{}}`, nil, []string{"blocks must be an array"}},
+		{"block not an object", `{"blocks":["x"]}`, nil, []string{"blocks[0] must be an object"}},
+		{"missing type", `{"blocks":[{"text":"x"}]}`, nil, []string{"blocks[0].type is required"}},
+		{"unknown type", `{"blocks":[{"type":"carousel"}]}`, nil,
+			[]string{`blocks[0].type "carousel" is not a supported block type`}},
+		{"every problem reported", `{"blocks":[
+			{"type":"paragraph","text":"  "},
+			{"type":"heading","text":"x","level":7},
+			{"type":"heading","text":"x","level":1.5},
+			{"type":"list","items":[],"style":"zigzag"},
+			{"type":"list","items":["a",""]},
+			{"type":"quote","text":3}
+		]}`, nil, []string{
+			"blocks[0].text is required",
+			"blocks[1].level must be between 1 and 6",
+			"blocks[2].level must be a whole number",
+			"blocks[3].items must not be empty",
+			"blocks[3].style must be one of: ordered, unordered",
+			"blocks[4].items[1] must be a non-empty string",
+			"blocks[5].text must be a string",
+		}},
+		{"max length in characters", `{"blocks":[{"type":"heading","text":"` + strings.Repeat("ā", 300) + `"},
+			{"type":"heading","text":"` + strings.Repeat("ā", 301) + `"}]}`, nil,
+			[]string{"blocks[1].text must be at most 300 characters"}},
+		{"bad urls", `{"blocks":[
+			{"type":"image","url":"javascript:alert(1)"},
+			{"type":"embed","url":"//evil.example/x"},
+			{"type":"video","url":"/\\evil.example"},
+			{"type":"file","url":""}
+		]}`, nil, []string{
+			"blocks[0].url must be an http(s) URL",
+			"blocks[1].url must be an http(s) URL",
+			"blocks[2].url must be an http(s) URL",
+			"blocks[3].url is required",
+		}},
+		{"upload placeholder with file", `{"blocks":[{"type":"image","upload_key":"foto1"}]}`,
+			map[string]bool{"foto1": true}, nil},
+		{"upload placeholder without file", `{"blocks":[{"type":"image","upload_key":"foto2"}]}`,
+			map[string]bool{"foto1": true}, []string{`blocks[0].upload_key "foto2" has no matching content_files[foto2] file`}},
+		{"upload placeholder on a non-upload field", `{"blocks":[{"type":"embed","upload_key":"foto1"}]}`,
+			map[string]bool{"foto1": true}, []string{"blocks[0].url is required"}},
+	}
+	for _, tt := range tests {
+		err := NewRegistry(false).Validate(decode(t, tt.doc), tt.uploadKeys)
+		var got []string
+		if err != nil {
+			var ve *ValidationError
+			if !errors.As(err, &ve) {
+				t.Errorf("%s: error %T, want *ValidationError", tt.name, err)
+				continue
+			}
+			got = ve.Problems
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\n got %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidateUnknownTypes(t *testing.T) {
+	doc := decode(t, `{"blocks":[{"type":"carousel","slides":[]}]}`)
+	if err := NewRegistry(true).Validate(doc, nil); err != nil {
+		t.Errorf("allowUnknown: %v", err)
+	}
+	if err := NewRegistry(false).Validate(doc, nil); err == nil {
+		t.Error("unknown type accepted")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`null`, `{"blocks":[]}`},
+		{`[]`, `{"blocks":[]}`},
+		{`[{"type":"divider"}]`, `{"blocks":[{"type":"divider"}]}`},
+		{`{"blocks":[{"type":"divider"}],"v":2}`, `{"blocks":[{"type":"divider"}],"v":2}`},
+		{`"teks"`, `"teks"`}, // left for Validate to reject
+	}
+	for _, tt := range tests {
+		out, _ := json.Marshal(Normalize(decode(t, tt.in)))
+		if string(out) != tt.want {
+			t.Errorf("Normalize(%s) = %s, want %s", tt.in, out, tt.want)
+		}
+	}
+}
+
+func TestValidURL(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"https://darulabror.sch.id/foto.jpg", true},
+		{"http://localhost:8080/uploads/a.png", true},
+		{"/uploads/articles/a.jpg", true},
+		{"/uploads/a.jpg?v=2#top", true},
+		{"HTTPS://EXAMPLE.COM/a", true},
+		{"uploads/a.jpg", false}, // relative to the page
+		{"//evil.example/a.jpg", false},
+		{"/\\evil.example", false},
+		{"https://example.com\\@evil.example", false},
+		{"/uploads/a.jpg\n", false},
+		{"/up\tloads", false},
+		{"/a\x7f", false},
+		{"https:///nohost", false},
+		{"javascript:alert(1)", false},
+		{"data:image/png;base64,AAAA", false},
+		{"ftp://example.com/a", false},
+		{"mailto:admin@example.com", false},
+	}
+	for _, tt := range tests {
+		if got := validURL(tt.in); got != tt.want {
+			t.Errorf("validURL(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAcceptsMIME(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		mimeType string
+		want     bool
+	}{
+		{[]string{"image/*"}, "image/png", true},
+		{[]string{"image/*"}, "video/mp4", false},
+		{[]string{"image/*"}, "imagex/png", false},
+		{[]string{" Image/JPEG "}, "image/jpeg", true},
+		{[]string{"image/jpeg"}, "IMAGE/JPEG", true},
+		{[]string{"image/jpeg", "application/pdf"}, "application/pdf", true},
+		{[]string{"application/pdf"}, "application/pdf+x", false},
+		{[]string{"*"}, "image/png", false},
+		{nil, "image/png", false},
+	}
+	for _, tt := range tests {
+		if got := AcceptsMIME(tt.patterns, tt.mimeType); got != tt.want {
+			t.Errorf("%q accepts %s: got %v, want %v", tt.patterns, tt.mimeType, got, tt.want)
+		}
+	}
+}
+
+func TestUploadAccepts(t *testing.T) {
+	doc := decode(t, `{"blocks":[
+		{"type":"image","upload_key":"foto"},
+		{"type":"video","upload_key":"klip"},
+		{"type":"file","upload_key":"brosur"},
+		{"type":"embed","upload_key":"ignored"},
+		{"type":"paragraph","text":"no upload"}
+	]}`)
+	got := NewRegistry(false).UploadAccepts(doc)
+	want := map[string][]string{"foto": {"image/*"}, "klip": {"video/*"}, "brosur": nil}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,95 @@
+package dto
+
+import (
+	"darulabror/internal/models"
+	"time"
+)
+
+// AdmissionPeriodDTO is an admission wave. Quotas are optional (omitted or null =
+// no limit); dates are RFC3339, closes_at exclusive.
+type AdmissionPeriodDTO struct {
+	ID           uint   `json:"id" validate:"omitempty"`
+	AcademicYear string `json:"academic_year" validate:"required" example:"2025/2026"`
+	WaveName     string `json:"wave_name" validate:"required,min=2,max=100" example:"Gelombang 1"`
+	OpensAt      string `json:"opens_at" validate:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2025-01-01T00:00:00+07:00"`
+	ClosesAt     string `json:"closes_at" validate:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2025-03-31T23:59:59+07:00"`
+
+	QuotaMale     *int `json:"quota_male" validate:"omitempty,min=0"`
+	QuotaFemale   *int `json:"quota_female" validate:"omitempty,min=0"`
+	QuotaNew      *int `json:"quota_new" validate:"omitempty,min=0"`
+	QuotaTransfer *int `json:"quota_transfer" validate:"omitempty,min=0"`
+
+	// Read-only
+	IsOpen     bool               `json:"is_open"`
+	Remaining  *AdmissionSeatsDTO `json:"remaining,omitempty"`   // seats left (current period only)
+	ServerTime string             `json:"server_time,omitempty"` // for countdowns (current period only)
+	CreatedAt  string             `json:"created_at,omitempty"`
+	UpdatedAt  string             `json:"updated_at,omitempty"`
+}
+
+// AdmissionSeatsDTO holds seats left per quota; null where there is no limit.
+type AdmissionSeatsDTO struct {
+	Male     *int64 `json:"male"`
+	Female   *int64 `json:"female"`
+	New      *int64 `json:"new"`
+	Transfer *int64 `json:"transfer"`
+}
+
+func AdmissionPeriodDTOToModel(d AdmissionPeriodDTO) (models.AdmissionPeriod, error) {
+	opensAt, err := time.Parse(time.RFC3339, d.OpensAt)
+	if err != nil {
+		return models.AdmissionPeriod{}, err
+	}
+	closesAt, err := time.Parse(time.RFC3339, d.ClosesAt)
+	if err != nil {
+		return models.AdmissionPeriod{}, err
+	}
+	return models.AdmissionPeriod{
+		ID:            d.ID,
+		AcademicYear:  d.AcademicYear,
+		WaveName:      d.WaveName,
+		OpensAt:       opensAt,
+		ClosesAt:      closesAt,
+		QuotaMale:     d.QuotaMale,
+		QuotaFemale:   d.QuotaFemale,
+		QuotaNew:      d.QuotaNew,
+		QuotaTransfer: d.QuotaTransfer,
+	}, nil
+}
+
+func AdmissionPeriodModelToDTO(m models.AdmissionPeriod, now time.Time) AdmissionPeriodDTO {
+	return AdmissionPeriodDTO{
+		ID:            m.ID,
+		AcademicYear:  m.AcademicYear,
+		WaveName:      m.WaveName,
+		OpensAt:       m.OpensAt.Format(time.RFC3339),
+		ClosesAt:      m.ClosesAt.Format(time.RFC3339),
+		QuotaMale:     m.QuotaMale,
+		QuotaFemale:   m.QuotaFemale,
+		QuotaNew:      m.QuotaNew,
+		QuotaTransfer: m.QuotaTransfer,
+		IsOpen:        m.IsOpen(now),
+		CreatedAt:     m.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     m.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// AdmissionSeatsLeft computes the seats left per quota of the period.
+func AdmissionSeatsLeft(m models.AdmissionPeriod, taken models.AdmissionSeats) AdmissionSeatsDTO {
+	left := func(quota *int, used int64) *int64 {
+		if quota == nil {
+			return nil
+		}
+		n := int64(*quota) - used
+		if n < 0 {
+			n = 0
+		}
+		return &n
+	}
+	return AdmissionSeatsDTO{
+		Male:     left(m.QuotaMale, taken.Male),
+		Female:   left(m.QuotaFemale, taken.Female),
+		New:      left(m.QuotaNew, taken.New),
+		Transfer: left(m.QuotaTransfer, taken.Transfer),
+	}
+}
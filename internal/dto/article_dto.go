@@ -9,34 +9,84 @@ import (
 type ArticleDTO struct {
 	ID          uint           `json:"id" validate:"omitempty"`
 	Title       string         `json:"title" validate:"required,min=3,max=100"`
+	Slug        string         `json:"slug" validate:"omitempty,max=100"`
 	PhotoHeader string         `json:"photo_header" validate:"required,max=2000"`
 	Content     datatypes.JSON `json:"content" validate:"required"`
+	Excerpt     string         `json:"excerpt" validate:"omitempty"` // read-only, generated from content
 	Author      string         `json:"author" validate:"required,min=3,max=50"`
-	Status      string         `json:"status" validate:"omitempty,oneof=draft published"`
+	Status      string         `json:"status" validate:"omitempty,oneof=draft published scheduled"`
+	PublishAt   *int64         `json:"publish_at,omitempty" example:"1735693200"`   // unix seconds, required for scheduled
+	UnpublishAt *int64         `json:"unpublish_at,omitempty" example:"1736298000"` // unix seconds, optional
+	// Input only: on update, omitted publish_at / unpublish_at keep the stored
+	// ones; this removes unpublish_at.
+	ClearUnpublishAt bool `json:"clear_unpublish_at,omitempty" swaggerignore:"true"`
+
+	// Read-only: resized renditions of the header (widest = photo_header), empty when it isn't a processed image.
+	PhotoHeaderSrcset []models.ImageSource `json:"photo_header_srcset"`
+
+	// Input only: nil keeps the current assignment on update, empty clears it.
+	CategoryIDs []uint `json:"category_ids,omitempty" swaggerignore:"true"`
+	TagIDs      []uint `json:"tag_ids,omitempty" swaggerignore:"true"`
+
+	Categories []CategoryDTO `json:"categories"`
+	Tags       []TagDTO      `json:"tags"`
 
 	CreatedAt int64 `json:"created_at,omitempty"`
 	UpdatedAt int64 `json:"updated_at,omitempty"`
+
+	// Rendered content, only filled when requested with ?format=html|text
+	ContentHTML string `json:"content_html,omitempty"`
+	ContentText string `json:"content_text,omitempty"`
 }
 
 func ArticleDTOToModel(dto ArticleDTO) (models.Article, error) {
 	return models.Article{
 		Title:       dto.Title,
+		Slug:        dto.Slug,
 		PhotoHeader: dto.PhotoHeader,
 		Content:     dto.Content,
 		Author:      dto.Author,
 		Status:      dto.Status,
+		PublishAt:   dto.PublishAt,
+		UnpublishAt: dto.UnpublishAt,
 	}, nil
 }
 
 func ArticleModelToDTO(article models.Article) ArticleDTO {
+	categories := make([]CategoryDTO, 0, len(article.Categories))
+	for _, c := range article.Categories {
+		categories = append(categories, CategoryModelToDTO(c))
+	}
+	tags := make([]TagDTO, 0, len(article.Tags))
+	for _, t := range article.Tags {
+		tags = append(tags, TagModelToDTO(t))
+	}
+
 	return ArticleDTO{
 		ID:          article.ID,
 		Title:       article.Title,
+		Slug:        article.Slug,
 		PhotoHeader: article.PhotoHeader,
 		Content:     article.Content,
+		Excerpt:     article.Excerpt,
 		Author:      article.Author,
 		Status:      article.Status,
+		PublishAt:   article.PublishAt,
+		UnpublishAt: article.UnpublishAt,
+		Categories:  categories,
+		Tags:        tags,
 		CreatedAt:   article.CreatedAt,
 		UpdatedAt:   article.UpdatedAt,
+
+		PhotoHeaderSrcset: append([]models.ImageSource{}, article.PhotoHeaderSrcset...),
 	}
 }
+
+// ArticleSearchResultDTO is an article plus its search rank and highlighted
+// fragments (matches wrapped in <mark>...</mark>).
+type ArticleSearchResultDTO struct {
+	ArticleDTO
+	Rank           float64 `json:"rank"`
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        string  `json:"snippet"`
+}
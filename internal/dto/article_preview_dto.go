@@ -0,0 +1,35 @@
+package dto
+
+import "darulabror/internal/models"
+
+type PreviewLinkCreateRequest struct {
+	ExpiresInHours int    `json:"expires_in_hours" validate:"omitempty,min=1,max=720" example:"72"` // default 72
+	Note           string `json:"note" validate:"omitempty,max=200" example:"Kepala sekolah"`
+}
+
+// PreviewLinkDTO describes a preview link. Token and URL are only returned once, on creation.
+type PreviewLinkDTO struct {
+	ID        uint   `json:"id"`
+	ArticleID uint   `json:"article_id"`
+	Token     string `json:"token,omitempty"`
+	URL       string `json:"url,omitempty"` // website preview page, when SITE_URL is set
+	Note      string `json:"note"`
+	CreatedBy uint   `json:"created_by"`
+	ExpiresAt int64  `json:"expires_at"`
+	RevokedAt *int64 `json:"revoked_at"`
+	Active    bool   `json:"active"` // not revoked and not expired
+	CreatedAt int64  `json:"created_at"`
+}
+
+func PreviewLinkModelToDTO(link models.ArticlePreviewLink, now int64) PreviewLinkDTO {
+	return PreviewLinkDTO{
+		ID:        link.ID,
+		ArticleID: link.ArticleID,
+		Note:      link.Note,
+		CreatedBy: link.CreatedBy,
+		ExpiresAt: link.ExpiresAt,
+		RevokedAt: link.RevokedAt,
+		Active:    link.RevokedAt == nil && link.ExpiresAt > now,
+		CreatedAt: link.CreatedAt,
+	}
+}
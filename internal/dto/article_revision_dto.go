@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"darulabror/internal/models"
+	"encoding/json"
+
+	"gorm.io/datatypes"
+)
+
+// ArticleRevisionDTO is a stored snapshot of an article. Content is omitted in listings.
+type ArticleRevisionDTO struct {
+	ArticleID    uint           `json:"article_id"`
+	Revision     int            `json:"revision"`
+	Title        string         `json:"title"`
+	Slug         string         `json:"slug"`
+	PhotoHeader  string         `json:"photo_header"`
+	Content      datatypes.JSON `json:"content,omitempty"`
+	Author       string         `json:"author"`
+	Status       string         `json:"status"`
+	AdminID      *uint          `json:"admin_id"`
+	RestoredFrom *int           `json:"restored_from,omitempty"`
+	CreatedAt    int64          `json:"created_at"`
+}
+
+func ArticleRevisionModelToDTO(rev models.ArticleRevision) ArticleRevisionDTO {
+	return ArticleRevisionDTO{
+		ArticleID:    rev.ArticleID,
+		Revision:     rev.Revision,
+		Title:        rev.Title,
+		Slug:         rev.Slug,
+		PhotoHeader:  rev.PhotoHeader,
+		Content:      rev.Content,
+		Author:       rev.Author,
+		Status:       rev.Status,
+		AdminID:      rev.AdminID,
+		RestoredFrom: rev.RestoredFrom,
+		CreatedAt:    rev.CreatedAt,
+	}
+}
+
+// Block diff operations.
+const (
+	BlockOpEqual   = "equal"
+	BlockOpAdded   = "added"
+	BlockOpRemoved = "removed"
+	BlockOpChanged = "changed"
+)
+
+// FieldChangeDTO is a scalar article field that differs between two revisions.
+type FieldChangeDTO struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// BlockChangeDTO is one step of the content block diff, in document order.
+// FromIndex/ToIndex are the block positions in the "from"/"to" revision.
+type BlockChangeDTO struct {
+	Op          string          `json:"op" enums:"equal,added,removed,changed"`
+	FromIndex   *int            `json:"from_index,omitempty"`
+	ToIndex     *int            `json:"to_index,omitempty"`
+	Type        string          `json:"type,omitempty"`
+	Before      json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After       json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	ChangedKeys []string        `json:"changed_keys,omitempty"`
+}
+
+type ArticleRevisionDiffDTO struct {
+	ArticleID uint             `json:"article_id"`
+	From      int              `json:"from"`
+	To        int              `json:"to"`
+	Fields    []FieldChangeDTO `json:"fields"`
+	Blocks    []BlockChangeDTO `json:"blocks"`
+}
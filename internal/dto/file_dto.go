@@ -0,0 +1,7 @@
+package dto
+
+// SignedURLDTO is a short-lived link to a private file.
+type SignedURLDTO struct {
+	URL       string `json:"url"`
+	ExpiresAt int64  `json:"expires_at"` // unix seconds
+}
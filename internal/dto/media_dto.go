@@ -0,0 +1,86 @@
+package dto
+
+import "darulabror/internal/models"
+
+type MediaDTO struct {
+	ID         uint   `json:"id"`
+	URL        string `json:"url"`
+	ObjectName string `json:"object_name"`
+	FileName   string `json:"file_name"`
+	MimeType   string `json:"mime_type"`
+	Size       int64  `json:"size"` // bytes
+	Width      *int   `json:"width"`
+	Height     *int   `json:"height"`
+	UploadedBy uint   `json:"uploaded_by"`
+
+	// Processed images: resized renditions, narrowest first (empty for other files).
+	Srcset []models.ImageSource `json:"srcset"`
+
+	// Number of articles using the item (listing only).
+	UsageCount *int64 `json:"usage_count,omitempty"`
+
+	CreatedAt int64 `json:"created_at"`
+}
+
+// MediaUsageDTO is an article that uses a media item.
+type MediaUsageDTO struct {
+	ArticleID uint   `json:"article_id"`
+	Title     string `json:"title"`
+	Slug      string `json:"slug"`
+	Status    string `json:"status"`
+	Trashed   bool   `json:"trashed"` // article is in the trash but may still be restored
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+func MediaModelToDTO(media models.Media) MediaDTO {
+	return MediaDTO{
+		ID:         media.ID,
+		URL:        media.URL,
+		ObjectName: media.ObjectName,
+		FileName:   media.FileName,
+		MimeType:   media.MimeType,
+		Size:       media.Size,
+		Width:      media.Width,
+		Height:     media.Height,
+		UploadedBy: media.UploadedBy,
+		Srcset:     media.Srcset(),
+		CreatedAt:  media.CreatedAt,
+	}
+}
+
+func MediaUsageFromArticle(article models.Article) MediaUsageDTO {
+	return MediaUsageDTO{
+		ArticleID: article.ID,
+		Title:     article.Title,
+		Slug:      article.Slug,
+		Status:    article.Status,
+		Trashed:   article.DeletedAt.Valid,
+		UpdatedAt: article.UpdatedAt,
+	}
+}
+
+// MediaGCReportDTO is the result of one orphaned-media scan.
+type MediaGCReportDTO struct {
+	DryRun       bool   `json:"dry_run"`
+	Prefix       string `json:"prefix"`        // storage prefix that was scanned
+	BaseURL      string `json:"base_url"`      // what the store puts before object names in URLs ("" for private stores)
+	GracePeriod  string `json:"grace_period"`  // e.g. "168h0m0s"
+	Cutoff       int64  `json:"cutoff"`        // unix seconds; only objects last written before this are collected
+	Scanned      int    `json:"scanned"`       // objects under the prefix
+	Referenced   int    `json:"referenced"`    // used by an article (trashed included)
+	WithinGrace  int    `json:"within_grace"`  // unreferenced but too recent to collect
+	Orphans      int    `json:"orphans"`       // unreferenced and older than the cutoff
+	OrphanBytes  int64  `json:"orphan_bytes"`  // total size of the orphans
+	Deleted      int    `json:"deleted"`       // objects actually deleted (0 on dry run)
+	MediaDeleted int64  `json:"media_deleted"` // media library rows removed with them
+
+	Items []MediaGCObjectDTO `json:"items"` // the orphans
+}
+
+type MediaGCObjectDTO struct {
+	ObjectName string `json:"object_name"`
+	URL        string `json:"url"`
+	Size       int64  `json:"size"`
+	UpdatedAt  int64  `json:"updated_at"`
+	MediaID    *uint  `json:"media_id"` // media library item for the object, if any
+}
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"darulabror/internal/models"
+	"time"
+)
+
+type NotificationDeliveryDTO struct {
+	ID             uint                  `json:"id"`
+	Channel        string                `json:"channel" example:"whatsapp"`
+	Event          string                `json:"event" example:"registration_received"`
+	RegistrationID *uint                 `json:"registration_id,omitempty"`
+	Recipient      string                `json:"recipient" example:"+6281234567890"`
+	Message        string                `json:"message"`
+	Status         models.DeliveryStatus `json:"status" example:"pending"`
+	Attempts       int                   `json:"attempts"`
+	LastError      string                `json:"last_error,omitempty"`
+	NextAttemptAt  string                `json:"next_attempt_at,omitempty"` // RFC3339; empty once sent or failed
+	SentAt         string                `json:"sent_at,omitempty"`
+	CreatedAt      string                `json:"created_at"`
+	UpdatedAt      string                `json:"updated_at"`
+}
+
+func NotificationDeliveryModelToDTO(m models.NotificationDelivery) NotificationDeliveryDTO {
+	out := NotificationDeliveryDTO{
+		ID:             m.ID,
+		Channel:        m.Channel,
+		Event:          m.Event,
+		RegistrationID: m.RegistrationID,
+		Recipient:      m.Recipient,
+		Message:        m.Message,
+		Status:         m.Status,
+		Attempts:       m.Attempts,
+		LastError:      m.LastError,
+		CreatedAt:      m.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      m.UpdatedAt.Format(time.RFC3339),
+	}
+	if m.NextAttemptAt != nil {
+		out.NextAttemptAt = m.NextAttemptAt.Format(time.RFC3339)
+	}
+	if m.SentAt != nil {
+		out.SentAt = m.SentAt.Format(time.RFC3339)
+	}
+	return out
+}
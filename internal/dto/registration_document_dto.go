@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"darulabror/internal/models"
+	"time"
+)
+
+type RegistrationDocumentDTO struct {
+	ID             uint                            `json:"id"`
+	RegistrationID uint                            `json:"registration_id"`
+	Type           models.RegistrationDocumentType `json:"type"`
+	FileName       string                          `json:"file_name"`
+	MimeType       string                          `json:"mime_type"`
+	Size           int64                           `json:"size"` // bytes
+	Status         models.DocumentStatus           `json:"status"`
+	RejectReason   string                          `json:"reject_reason,omitempty"`
+	ReviewedBy     *uint                           `json:"reviewed_by,omitempty"`
+	ReviewedAt     string                          `json:"reviewed_at,omitempty"`
+	CreatedAt      string                          `json:"created_at"`
+	UpdatedAt      string                          `json:"updated_at"`
+}
+
+// RegistrationDocumentChecklistDTO is what an applicant sees: the uploaded
+// documents and the types still to upload (never uploaded, or rejected).
+type RegistrationDocumentChecklistDTO struct {
+	Documents []RegistrationDocumentDTO         `json:"documents"`
+	Missing   []models.RegistrationDocumentType `json:"missing"`
+}
+
+// RegistrationDocumentReviewRequest marks a document verified or rejected.
+type RegistrationDocumentReviewRequest struct {
+	Status string `json:"status" validate:"required,oneof=verified rejected"`
+	Reason string `json:"reason" validate:"max=500"` // required when rejected
+}
+
+func RegistrationDocumentModelToDTO(m models.RegistrationDocument) RegistrationDocumentDTO {
+	out := RegistrationDocumentDTO{
+		ID:             m.ID,
+		RegistrationID: m.RegistrationID,
+		Type:           m.Type,
+		FileName:       m.FileName,
+		MimeType:       m.MimeType,
+		Size:           m.Size,
+		Status:         m.Status,
+		RejectReason:   m.RejectReason,
+		ReviewedBy:     m.ReviewedBy,
+		CreatedAt:      m.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      m.UpdatedAt.Format(time.RFC3339),
+	}
+	if m.ReviewedAt != nil {
+		out.ReviewedAt = m.ReviewedAt.Format(time.RFC3339)
+	}
+	return out
+}
+
+// MissingRegistrationDocuments lists the document types without an accepted
+// upload: never uploaded, or rejected.
+func MissingRegistrationDocuments(docs []models.RegistrationDocument) []models.RegistrationDocumentType {
+	have := map[models.RegistrationDocumentType]bool{}
+	for _, d := range docs {
+		if d.Status != models.DocumentRejected {
+			have[d.Type] = true
+		}
+	}
+	missing := make([]models.RegistrationDocumentType, 0)
+	for _, t := range models.RegistrationDocumentTypes {
+		if !have[t] {
+			missing = append(missing, t)
+		}
+	}
+	return missing
+}
+
+// RegistrationCreatedDTO is returned to the applicant after registering.
+type RegistrationCreatedDTO struct {
+	// Quoted to the office and used for the status lookup (POST /registrations/status-lookup)
+	ReferenceNumber string `json:"reference_number"`
+	// Authorizes document uploads (POST /registrations/documents, header
+	// X-Registration-Token). Shown once; keep it.
+	UploadToken          string `json:"upload_token"`
+	UploadTokenExpiresAt string `json:"upload_token_expires_at"`
+}
@@ -6,7 +6,8 @@ import (
 )
 
 type RegistrationDTO struct {
-	ID uint `json:"id" validate:"omitempty"`
+	ID              uint   `json:"id" validate:"omitempty"`
+	ReferenceNumber string `json:"reference_number,omitempty"` // read-only
 
 	StudentType models.StudentType        `json:"student_type" validate:"required,oneof=new transfer"`
 	FullName    string                    `json:"full_name" validate:"required,min=3,max=100"`
@@ -14,6 +15,9 @@ type RegistrationDTO struct {
 	Phone       string                    `json:"phone" validate:"required,min=10,max=13"`
 	Status      models.RegistrationStatus `json:"status,omitempty"`
 
+	// Read-only: assigned on create
+	AdmissionPeriodID *uint `json:"admission_period_id,omitempty"`
+
 	Gender       models.Gender `json:"gender" validate:"required,oneof=male female"`
 	PlaceOfBirth string        `json:"place_of_birth" validate:"required,min=3,max=100"`
 	DateOfBirth  string        `json:"date_of_birth" validate:"required,datetime=2006-01-02"`
@@ -78,6 +82,7 @@ func RegistrationDTOToModel(d RegistrationDTO) (models.Registration, error) {
 func RegistrationModelToDTO(m models.Registration) RegistrationDTO {
 	return RegistrationDTO{
 		ID:                m.ID,
+		ReferenceNumber:   m.ReferenceNumber,
 		StudentType:       m.StudentType,
 		FullName:          m.FullName,
 		Email:             m.Email,
@@ -97,6 +102,7 @@ func RegistrationModelToDTO(m models.Registration) RegistrationDTO {
 		PhoneMother:       m.PhoneMother,
 		DateOfBirthMother: m.DateOfBirthMother.Format(dateLayout),
 		Status:            m.Status,
+		AdmissionPeriodID: m.AdmissionPeriodID,
 		CreatedAt:         m.CreatedAt.Format(time.RFC3339),
 	}
 }
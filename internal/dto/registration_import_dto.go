@@ -0,0 +1,31 @@
+package dto
+
+// Outcome of an imported row (RegistrationImportRowDTO.Status).
+const (
+	ImportRowCreated = "created" // stored
+	ImportRowValid   = "valid"   // passed every check but was not stored (dry run, or an atomic import that failed)
+	ImportRowInvalid = "invalid" // see Errors
+)
+
+type RegistrationImportRowDTO struct {
+	Row             int      `json:"row" example:"2"` // line in the spreadsheet; the header is row 1
+	Status          string   `json:"status" example:"created"`
+	FullName        string   `json:"full_name,omitempty"`
+	NISN            string   `json:"nisn,omitempty"`
+	Errors          []string `json:"errors,omitempty"`
+	ID              uint     `json:"id,omitempty"`
+	ReferenceNumber string   `json:"reference_number,omitempty"`
+}
+
+type RegistrationImportReportDTO struct {
+	DryRun    bool `json:"dry_run"`
+	Atomic    bool `json:"atomic"`
+	PeriodID  uint `json:"admission_period_id"`
+	Total     int  `json:"total"`
+	Valid     int  `json:"valid"` // rows that passed the checks, stored or not
+	Invalid   int  `json:"invalid"`
+	Created   int  `json:"created"`
+	Committed bool `json:"committed"` // false when nothing was stored
+
+	Rows []RegistrationImportRowDTO `json:"rows"`
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"darulabror/internal/models"
+	"time"
+)
+
+// RegistrationStatusHistoryDTO is one entry of a registration's timeline. The
+// first entry is the creation (from_status empty).
+type RegistrationStatusHistoryDTO struct {
+	ID         uint                      `json:"id"`
+	FromStatus models.RegistrationStatus `json:"from_status"`
+	ToStatus   models.RegistrationStatus `json:"to_status"`
+	AdminID    *uint                     `json:"admin_id,omitempty"`
+	Note       string                    `json:"note,omitempty"`
+	CreatedAt  string                    `json:"created_at"`
+}
+
+func RegistrationStatusHistoryModelToDTO(m models.RegistrationStatusHistory) RegistrationStatusHistoryDTO {
+	return RegistrationStatusHistoryDTO{
+		ID:         m.ID,
+		FromStatus: m.FromStatus,
+		ToStatus:   m.ToStatus,
+		AdminID:    m.AdminID,
+		Note:       m.Note,
+		CreatedAt:  m.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// RegistrationStatusLookupRequest identifies a registration by its reference
+// number or the student's NISN (one of them is required), confirmed with the
+// date of birth.
+type RegistrationStatusLookupRequest struct {
+	ReferenceNumber string `json:"reference_number" validate:"omitempty,max=20" example:"DA25-7K3M9Q"`
+	NISN            string `json:"nisn" validate:"omitempty,len=10" example:"1234567890"`
+	DateOfBirth     string `json:"date_of_birth" validate:"required,datetime=2006-01-02" example:"2010-05-17"`
+}
+
+// RegistrationStatusLookupDTO is what applicants see of their registration.
+// Admin notes and IDs are left out of the timeline.
+type RegistrationStatusLookupDTO struct {
+	ReferenceNumber string                      `json:"reference_number"`
+	FullName        string                      `json:"full_name"`
+	Status          models.RegistrationStatus   `json:"status"`
+	AcademicYear    string                      `json:"academic_year,omitempty"`
+	WaveName        string                      `json:"wave_name,omitempty"`
+	SubmittedAt     string                      `json:"submitted_at"`
+	Timeline        []RegistrationStatusStepDTO `json:"timeline"`
+
+	// Documents still to upload: never uploaded, or rejected (with the reason)
+	MissingDocuments  []models.RegistrationDocumentType `json:"missing_documents"`
+	RejectedDocuments []RejectedDocumentDTO             `json:"rejected_documents"`
+}
+
+type RegistrationStatusStepDTO struct {
+	Status    models.RegistrationStatus `json:"status"`
+	ChangedAt string                    `json:"changed_at"`
+}
+
+type RejectedDocumentDTO struct {
+	Type   models.RegistrationDocumentType `json:"type"`
+	Reason string                          `json:"reason"`
+}
@@ -0,0 +1,65 @@
+package dto
+
+import "darulabror/internal/models"
+
+type CategoryDTO struct {
+	ID          uint   `json:"id" validate:"omitempty"`
+	Name        string `json:"name" validate:"required,min=2,max=50"`
+	Slug        string `json:"slug" validate:"omitempty,max=100"`
+	Description string `json:"description" validate:"omitempty,max=255"`
+
+	// Number of published articles (public listing) or all articles (admin listing).
+	ArticleCount *int64 `json:"article_count,omitempty"`
+
+	CreatedAt int64 `json:"created_at,omitempty"`
+	UpdatedAt int64 `json:"updated_at,omitempty"`
+}
+
+type TagDTO struct {
+	ID   uint   `json:"id" validate:"omitempty"`
+	Name string `json:"name" validate:"required,min=2,max=50"`
+	Slug string `json:"slug" validate:"omitempty,max=100"`
+
+	ArticleCount *int64 `json:"article_count,omitempty"`
+
+	CreatedAt int64 `json:"created_at,omitempty"`
+	UpdatedAt int64 `json:"updated_at,omitempty"`
+}
+
+func CategoryDTOToModel(dto CategoryDTO) models.Category {
+	return models.Category{
+		ID:          dto.ID,
+		Name:        dto.Name,
+		Slug:        dto.Slug,
+		Description: dto.Description,
+	}
+}
+
+func CategoryModelToDTO(category models.Category) CategoryDTO {
+	return CategoryDTO{
+		ID:          category.ID,
+		Name:        category.Name,
+		Slug:        category.Slug,
+		Description: category.Description,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+	}
+}
+
+func TagDTOToModel(dto TagDTO) models.Tag {
+	return models.Tag{
+		ID:   dto.ID,
+		Name: dto.Name,
+		Slug: dto.Slug,
+	}
+}
+
+func TagModelToDTO(tag models.Tag) TagDTO {
+	return TagDTO{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		Slug:      tag.Slug,
+		CreatedAt: tag.CreatedAt,
+		UpdatedAt: tag.UpdatedAt,
+	}
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// Trash entity types, as used in /admin/trash/:type.
+const (
+	TrashTypeArticles      = "articles"
+	TrashTypeRegistrations = "registrations"
+	TrashTypeContacts      = "contacts"
+)
+
+// TrashItemDTO is a soft-deleted row. Item holds the entity itself
+// (ArticleDTO, RegistrationDTO or contact).
+type TrashItemDTO struct {
+	Type      string      `json:"type" example:"registrations"`
+	ID        uint        `json:"id" example:"12"`
+	Label     string      `json:"label" example:"Ahmad Fauzi"` // title / full name / subject
+	DeletedAt time.Time   `json:"deleted_at"`
+	PurgeAt   *time.Time  `json:"purge_at,omitempty"` // when it is purged automatically; nil when retention is off
+	Item      interface{} `json:"item" swaggertype:"object"`
+}
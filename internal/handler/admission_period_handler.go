@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"darulabror/internal/dto"
+	"darulabror/internal/service"
+	"darulabror/internal/utils"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AdmissionPeriodHandler struct {
+	svc service.AdmissionPeriodService
+}
+
+func NewAdmissionPeriodHandler(svc service.AdmissionPeriodService) *AdmissionPeriodHandler {
+	return &AdmissionPeriodHandler{svc: svc}
+}
+
+// PUBLIC: GET /admission-periods/current
+// Current godoc
+// @Summary Get the current admission period
+// @Description The period open now, with the seats left per quota (null = no limit). Between periods, the next one to open (is_open false, no remaining). server_time is the API's clock, for countdowns.
+// @Tags Admission Periods
+// @Produce json
+// @Success 200 {object} SuccessResponse[dto.AdmissionPeriodDTO]
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admission-periods/current [get]
+func (h *AdmissionPeriodHandler) Current(c echo.Context) error {
+	period, err := h.svc.GetCurrent(time.Now())
+	if err != nil {
+		if errors.Is(err, service.ErrNotFoundAdmissionPeriod) {
+			return utils.NotFoundResponse(c, "no admission period is open or scheduled")
+		}
+		return admissionPeriodErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, "admission period fetched", period)
+}
+
+// ADMIN: GET /admin/admission-periods
+// AdminList godoc
+// @Summary Admin list admission periods
+// @Tags Admission Periods (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param academic_year query string false "Filter by academic year, e.g. 2025/2026"
+// @Success 200 {object} AdmissionPeriodListResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/admission-periods [get]
+func (h *AdmissionPeriodHandler) AdminList(c echo.Context) error {
+	items, err := h.svc.ListPeriods(c.QueryParam("academic_year"))
+	if err != nil {
+		return admissionPeriodErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, "admission periods fetched", items)
+}
+
+// ADMIN: GET /admin/admission-periods/:id
+// AdminGet godoc
+// @Summary Admin get admission period
+// @Tags Admission Periods (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Admission period ID" minimum(1)
+// @Success 200 {object} SuccessResponse[dto.AdmissionPeriodDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/admission-periods/{id} [get]
+func (h *AdmissionPeriodHandler) AdminGet(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	item, err := h.svc.GetPeriod(uint(id64))
+	if err != nil {
+		return admissionPeriodErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, "admission period fetched", item)
+}
+
+// ADMIN: POST /admin/admission-periods
+// AdminCreate godoc
+// @Summary Admin create admission period
+// @Description Periods can't overlap. Quotas are optional (omitted or null = no limit).
+// @Tags Admission Periods (Admin)
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.AdmissionPeriodDTO true "Admission period payload"
+// @Success 201 {object} SuccessResponse[dto.AdmissionPeriodDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/admission-periods [post]
+func (h *AdmissionPeriodHandler) AdminCreate(c echo.Context) error {
+	var body dto.AdmissionPeriodDTO
+	if err := c.Bind(&body); err != nil {
+		return utils.BadRequestResponse(c, "invalid body")
+	}
+	if err := c.Validate(&body); err != nil {
+		return utils.UnprocessableEntityResponse(c, err.Error())
+	}
+
+	item, err := h.svc.CreatePeriod(body)
+	if err != nil {
+		return admissionPeriodErrorResponse(c, err)
+	}
+	return utils.CreatedResponse(c, "admission period created", item)
+}
+
+// ADMIN: PUT /admin/admission-periods/:id
+// AdminUpdate godoc
+// @Summary Admin update admission period
+// @Description Lowering a quota below the seats already taken only stops new registrations; existing ones are kept.
+// @Tags Admission Periods (Admin)
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Admission period ID" minimum(1)
+// @Param request body dto.AdmissionPeriodDTO true "Admission period payload"
+// @Success 200 {object} SuccessResponse[dto.AdmissionPeriodDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/admission-periods/{id} [put]
+func (h *AdmissionPeriodHandler) AdminUpdate(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	var body dto.AdmissionPeriodDTO
+	if err := c.Bind(&body); err != nil {
+		return utils.BadRequestResponse(c, "invalid body")
+	}
+	if err := c.Validate(&body); err != nil {
+		return utils.UnprocessableEntityResponse(c, err.Error())
+	}
+
+	item, err := h.svc.UpdatePeriod(uint(id64), body)
+	if err != nil {
+		return admissionPeriodErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, "admission period updated", item)
+}
+
+// ADMIN: DELETE /admin/admission-periods/:id
+// AdminDelete godoc
+// @Summary Admin delete admission period
+// @Description Only periods without registrations (trashed ones included) can be deleted.
+// @Tags Admission Periods (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Admission period ID" minimum(1)
+// @Success 204 {string} string "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/admission-periods/{id} [delete]
+func (h *AdmissionPeriodHandler) AdminDelete(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	if err := h.svc.DeletePeriod(uint(id64)); err != nil {
+		return admissionPeriodErrorResponse(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func admissionPeriodErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrNotFoundAdmissionPeriod):
+		return utils.NotFoundResponse(c, err.Error())
+	case errors.Is(err, service.ErrAdmissionPeriodOverlaps), errors.Is(err, service.ErrAdmissionPeriodInUse):
+		return utils.ConflictResponse(c, err.Error())
+	case errors.Is(err, service.ErrInvalidAcademicYear), errors.Is(err, service.ErrInvalidAdmissionDates):
+		return utils.UnprocessableEntityResponse(c, err.Error())
+	default:
+		return utils.InternalServerErrorResponse(c, err.Error())
+	}
+}
@@ -2,16 +2,17 @@ package handler
 
 import (
 	"context"
+	"darulabror/internal/content"
 	"darulabror/internal/dto"
+	"darulabror/internal/imaging"
 	"darulabror/internal/repository"
 	"darulabror/internal/service"
 	"darulabror/internal/utils"
 	"encoding/json"
 	"errors"
-	"io"
+	"fmt"
 	"mime/multipart"
 	"net/http"
-	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -21,11 +22,13 @@ import (
 )
 
 type ArticleHandler struct {
-	svc service.ArticleService
+	svc    service.ArticleService
+	media  service.MediaService
+	blocks *content.Registry
 }
 
-func NewArticleHandler(svc service.ArticleService) *ArticleHandler {
-	return &ArticleHandler{svc: svc}
+func NewArticleHandler(svc service.ArticleService, media service.MediaService, blocks *content.Registry) *ArticleHandler {
+	return &ArticleHandler{svc: svc, media: media, blocks: blocks}
 }
 
 // PUBLIC: GET /articles
@@ -36,12 +39,14 @@ func NewArticleHandler(svc service.ArticleService) *ArticleHandler {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Page size" default(10)
+// @Param category query string false "Filter by category slug"
+// @Param tag query string false "Filter by tag slug"
 // @Success 200 {object} ArticleListResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /articles [get]
 func (h *ArticleHandler) ListPublished(c echo.Context) error {
 	page, limit := utils.ParsePagination(c)
-	items, total, err := h.svc.GetPublishedArticles(page, limit)
+	items, total, err := h.svc.GetPublishedArticles(page, limit, parseArticleFilter(c))
 	if err != nil {
 		logrus.WithError(err).Error("failed list published articles")
 		return utils.InternalServerErrorResponse(c, "failed to fetch articles")
@@ -57,12 +62,52 @@ func (h *ArticleHandler) ListPublished(c echo.Context) error {
 	})
 }
 
+// PUBLIC: GET /articles/search
+// SearchPublished godoc
+// @Summary Search published articles
+// @Description Full-text search over title (weighted higher) and content text blocks, ranked by relevance.
+// @Description Supports web-search syntax: "exact phrase", -exclude, OR. Matches in title_highlight / snippet are wrapped in <mark>.
+// @Tags Articles (Public)
+// @Produce json
+// @Param q query string true "Search query" minlength(2) maxlength(200)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Page size" default(10)
+// @Param category query string false "Filter by category slug"
+// @Param tag query string false "Filter by tag slug"
+// @Success 200 {object} ArticleSearchResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /articles/search [get]
+func (h *ArticleHandler) SearchPublished(c echo.Context) error {
+	filter := parseArticleFilter(c)
+	if len([]rune(filter.Query)) < 2 {
+		return utils.BadRequestResponse(c, "q must be at least 2 characters")
+	}
+
+	page, limit := utils.ParsePagination(c)
+	items, total, err := h.svc.SearchPublishedArticles(page, limit, filter)
+	if err != nil {
+		logrus.WithError(err).Error("failed search published articles")
+		return utils.InternalServerErrorResponse(c, "failed to search articles")
+	}
+
+	return utils.SuccessResponse(c, "articles fetched", map[string]interface{}{
+		"items": items,
+		"meta": map[string]interface{}{
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
+
 // PUBLIC: GET /articles/:id
 // GetPublishedByID godoc
 // @Summary Get published article by ID
 // @Tags Articles (Public)
 // @Produce json
 // @Param id path int true "Article ID" minimum(1)
+// @Param format query string false "Also render content as sanitized HTML (content_html) or plain text (content_text)" Enums(json, html, text)
 // @Success 200 {object} SuccessResponse[dto.ArticleDTO]
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -77,6 +122,85 @@ func (h *ArticleHandler) GetPublishedByID(c echo.Context) error {
 	if err != nil {
 		return utils.NotFoundResponse(c, err.Error())
 	}
+	if err := h.svc.RenderArticleContent(&item, c.QueryParam("format")); err != nil {
+		return utils.BadRequestResponse(c, "format must be json, html or text")
+	}
+
+	return utils.SuccessResponse(c, "article fetched", item)
+}
+
+// PUBLIC: GET /articles/preview/:token
+// GetPreview godoc
+// @Summary Preview article through a preview link
+// @Description Returns the article (any status) exactly like the public detail endpoint, while the link is neither expired nor revoked.
+// @Description Responses are marked noindex and not cacheable.
+// @Tags Articles (Public)
+// @Produce json
+// @Param token path string true "Preview token"
+// @Param format query string false "Also render content as sanitized HTML (content_html) or plain text (content_text)" Enums(json, html, text)
+// @Success 200 {object} SuccessResponse[dto.ArticleDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /articles/preview/{token} [get]
+func (h *ArticleHandler) GetPreview(c echo.Context) error {
+	c.Response().Header().Set("X-Robots-Tag", "noindex, nofollow")
+	c.Response().Header().Set("Cache-Control", "no-store")
+
+	token := strings.TrimSpace(c.Param("token"))
+	if token == "" {
+		return utils.NotFoundResponse(c, service.ErrNotFoundPreviewLink.Error())
+	}
+
+	item, err := h.svc.GetArticleByPreviewToken(token)
+	if err != nil {
+		return previewLinkErrorResponse(c, err)
+	}
+	if err := h.svc.RenderArticleContent(&item, c.QueryParam("format")); err != nil {
+		return utils.BadRequestResponse(c, "format must be json, html or text")
+	}
+
+	return utils.SuccessResponse(c, "article preview fetched", item)
+}
+
+// PUBLIC: GET /articles/slug/:slug
+// GetPublishedBySlug godoc
+// @Summary Get published article by slug
+// @Description Old slugs (before a rename) answer with 301 to the current slug.
+// @Tags Articles (Public)
+// @Produce json
+// @Param slug path string true "Article slug"
+// @Param format query string false "Also render content as sanitized HTML (content_html) or plain text (content_text)" Enums(json, html, text)
+// @Success 200 {object} SuccessResponse[dto.ArticleDTO]
+// @Success 301 {string} string "Moved Permanently"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /articles/slug/{slug} [get]
+func (h *ArticleHandler) GetPublishedBySlug(c echo.Context) error {
+	slug := strings.TrimSpace(c.Param("slug"))
+	if slug == "" {
+		return utils.NotFoundResponse(c, service.ErrNotFoundArticle.Error())
+	}
+
+	item, err := h.svc.GetPublishedArticleBySlug(slug)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrArticleSlugMoved):
+			target := "/articles/slug/" + item.Slug
+			if q := c.QueryString(); q != "" {
+				target += "?" + q
+			}
+			return c.Redirect(http.StatusMovedPermanently, target)
+		case errors.Is(err, service.ErrNotFoundArticle):
+			return utils.NotFoundResponse(c, err.Error())
+		default:
+			logrus.WithError(err).WithField("slug", slug).Error("failed get article by slug")
+			return utils.InternalServerErrorResponse(c, "failed to fetch article")
+		}
+	}
+	if err := h.svc.RenderArticleContent(&item, c.QueryParam("format")); err != nil {
+		return utils.BadRequestResponse(c, "format must be json, html or text")
+	}
 
 	return utils.SuccessResponse(c, "article fetched", item)
 }
@@ -84,12 +208,15 @@ func (h *ArticleHandler) GetPublishedByID(c echo.Context) error {
 // ADMIN: GET /admin/articles
 // AdminListAll godoc
 // @Summary Admin list all articles
-// @Description Returns draft + published.
+// @Description Returns draft + published + scheduled.
 // @Tags Articles (Admin)
 // @Security BearerAuth
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Page size" default(10)
+// @Param category query string false "Filter by category slug"
+// @Param tag query string false "Filter by tag slug"
+// @Param q query string false "Full-text search; results are ordered by relevance"
 // @Success 200 {object} ArticleListResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
@@ -97,7 +224,7 @@ func (h *ArticleHandler) GetPublishedByID(c echo.Context) error {
 // @Router /admin/articles [get]
 func (h *ArticleHandler) AdminListAll(c echo.Context) error {
 	page, limit := utils.ParsePagination(c)
-	items, total, err := h.svc.GetAllArticles(page, limit)
+	items, total, err := h.svc.GetAllArticles(page, limit, parseArticleFilter(c))
 	if err != nil {
 		logrus.WithError(err).Error("failed admin list all articles")
 		return utils.InternalServerErrorResponse(c, "failed to fetch articles")
@@ -121,21 +248,35 @@ func (h *ArticleHandler) AdminListAll(c echo.Context) error {
 // @Accept multipart/form-data
 // @Produce json
 // @Param title formData string true "Title"
+// @Param slug formData string false "Optional custom slug (auto-generated from title if empty)"
 // @Param author formData string true "Author"
-// @Param status formData string false "draft|published" Enums(draft,published)
-// @Param content formData string true "JSON string (flexible)"
+// @Param status formData string false "draft|published|scheduled (defaults to scheduled when publish_at is set)" Enums(draft,published,scheduled)
+// @Param publish_at formData string false "Go-live time for scheduled articles: RFC3339 (2025-01-01T07:00:00+07:00) or unix seconds"
+// @Param unpublish_at formData string false "Optional expiry (article goes back to draft): RFC3339 or unix seconds"
+// @Param content formData string true "JSON string with a blocks array; each block is validated against its type (422 lists every problem, e.g. blocks[3].url is required)"
+// @Param category_ids formData string false "Comma-separated category IDs (on update: omit to keep, send empty to clear)"
+// @Param tag_ids formData string false "Comma-separated tag IDs (on update: omit to keep, send empty to clear)"
 // @Param photo_header formData string false "Optional header URL (ignored if photo_header_file is provided)"
-// @Param photo_header_file formData file false "Optional header image file (uploaded and set to photo_header)"
-// @Param content_files formData file false "Inline media files. Use field name: content_files[<upload_key>] (repeatable). Example: content_files[img1], content_files[vid1]"
+// @Param photo_header_file formData file false "Optional header image file (uploaded to the media library and set to photo_header). Images only: 415 for other types, 413 over the size limit"
+// @Param photo_header_media_id formData int false "Optional media library item to use as header (ignored if photo_header_file is provided)"
+// @Param content_files formData file false "Inline media files, added to the media library. Use field name: content_files[<upload_key>] (repeatable). Example: content_files[img1], content_files[vid1]. Existing library items are referenced with media_id in the block instead. Types are sniffed from the content: images, video and PDF (image blocks take images, video blocks video); 415/413 before anything is uploaded"
 // @Success 201 {string} string "Created"
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
 // @Failure 422 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /admin/articles [post]
 func (h *ArticleHandler) AdminCreate(c echo.Context) error {
+	adminID, ok := utils.GetAdminID(c)
+	if !ok {
+		return utils.UnauthorizedResponse(c, "unauthorized")
+	}
+
 	title := c.FormValue("title")
+	slug := c.FormValue("slug")
 	author := c.FormValue("author")
 	status := c.FormValue("status")
 	contentStr := c.FormValue("content")
@@ -152,68 +293,114 @@ func (h *ArticleHandler) AdminCreate(c echo.Context) error {
 	if err := json.Unmarshal([]byte(contentStr), &contentAny); err != nil {
 		return utils.BadRequestResponse(c, "content must be valid JSON")
 	}
+	// legacy bare block arrays are stored in the object form from now on
+	contentAny = content.Normalize(contentAny)
+	// validate block shapes before uploading anything
+	if err := h.blocks.Validate(contentAny, contentUploadKeys(c)); err != nil {
+		return utils.UnprocessableEntityResponse(c, err.Error())
+	}
+	// and every file's type and size (415/413)
+	if err := h.checkUploads(c, contentAny); err != nil {
+		return uploadErrorResponse(c, err, "failed to read uploaded files")
+	}
 
-	// 2) upload inline content files (content_files[<key>])
-	urlByKey, err := h.parseAndUploadContentFiles(c)
+	categoryIDs, err := parseFormIDs(c, "category_ids")
 	if err != nil {
-		if errors.Is(err, repository.ErrStorageNotConfigured) {
-			return utils.BadRequestResponse(c, "storage not configured: set PUBLIC_BUCKET to enable uploads")
-		}
-		logrus.WithError(err).Error("failed upload content files")
-		return utils.InternalServerErrorResponse(c, "failed to upload content files")
+		return utils.BadRequestResponse(c, "category_ids must be comma-separated numeric IDs")
+	}
+	tagIDs, err := parseFormIDs(c, "tag_ids")
+	if err != nil {
+		return utils.BadRequestResponse(c, "tag_ids must be comma-separated numeric IDs")
 	}
 
-	// 3) inject URLs into content JSON
-	if len(urlByKey) > 0 {
-		contentAny = injectUploadedURLs(contentAny, urlByKey)
+	publishAt, err := parseFormTime(c.FormValue("publish_at"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "publish_at must be RFC3339 or unix seconds")
 	}
-	contentBytes, err := json.Marshal(contentAny)
+	unpublishAt, err := parseFormTime(c.FormValue("unpublish_at"))
 	if err != nil {
-		return utils.InternalServerErrorResponse(c, "failed to serialize content")
+		return utils.BadRequestResponse(c, "unpublish_at must be RFC3339 or unix seconds")
 	}
 
-	body := dto.ArticleDTO{
-		Title:       title,
-		Author:      author,
-		Status:      status,
-		PhotoHeader: c.FormValue("photo_header"),
-		Content:     contentBytes,
+	// 2) header: photo_header_file (uploaded below) overrides photo_header_media_id / photo_header
+	headerFile, _ := c.FormFile("photo_header_file")
+	var photoHeader string
+	if headerFile == nil {
+		if photoHeader, err = h.photoHeaderFromRequest(c); err != nil {
+			return uploadErrorResponse(c, err, "failed to read header")
+		}
+		if strings.TrimSpace(photoHeader) == "" {
+			return utils.BadRequestResponse(c, "photo_header is required (provide photo_header URL, photo_header_media_id or upload photo_header_file)")
+		}
 	}
 
-	// 4) optional header upload (photo_header_file) overrides photo_header
-	if fh, err := c.FormFile("photo_header_file"); err == nil && fh != nil {
-		src, err := fh.Open()
-		if err != nil {
-			return utils.BadRequestResponse(c, "failed to open photo_header_file")
+	// 3) upload the header and inline content files (content_files[<key>]) into the
+	// media library; if the save fails they are deleted again
+	var uploads []dto.MediaDTO
+	saved := false
+	defer func() {
+		if !saved {
+			h.discardUploads(c, uploads)
 		}
-		defer src.Close()
-
-		safeName := filepath.Base(fh.Filename)
-		objectName := "articles/header_" + strconv.FormatInt(time.Now().UnixNano(), 10) + "_" + safeName
+	}()
 
-		urlOrObject, err := h.svc.UploadArticleMedia(c.Request().Context(), src, objectName)
+	if headerFile != nil {
+		media, err := h.uploadMedia(c, adminID, headerFile, service.UploadSlotArticleHeader)
 		if err != nil {
-			if errors.Is(err, repository.ErrStorageNotConfigured) {
-				return utils.BadRequestResponse(c, "storage not configured: set PUBLIC_BUCKET to enable uploads")
-			}
-			logrus.WithError(err).Error("failed upload photo_header_file")
-			return utils.InternalServerErrorResponse(c, "failed to upload header")
+			return uploadErrorResponse(c, err, "failed to upload header")
 		}
-		body.PhotoHeader = urlOrObject
+		uploads = append(uploads, media)
+		photoHeader = media.URL
 	}
 
-	// after parsing fields + (optional) uploading photo_header_file
-	if strings.TrimSpace(body.PhotoHeader) == "" {
-		return utils.BadRequestResponse(c, "photo_header is required (provide photo_header URL or upload photo_header_file)")
+	mediaByKey, err := h.parseAndUploadContentFiles(c, adminID)
+	for _, media := range mediaByKey {
+		uploads = append(uploads, media)
+	}
+	if err != nil {
+		return uploadErrorResponse(c, err, "failed to upload content files")
+	}
+
+	// 4) inject URLs (and media IDs) into content JSON
+	if len(mediaByKey) > 0 {
+		contentAny = injectUploadedMedia(contentAny, mediaByKey)
+	}
+	contentBytes, err := json.Marshal(contentAny)
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, "failed to serialize content")
+	}
+
+	body := dto.ArticleDTO{
+		Title:       title,
+		Slug:        slug,
+		Author:      author,
+		Status:      status,
+		PublishAt:   publishAt,
+		UnpublishAt: unpublishAt,
+		PhotoHeader: photoHeader,
+		Content:     contentBytes,
+		CategoryIDs: categoryIDs,
+		TagIDs:      tagIDs,
 	}
 
 	if err := c.Validate(&body); err != nil {
 		return utils.UnprocessableEntityResponse(c, err.Error())
 	}
 
-	if err := h.svc.CreateArticle(body); err != nil {
+	if err := h.svc.CreateArticle(adminID, body); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArticleSlug):
+			return utils.UnprocessableEntityResponse(c, "slug must contain at least one letter or digit")
+		case errors.Is(err, service.ErrInvalidCategory), errors.Is(err, service.ErrInvalidTag):
+			return utils.UnprocessableEntityResponse(c, "unknown category_ids or tag_ids")
+		case errors.Is(err, service.ErrInvalidMedia):
+			return utils.UnprocessableEntityResponse(c, "content references a media_id that does not exist")
+		case errors.Is(err, service.ErrInvalidArticleSchedule):
+			return utils.UnprocessableEntityResponse(c, "scheduled status requires publish_at; unpublish_at must be in the future and after publish_at")
+		}
 		return utils.InternalServerErrorResponse(c, err.Error())
 	}
+	saved = true
 	return c.NoContent(http.StatusCreated)
 }
 
@@ -226,26 +413,41 @@ func (h *ArticleHandler) AdminCreate(c echo.Context) error {
 // @Produce json
 // @Param id path int true "Article ID" minimum(1)
 // @Param title formData string true "Title"
+// @Param slug formData string false "Optional custom slug (auto-generated from title if empty)"
 // @Param author formData string true "Author"
-// @Param status formData string false "draft|published" Enums(draft,published)
-// @Param content formData string true "JSON string (flexible)"
+// @Param status formData string false "draft|published|scheduled (defaults to scheduled when publish_at is set)" Enums(draft,published,scheduled)
+// @Param publish_at formData string false "Go-live time for scheduled articles: RFC3339 (2025-01-01T07:00:00+07:00) or unix seconds (omit to keep; dropped when the status isn't scheduled)"
+// @Param unpublish_at formData string false "Optional expiry (article goes back to draft): RFC3339 or unix seconds (omit to keep)"
+// @Param clear_unpublish_at formData bool false "Remove the expiry"
+// @Param content formData string true "JSON string with a blocks array; each block is validated against its type (422 lists every problem, e.g. blocks[3].url is required)"
+// @Param category_ids formData string false "Comma-separated category IDs (on update: omit to keep, send empty to clear)"
+// @Param tag_ids formData string false "Comma-separated tag IDs (on update: omit to keep, send empty to clear)"
 // @Param photo_header formData string false "Optional header URL (ignored if photo_header_file is provided)"
-// @Param photo_header_file formData file false "Optional header image file (uploaded and set to photo_header)"
-// @Param content_files formData file false "Inline media files. Use field name: content_files[<upload_key>] (repeatable). Example: content_files[img1], content_files[vid1]"
+// @Param photo_header_file formData file false "Optional header image file (uploaded to the media library and set to photo_header). Images only: 415 for other types, 413 over the size limit"
+// @Param photo_header_media_id formData int false "Optional media library item to use as header (ignored if photo_header_file is provided)"
+// @Param content_files formData file false "Inline media files, added to the media library. Use field name: content_files[<upload_key>] (repeatable). Example: content_files[img1], content_files[vid1]. Existing library items are referenced with media_id in the block instead. Types are sniffed from the content: images, video and PDF (image blocks take images, video blocks video); 415/413 before anything is uploaded"
 // @Success 200 {string} string "OK"
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
 // @Failure 422 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /admin/articles/{id} [put]
 func (h *ArticleHandler) AdminUpdate(c echo.Context) error {
+	adminID, ok := utils.GetAdminID(c)
+	if !ok {
+		return utils.UnauthorizedResponse(c, "unauthorized")
+	}
 	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
 		return utils.BadRequestResponse(c, "invalid id")
 	}
 
 	title := c.FormValue("title")
+	slug := c.FormValue("slug")
 	author := c.FormValue("author")
 	status := c.FormValue("status")
 	contentStr := c.FormValue("content")
@@ -262,65 +464,123 @@ func (h *ArticleHandler) AdminUpdate(c echo.Context) error {
 	if err := json.Unmarshal([]byte(contentStr), &contentAny); err != nil {
 		return utils.BadRequestResponse(c, "content must be valid JSON")
 	}
+	// legacy bare block arrays are stored in the object form from now on
+	contentAny = content.Normalize(contentAny)
+	// validate block shapes before uploading anything
+	if err := h.blocks.Validate(contentAny, contentUploadKeys(c)); err != nil {
+		return utils.UnprocessableEntityResponse(c, err.Error())
+	}
+	// and every file's type and size (415/413)
+	if err := h.checkUploads(c, contentAny); err != nil {
+		return uploadErrorResponse(c, err, "failed to read uploaded files")
+	}
 
-	urlByKey, err := h.parseAndUploadContentFiles(c)
+	categoryIDs, err := parseFormIDs(c, "category_ids")
 	if err != nil {
-		if errors.Is(err, repository.ErrStorageNotConfigured) {
-			return utils.BadRequestResponse(c, "storage not configured: set PUBLIC_BUCKET to enable uploads")
-		}
-		logrus.WithError(err).Error("failed upload content files")
-		return utils.InternalServerErrorResponse(c, "failed to upload content files")
-	}
-	if len(urlByKey) > 0 {
-		contentAny = injectUploadedURLs(contentAny, urlByKey)
+		return utils.BadRequestResponse(c, "category_ids must be comma-separated numeric IDs")
 	}
-	contentBytes, err := json.Marshal(contentAny)
+	tagIDs, err := parseFormIDs(c, "tag_ids")
 	if err != nil {
-		return utils.InternalServerErrorResponse(c, "failed to serialize content")
+		return utils.BadRequestResponse(c, "tag_ids must be comma-separated numeric IDs")
 	}
 
-	body := dto.ArticleDTO{
-		Title:       title,
-		Author:      author,
-		Status:      status,
-		PhotoHeader: c.FormValue("photo_header"),
-		Content:     contentBytes,
+	publishAt, err := parseFormTime(c.FormValue("publish_at"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "publish_at must be RFC3339 or unix seconds")
+	}
+	unpublishAt, err := parseFormTime(c.FormValue("unpublish_at"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "unpublish_at must be RFC3339 or unix seconds")
+	}
+	var clearUnpublishAt bool
+	if raw := strings.TrimSpace(c.FormValue("clear_unpublish_at")); raw != "" {
+		if clearUnpublishAt, err = strconv.ParseBool(raw); err != nil {
+			return utils.BadRequestResponse(c, "invalid clear_unpublish_at")
+		}
+	}
+	if clearUnpublishAt && unpublishAt != nil {
+		return utils.BadRequestResponse(c, "send either unpublish_at or clear_unpublish_at")
 	}
 
-	// Optional header upload: if provided, overrides photo_header string
-	if fh, err := c.FormFile("photo_header_file"); err == nil && fh != nil {
-		src, err := fh.Open()
-		if err != nil {
-			return utils.BadRequestResponse(c, "failed to open photo_header_file")
+	// Optional header: photo_header_file (uploaded below) overrides photo_header_media_id / photo_header
+	headerFile, _ := c.FormFile("photo_header_file")
+	var photoHeader string
+	if headerFile == nil {
+		if photoHeader, err = h.photoHeaderFromRequest(c); err != nil {
+			return uploadErrorResponse(c, err, "failed to read header")
 		}
-		defer src.Close()
+		if strings.TrimSpace(photoHeader) == "" {
+			return utils.BadRequestResponse(c, "photo_header is required (provide photo_header URL, photo_header_media_id or upload photo_header_file)")
+		}
+	}
 
-		safeName := filepath.Base(fh.Filename)
-		objectName := "articles/header_" + strconv.FormatInt(time.Now().UnixNano(), 10) + "_" + safeName
+	// Same upload flow as create: deleted again if the save fails
+	var uploads []dto.MediaDTO
+	saved := false
+	defer func() {
+		if !saved {
+			h.discardUploads(c, uploads)
+		}
+	}()
 
-		urlOrObject, err := h.svc.UploadArticleMedia(c.Request().Context(), src, objectName)
+	if headerFile != nil {
+		media, err := h.uploadMedia(c, adminID, headerFile, service.UploadSlotArticleHeader)
 		if err != nil {
-			if errors.Is(err, repository.ErrStorageNotConfigured) {
-				return utils.BadRequestResponse(c, "storage not configured: set PUBLIC_BUCKET to enable header upload")
-			}
-			logrus.WithError(err).Error("failed upload photo_header_file")
-			return utils.InternalServerErrorResponse(c, "failed to upload header")
+			return uploadErrorResponse(c, err, "failed to upload header")
 		}
-		body.PhotoHeader = urlOrObject
+		uploads = append(uploads, media)
+		photoHeader = media.URL
+	}
+
+	mediaByKey, err := h.parseAndUploadContentFiles(c, adminID)
+	for _, media := range mediaByKey {
+		uploads = append(uploads, media)
+	}
+	if err != nil {
+		return uploadErrorResponse(c, err, "failed to upload content files")
+	}
+	if len(mediaByKey) > 0 {
+		contentAny = injectUploadedMedia(contentAny, mediaByKey)
+	}
+	contentBytes, err := json.Marshal(contentAny)
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, "failed to serialize content")
 	}
 
-	// after parsing fields + (optional) uploading photo_header_file
-	if strings.TrimSpace(body.PhotoHeader) == "" {
-		return utils.BadRequestResponse(c, "photo_header is required (provide photo_header URL or upload photo_header_file)")
+	body := dto.ArticleDTO{
+		Title:            title,
+		Slug:             slug,
+		Author:           author,
+		Status:           status,
+		PublishAt:        publishAt,
+		UnpublishAt:      unpublishAt,
+		ClearUnpublishAt: clearUnpublishAt,
+		PhotoHeader:      photoHeader,
+		Content:          contentBytes,
+		CategoryIDs:      categoryIDs,
+		TagIDs:           tagIDs,
 	}
 
 	if err := c.Validate(&body); err != nil {
 		return utils.UnprocessableEntityResponse(c, err.Error())
 	}
 
-	if err := h.svc.UpdateArticle(uint(id64), body); err != nil {
+	if err := h.svc.UpdateArticle(adminID, uint(id64), body); err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotFoundArticle):
+			return utils.NotFoundResponse(c, err.Error())
+		case errors.Is(err, service.ErrInvalidArticleSlug):
+			return utils.UnprocessableEntityResponse(c, "slug must contain at least one letter or digit")
+		case errors.Is(err, service.ErrInvalidCategory), errors.Is(err, service.ErrInvalidTag):
+			return utils.UnprocessableEntityResponse(c, "unknown category_ids or tag_ids")
+		case errors.Is(err, service.ErrInvalidMedia):
+			return utils.UnprocessableEntityResponse(c, "content references a media_id that does not exist")
+		case errors.Is(err, service.ErrInvalidArticleSchedule):
+			return utils.UnprocessableEntityResponse(c, "scheduled status requires publish_at; unpublish_at must be in the future and after publish_at")
+		}
 		return utils.InternalServerErrorResponse(c, err.Error())
 	}
+	saved = true
 	return c.NoContent(http.StatusOK)
 }
 
@@ -351,6 +611,347 @@ func (h *ArticleHandler) AdminDelete(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// ADMIN: GET /admin/articles/:id/revisions
+// AdminListRevisions godoc
+// @Summary Admin list article revisions
+// @Description Newest first; content is omitted (fetch a single revision for it).
+// @Tags Articles (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Article ID" minimum(1)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Page size" default(10)
+// @Success 200 {object} ArticleRevisionListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/articles/{id}/revisions [get]
+func (h *ArticleHandler) AdminListRevisions(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	page, limit := utils.ParsePagination(c)
+	items, total, err := h.svc.GetArticleRevisions(uint(id64), page, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrNotFoundArticle) {
+			return utils.NotFoundResponse(c, err.Error())
+		}
+		return utils.InternalServerErrorResponse(c, "failed to fetch revisions")
+	}
+
+	return utils.SuccessResponse(c, "revisions fetched", map[string]interface{}{
+		"items": items,
+		"meta": map[string]interface{}{
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
+
+// ADMIN: GET /admin/articles/:id/revisions/:rev
+// AdminGetRevision godoc
+// @Summary Admin get article revision
+// @Tags Articles (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Article ID" minimum(1)
+// @Param rev path int true "Revision number" minimum(1)
+// @Success 200 {object} SuccessResponse[dto.ArticleRevisionDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/articles/{id}/revisions/{rev} [get]
+func (h *ArticleHandler) AdminGetRevision(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+	rev, err := parseRevision(c.Param("rev"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid revision")
+	}
+
+	item, err := h.svc.GetArticleRevision(uint(id64), rev)
+	if err != nil {
+		return revisionErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, "revision fetched", item)
+}
+
+// ADMIN: GET /admin/articles/:id/revisions/diff?from=&to=
+// AdminDiffRevisions godoc
+// @Summary Admin diff two article revisions
+// @Description Changed fields plus a block-by-block diff of content (op: equal, added, removed, changed).
+// @Description Content blocks are read from content.blocks, or content itself when it is an array.
+// @Tags Articles (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Article ID" minimum(1)
+// @Param from query int true "Older revision number" minimum(1)
+// @Param to query int true "Newer revision number" minimum(1)
+// @Success 200 {object} SuccessResponse[dto.ArticleRevisionDiffDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/articles/{id}/revisions/diff [get]
+func (h *ArticleHandler) AdminDiffRevisions(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+	from, err := parseRevision(c.QueryParam("from"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "from must be a revision number")
+	}
+	to, err := parseRevision(c.QueryParam("to"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "to must be a revision number")
+	}
+
+	diff, err := h.svc.DiffArticleRevisions(uint(id64), from, to)
+	if err != nil {
+		return revisionErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, "revision diff fetched", diff)
+}
+
+// ADMIN: POST /admin/articles/:id/revisions/:rev/restore
+// AdminRestoreRevision godoc
+// @Summary Admin restore article revision
+// @Description Makes the revision's title, photo header, content and author current, recorded as a new revision.
+// @Description Slug, status, schedule and categories/tags are kept as they are.
+// @Tags Articles (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Article ID" minimum(1)
+// @Param rev path int true "Revision number" minimum(1)
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/articles/{id}/revisions/{rev}/restore [post]
+func (h *ArticleHandler) AdminRestoreRevision(c echo.Context) error {
+	adminID, ok := utils.GetAdminID(c)
+	if !ok {
+		return utils.UnauthorizedResponse(c, "unauthorized")
+	}
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+	rev, err := parseRevision(c.Param("rev"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid revision")
+	}
+
+	if err := h.svc.RestoreArticleRevision(adminID, uint(id64), rev); err != nil {
+		return revisionErrorResponse(c, err)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// ADMIN: POST /admin/articles/:id/preview-links
+// AdminCreatePreviewLink godoc
+// @Summary Admin create article preview link
+// @Description Mints an expiring token that lets anyone read the article (draft included) via GET /articles/preview/{token}.
+// @Description The token is only returned here; store it or share the url right away.
+// @Tags Articles (Admin)
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Article ID" minimum(1)
+// @Param request body dto.PreviewLinkCreateRequest false "Optional lifetime (hours, default 72, max 720) and note"
+// @Success 201 {object} SuccessResponse[dto.PreviewLinkDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/articles/{id}/preview-links [post]
+func (h *ArticleHandler) AdminCreatePreviewLink(c echo.Context) error {
+	adminID, ok := utils.GetAdminID(c)
+	if !ok {
+		return utils.UnauthorizedResponse(c, "unauthorized")
+	}
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	var body dto.PreviewLinkCreateRequest
+	if err := c.Bind(&body); err != nil {
+		return utils.BadRequestResponse(c, "invalid body")
+	}
+	if err := c.Validate(&body); err != nil {
+		return utils.UnprocessableEntityResponse(c, err.Error())
+	}
+
+	link, err := h.svc.CreatePreviewLink(adminID, uint(id64), body)
+	if err != nil {
+		return previewLinkErrorResponse(c, err)
+	}
+	return utils.CreatedResponse(c, "preview link created", link)
+}
+
+// ADMIN: GET /admin/articles/:id/preview-links
+// AdminListPreviewLinks godoc
+// @Summary Admin list article preview links
+// @Description Newest first, including expired and revoked links (tokens are not shown).
+// @Tags Articles (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Article ID" minimum(1)
+// @Success 200 {object} SuccessResponse[[]dto.PreviewLinkDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/articles/{id}/preview-links [get]
+func (h *ArticleHandler) AdminListPreviewLinks(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	links, err := h.svc.GetPreviewLinks(uint(id64))
+	if err != nil {
+		return previewLinkErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, "preview links fetched", links)
+}
+
+// ADMIN: DELETE /admin/articles/:id/preview-links/:linkId
+// AdminRevokePreviewLink godoc
+// @Summary Admin revoke article preview link
+// @Tags Articles (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Article ID" minimum(1)
+// @Param linkId path int true "Preview link ID" minimum(1)
+// @Success 204 {string} string "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/articles/{id}/preview-links/{linkId} [delete]
+func (h *ArticleHandler) AdminRevokePreviewLink(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+	linkID, err := strconv.ParseUint(c.Param("linkId"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid link id")
+	}
+
+	if err := h.svc.RevokePreviewLink(uint(id64), uint(linkID)); err != nil {
+		return previewLinkErrorResponse(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func previewLinkErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrNotFoundArticle),
+		errors.Is(err, service.ErrNotFoundPreviewLink),
+		errors.Is(err, service.ErrPreviewLinkInactive):
+		return utils.NotFoundResponse(c, err.Error())
+	}
+	return utils.InternalServerErrorResponse(c, err.Error())
+}
+
+func parseRevision(raw string) (int, error) {
+	rev, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || rev < 1 {
+		return 0, errors.New("invalid revision")
+	}
+	return rev, nil
+}
+
+func revisionErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrNotFoundArticle), errors.Is(err, service.ErrNotFoundArticleRevision):
+		return utils.NotFoundResponse(c, err.Error())
+	case errors.Is(err, service.ErrInvalidMedia):
+		return utils.UnprocessableEntityResponse(c, "revision references media that no longer exists")
+	}
+	return utils.InternalServerErrorResponse(c, err.Error())
+}
+
+const maxSearchQueryLength = 200
+
+func parseArticleFilter(c echo.Context) repository.ArticleFilter {
+	q := strings.TrimSpace(c.QueryParam("q"))
+	if r := []rune(q); len(r) > maxSearchQueryLength {
+		q = string(r[:maxSearchQueryLength])
+	}
+	return repository.ArticleFilter{
+		Category: strings.TrimSpace(c.QueryParam("category")),
+		Tag:      strings.TrimSpace(c.QueryParam("tag")),
+		Query:    q,
+	}
+}
+
+// parseFormIDs reads IDs from a form field, either comma-separated ("1,2,3") or repeated.
+// Returns nil when the field is absent, and an empty (non-nil) slice when present but empty.
+func parseFormIDs(c echo.Context, field string) ([]uint, error) {
+	form, err := c.FormParams()
+	if err != nil {
+		return nil, nil
+	}
+	values, present := form[field]
+	if !present {
+		return nil, nil
+	}
+
+	ids := []uint{}
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			id, err := strconv.ParseUint(part, 10, 64)
+			if err != nil || id == 0 {
+				return nil, errors.New("invalid id: " + part)
+			}
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids, nil
+}
+
+// parseFormTime accepts RFC3339 or unix seconds; empty -> nil.
+func parseFormTime(raw string) (*int64, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	if unix, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return &unix, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, err
+	}
+	unix := t.Unix()
+	return &unix, nil
+}
+
 // extractUploadKey supports field naming:
 // - content_files[img1]
 // - content_file_img1 (fallback)
@@ -367,40 +968,135 @@ func extractUploadKey(field string) (string, bool) {
 	return "", false
 }
 
-func uploadOne(ctx context.Context, svc interface {
-	UploadArticleMedia(ctx context.Context, file io.Reader, objectName string) (string, error)
-}, fh *multipart.FileHeader, objectPrefix, key string) (string, error) {
+// uploadMedia adds one multipart file to the media library.
+func (h *ArticleHandler) uploadMedia(c echo.Context, adminID uint, fh *multipart.FileHeader, slot service.UploadSlot) (dto.MediaDTO, error) {
+	src, err := fh.Open()
+	if err != nil {
+		return dto.MediaDTO{}, err
+	}
+	defer src.Close()
+
+	return h.media.Upload(c.Request().Context(), adminID, src, fh.Filename, slot)
+}
+
+// checkUploads checks photo_header_file and the content files against the upload
+// policy, and each content file against what its block accepts (an image block
+// takes images only), so nothing is uploaded when one of them would be refused.
+func (h *ArticleHandler) checkUploads(c echo.Context, contentAny any) error {
+	if fh, err := c.FormFile("photo_header_file"); err == nil && fh != nil {
+		if _, err := h.checkUpload(fh, service.UploadSlotArticleHeader); err != nil {
+			return fmt.Errorf("photo_header_file: %w", err)
+		}
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil
+	}
+	accepts := h.blocks.UploadAccepts(contentAny)
+	for field, fhs := range form.File {
+		key, ok := extractUploadKey(field)
+		if !ok || len(fhs) == 0 {
+			continue
+		}
+		mimeType, err := h.checkUpload(fhs[0], service.UploadSlotArticleContent)
+		if err != nil {
+			return fmt.Errorf("%s: %w", field, err)
+		}
+		if accept := accepts[key]; len(accept) > 0 && !content.AcceptsMIME(accept, mimeType) {
+			return fmt.Errorf("%s: %w: %s does not fit the block using upload_key %q (allowed: %s)",
+				field, service.ErrUnsupportedMediaType, mimeType, key, strings.Join(accept, ", "))
+		}
+	}
+	return nil
+}
+
+func (h *ArticleHandler) checkUpload(fh *multipart.FileHeader, slot service.UploadSlot) (string, error) {
 	src, err := fh.Open()
 	if err != nil {
 		return "", err
 	}
 	defer src.Close()
 
-	safeName := filepath.Base(fh.Filename)
-	objectName := objectPrefix + "/" + key + "_" + strconv.FormatInt(time.Now().UnixNano(), 10) + "_" + safeName
-	return svc.UploadArticleMedia(ctx, src, objectName)
+	return h.media.CheckUpload(src, slot)
+}
+
+// photoHeaderFromRequest returns the header URL of photo_header_media_id or, when
+// that isn't sent, photo_header. photo_header_file overrides both; it is uploaded
+// by the handlers once the rest of the request has been checked.
+func (h *ArticleHandler) photoHeaderFromRequest(c echo.Context) (string, error) {
+	raw := strings.TrimSpace(c.FormValue("photo_header_media_id"))
+	if raw == "" {
+		return c.FormValue("photo_header"), nil
+	}
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil || id == 0 {
+		return "", errInvalidHeaderMedia
+	}
+	media, err := h.media.GetMedia(uint(id))
+	if err != nil {
+		if errors.Is(err, service.ErrNotFoundMedia) {
+			return "", errInvalidHeaderMedia
+		}
+		return "", err
+	}
+	return media.URL, nil
+}
+
+// discardUploads deletes the library items uploaded for a save that failed, so
+// they don't sit in the public store until the media GC's grace period is over.
+func (h *ArticleHandler) discardUploads(c echo.Context, uploads []dto.MediaDTO) {
+	// the client may be gone already; the cleanup still has to run
+	ctx := context.WithoutCancel(c.Request().Context())
+	for _, media := range uploads {
+		if err := h.media.DeleteMedia(ctx, media.ID); err != nil {
+			logrus.WithError(err).WithField("media_id", media.ID).Warn("failed discard upload of a failed article save")
+		}
+	}
 }
 
-// replace any map that contains {"upload_key": "<key>"} with {"url": "<url>"} (and removes upload_key)
-// works recursively for objects/arrays
-func injectUploadedURLs(node any, urlByKey map[string]string) any {
+var errInvalidHeaderMedia = errors.New("photo_header_media_id does not match a media item")
+
+func uploadErrorResponse(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, repository.ErrStorageNotConfigured):
+		return utils.BadRequestResponse(c, "storage not configured: set PUBLIC_BUCKET (gcs, s3) or STORAGE_BACKEND=local to enable uploads")
+	case errors.Is(err, errInvalidHeaderMedia):
+		return utils.UnprocessableEntityResponse(c, err.Error())
+	case errors.Is(err, service.ErrUnsupportedMediaType):
+		return utils.UnsupportedMediaTypeResponse(c, err.Error())
+	case errors.Is(err, service.ErrMediaTooLarge):
+		return utils.RequestEntityTooLargeResponse(c, err.Error())
+	case errors.Is(err, imaging.ErrInvalidImage):
+		return utils.UnprocessableEntityResponse(c, "uploaded image could not be read (corrupt or unsupported)")
+	case errors.Is(err, imaging.ErrImageTooLarge):
+		return utils.UnprocessableEntityResponse(c, fmt.Sprintf("uploaded image is too large (max %d megapixels)", imaging.MaxPixels/1_000_000))
+	}
+	logrus.WithError(err).Error(message)
+	return utils.InternalServerErrorResponse(c, message)
+}
+
+// replace any map that contains {"upload_key": "<key>"} with {"url": "<url>", "media_id": <id>}
+// (and removes upload_key); works recursively for objects/arrays
+func injectUploadedMedia(node any, mediaByKey map[string]dto.MediaDTO) any {
 	switch v := node.(type) {
 	case map[string]any:
 		if raw, ok := v["upload_key"]; ok {
 			if key, ok := raw.(string); ok {
-				if url, exists := urlByKey[key]; exists {
-					v["url"] = url
+				if media, exists := mediaByKey[key]; exists {
+					v["url"] = media.URL
+					v[content.MediaIDField] = media.ID
 					delete(v, "upload_key")
 				}
 			}
 		}
 		for k, child := range v {
-			v[k] = injectUploadedURLs(child, urlByKey)
+			v[k] = injectUploadedMedia(child, mediaByKey)
 		}
 		return v
 	case []any:
 		for i := range v {
-			v[i] = injectUploadedURLs(v[i], urlByKey)
+			v[i] = injectUploadedMedia(v[i], mediaByKey)
 		}
 		return v
 	default:
@@ -408,14 +1104,31 @@ func injectUploadedURLs(node any, urlByKey map[string]string) any {
 	}
 }
 
-func (h *ArticleHandler) parseAndUploadContentFiles(c echo.Context) (map[string]string, error) {
+// contentUploadKeys returns the upload keys that have a file in the request.
+func contentUploadKeys(c echo.Context) map[string]bool {
+	keys := map[string]bool{}
+	form, err := c.MultipartForm()
+	if err != nil {
+		return keys
+	}
+	for field, fhs := range form.File {
+		if key, ok := extractUploadKey(field); ok && len(fhs) > 0 {
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+// parseAndUploadContentFiles uploads the content_files[<key>] files into the media
+// library. On error the map still holds the files uploaded before it.
+func (h *ArticleHandler) parseAndUploadContentFiles(c echo.Context, adminID uint) (map[string]dto.MediaDTO, error) {
 	form, err := c.MultipartForm()
 	if err != nil {
 		// no multipart form or not parsed: treat as no files
-		return map[string]string{}, nil
+		return map[string]dto.MediaDTO{}, nil
 	}
 
-	urlByKey := map[string]string{}
+	mediaByKey := map[string]dto.MediaDTO{}
 	for field, fhs := range form.File {
 		key, ok := extractUploadKey(field)
 		if !ok {
@@ -426,15 +1139,12 @@ func (h *ArticleHandler) parseAndUploadContentFiles(c echo.Context) (map[string]
 		}
 
 		// Only first file per key (keep API predictable)
-		url, err := uploadOne(c.Request().Context(), h.svc, fhs[0], "articles/content", key)
+		media, err := h.uploadMedia(c, adminID, fhs[0], service.UploadSlotArticleContent)
 		if err != nil {
-			if errors.Is(err, repository.ErrStorageNotConfigured) {
-				return nil, repository.ErrStorageNotConfigured
-			}
-			return nil, err
+			return mediaByKey, err
 		}
-		urlByKey[key] = url
+		mediaByKey[key] = media
 	}
 
-	return urlByKey, nil
+	return mediaByKey, nil
 }
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"darulabror/internal/content"
+	"darulabror/internal/dto"
+	"darulabror/internal/service"
+	"darulabror/internal/utils"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+func TestParseArticleFilter(t *testing.T) {
+	long := strings.Repeat("é", maxSearchQueryLength+10)
+
+	tests := []struct {
+		query     string
+		wantQuery string
+		wantCat   string
+		wantTag   string
+	}{
+		{"q=+santri+baru+&category=berita&tag=ramadhan", "santri baru", "berita", "ramadhan"},
+		{"q=%22ujian+akhir%22+-online", `"ujian akhir" -online`, "", ""},
+		{"category=+pengumuman+", "", "pengumuman", ""},
+		{"q=" + url.QueryEscape(long), strings.Repeat("é", maxSearchQueryLength), "", ""}, // cut by runes, not bytes
+		{"", "", "", ""},
+	}
+	e := echo.New()
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/articles/search?"+tt.query, nil)
+		filter := parseArticleFilter(e.NewContext(req, httptest.NewRecorder()))
+		if filter.Query != tt.wantQuery || filter.Category != tt.wantCat || filter.Tag != tt.wantTag {
+			t.Errorf("%q: got %+v", tt.query, filter)
+		}
+	}
+}
+
+type testValidator struct{ v *validator.Validate }
+
+func (tv testValidator) Validate(i interface{}) error { return tv.v.Struct(i) }
+
+type fakeArticleService struct {
+	service.ArticleService
+	err   error
+	saved []dto.ArticleDTO
+}
+
+func (f *fakeArticleService) CreateArticle(adminID uint, body dto.ArticleDTO) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.saved = append(f.saved, body)
+	return nil
+}
+
+func (f *fakeArticleService) UpdateArticle(adminID uint, id uint, body dto.ArticleDTO) error {
+	return f.CreateArticle(adminID, body)
+}
+
+type fakeMediaService struct {
+	service.MediaService
+	failUpload string // Upload fails for this file name
+	uploaded   []string
+	deleted    []uint
+}
+
+func (f *fakeMediaService) CheckUpload(file io.ReadSeeker, slot service.UploadSlot) (string, error) {
+	return "image/jpeg", nil
+}
+
+func (f *fakeMediaService) Upload(ctx context.Context, adminID uint, file io.ReadSeeker, fileName string, slot service.UploadSlot) (dto.MediaDTO, error) {
+	if fileName == f.failUpload {
+		return dto.MediaDTO{}, errors.New("storage unavailable")
+	}
+	f.uploaded = append(f.uploaded, fileName)
+	return dto.MediaDTO{ID: uint(len(f.uploaded)), URL: "https://cdn.example/" + fileName}, nil
+}
+
+func (f *fakeMediaService) DeleteMedia(ctx context.Context, id uint) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func articleForm(t *testing.T, fields map[string]string, files map[string]string) (string, *bytes.Buffer) {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for k, v := range fields {
+		w.WriteField(k, v)
+	}
+	for field, name := range files {
+		fw, err := w.CreateFormFile(field, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write([]byte("\xff\xd8\xff fake jpeg"))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return w.FormDataContentType(), &buf
+}
+
+func TestAdminSaveDiscardsUploads(t *testing.T) {
+	fields := func(extra map[string]string) map[string]string {
+		f := map[string]string{
+			"title":   "Penerimaan Santri Baru",
+			"author":  "Admin",
+			"content": `{"blocks":[{"type":"image","upload_key":"foto1"}]}`,
+		}
+		for k, v := range extra {
+			f[k] = v
+		}
+		return f
+	}
+	files := map[string]string{"photo_header_file": "header.jpg", "content_files[foto1]": "foto1.jpg"}
+
+	tests := []struct {
+		name         string
+		update       bool
+		fields       map[string]string
+		files        map[string]string
+		svcErr       error
+		failUpload   string
+		wantStatus   int
+		wantUploaded int
+		wantDeleted  int
+	}{
+		{"created", false, fields(nil), files, nil, "", http.StatusCreated, 2, 0},
+		{"updated", true, fields(nil), files, nil, "", http.StatusOK, 2, 0},
+		{"bad publish_at uploads nothing", false, fields(map[string]string{"publish_at": "besok"}), files, nil, "", http.StatusBadRequest, 0, 0},
+		{"bad category_ids uploads nothing", true, fields(map[string]string{"category_ids": "a,b"}), files, nil, "", http.StatusBadRequest, 0, 0},
+		{"missing header uploads nothing", false, fields(nil), map[string]string{"content_files[foto1]": "foto1.jpg"}, nil, "", http.StatusBadRequest, 0, 0},
+		{"create rejected", false, fields(nil), files, service.ErrInvalidCategory, "", http.StatusUnprocessableEntity, 2, 2},
+		{"update rejected", true, fields(nil), files, service.ErrInvalidArticleSchedule, "", http.StatusUnprocessableEntity, 2, 2},
+		{"save failed", false, fields(nil), files, service.ErrCreateArticle, "", http.StatusInternalServerError, 2, 2},
+		{"validation failed", false, fields(map[string]string{"title": "ab"}), files, nil, "", http.StatusUnprocessableEntity, 2, 2},
+		{"content upload failed", true, fields(nil), files, nil, "foto1.jpg", http.StatusInternalServerError, 1, 1},
+	}
+	e := echo.New()
+	e.Validator = testValidator{v: validator.New()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &fakeArticleService{err: tt.svcErr}
+			media := &fakeMediaService{failUpload: tt.failUpload}
+			h := NewArticleHandler(svc, media, content.NewRegistry(false))
+
+			contentType, body := articleForm(t, tt.fields, tt.files)
+			method := http.MethodPost
+			if tt.update {
+				method = http.MethodPut
+			}
+			req := httptest.NewRequest(method, "/admin/articles", body)
+			req.Header.Set(echo.HeaderContentType, contentType)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(utils.CtxAdminIDKey, uint(1))
+
+			var err error
+			if tt.update {
+				c.SetParamNames("id")
+				c.SetParamValues("7")
+				err = h.AdminUpdate(c)
+			} else {
+				err = h.AdminCreate(c)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if len(media.uploaded) != tt.wantUploaded || len(media.deleted) != tt.wantDeleted {
+				t.Errorf("uploaded %v, deleted %v; want %d uploaded, %d deleted", media.uploaded, media.deleted, tt.wantUploaded, tt.wantDeleted)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ func NewContactHandler(svc service.ContactService) *ContactHandler {
 
 // Create godoc
 // @Summary Create contact message
+// @Description The sender gets an acknowledgement mail. Rate limited per IP (CONTACT_RATE_LIMIT, 429 with Retry-After).
 // @Tags Contacts (Public)
 // @Accept json
 // @Produce json
@@ -28,6 +29,7 @@ func NewContactHandler(svc service.ContactService) *ContactHandler {
 // @Success 201 {string} string "Created"
 // @Failure 400 {object} ErrorResponse
 // @Failure 422 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /contacts [post]
 func (h *ContactHandler) Create(c echo.Context) error {
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"darulabror/internal/service"
+	"darulabror/internal/utils"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FeedHandler struct {
+	svc service.FeedService
+}
+
+func NewFeedHandler(svc service.FeedService) *FeedHandler {
+	return &FeedHandler{svc: svc}
+}
+
+// PUBLIC: GET /feeds/articles.rss
+// ArticlesRSS godoc
+// @Summary RSS 2.0 feed of published articles
+// @Description Latest 50 published articles. Supports conditional GET (If-None-Match / If-Modified-Since → 304).
+// @Tags Feeds (Public)
+// @Produce xml
+// @Success 200 {string} string "RSS document"
+// @Success 304 {string} string "Not Modified"
+// @Failure 503 {object} ErrorResponse
+// @Router /feeds/articles.rss [get]
+func (h *FeedHandler) ArticlesRSS(c echo.Context) error {
+	return h.serve(c, service.FeedFormatRSS)
+}
+
+// PUBLIC: GET /feeds/articles.atom
+// ArticlesAtom godoc
+// @Summary Atom feed of published articles
+// @Description Latest 50 published articles. Supports conditional GET (If-None-Match / If-Modified-Since → 304).
+// @Tags Feeds (Public)
+// @Produce xml
+// @Success 200 {string} string "Atom document"
+// @Success 304 {string} string "Not Modified"
+// @Failure 503 {object} ErrorResponse
+// @Router /feeds/articles.atom [get]
+func (h *FeedHandler) ArticlesAtom(c echo.Context) error {
+	return h.serve(c, service.FeedFormatAtom)
+}
+
+func (h *FeedHandler) serve(c echo.Context, format string) error {
+	feed, err := h.svc.ArticleFeed(format, c.Request().URL.Path)
+	if err != nil {
+		if errors.Is(err, service.ErrSiteNotConfigured) {
+			return utils.ServiceUnavailableResponse(c, err.Error())
+		}
+		return utils.InternalServerErrorResponse(c, "failed to build feed")
+	}
+
+	if notModified(c, feed.ETag, feed.LastModified) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.Blob(http.StatusOK, feed.ContentType, feed.Body)
+}
+
+// notModified sets the cache validators on the response and reports whether the
+// request's conditional headers still match them (If-None-Match wins over If-Modified-Since).
+func notModified(c echo.Context, etag string, lastModified time.Time) bool {
+	res := c.Response().Header()
+	res.Set("ETag", etag)
+	res.Set("Cache-Control", "public, max-age=300")
+	if !lastModified.IsZero() {
+		res.Set(echo.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	req := c.Request().Header
+	if inm := req.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := req.Get(echo.HeaderIfModifiedSince); ims != "" && !lastModified.IsZero() {
+		if t, err := http.ParseTime(ims); err == nil && !lastModified.Truncate(time.Second).After(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"darulabror/internal/service"
+	"darulabror/internal/utils"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FileHandler struct {
+	svc service.FileService
+}
+
+func NewFileHandler(svc service.FileService) *FileHandler {
+	return &FileHandler{svc: svc}
+}
+
+// ADMIN: GET /admin/files/signed-url
+// AdminSignedURL godoc
+// @Summary Admin get a signed URL for a private file
+// @Description Private files (PRIVATE_BUCKET, or the local disk backend) are never public. This returns a short-lived link (PRIVATE_URL_TTL, default 15m) to one of them; every issued link is logged with the admin ID.
+// @Tags Files (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param object query string true "Object name, e.g. registrations/12/akta.pdf"
+// @Success 200 {object} SuccessResponse[dto.SignedURLDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/files/signed-url [get]
+func (h *FileHandler) AdminSignedURL(c echo.Context) error {
+	adminID, ok := utils.GetAdminID(c)
+	if !ok {
+		return utils.UnauthorizedResponse(c, "unauthorized")
+	}
+
+	object := strings.TrimSpace(c.QueryParam("object"))
+	if object == "" {
+		return utils.BadRequestResponse(c, "object is required")
+	}
+
+	signed, err := h.svc.SignedURL(c.Request().Context(), adminID, object)
+	if err != nil {
+		return fileErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, "signed url created", signed)
+}
+
+// PUBLIC: GET /files/*
+// ServeSigned godoc
+// @Summary Download a private file through a signed link
+// @Description Only used by the local disk storage backend: links from /admin/files/signed-url point here. GCS and S3 links go to the bucket directly.
+// @Tags Files
+// @Produce octet-stream
+// @Param path path string true "Object name"
+// @Param expires query int true "Expiry (unix seconds)"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file
+// @Failure 403 {object} ErrorResponse
+// @Router /files/{path} [get]
+func (h *FileHandler) ServeSigned(c echo.Context) error {
+	object, err := url.PathUnescape(c.Param("*"))
+	if err != nil {
+		return utils.ForbiddenResponse(c, service.ErrInvalidSignedURL.Error())
+	}
+
+	file, err := h.svc.ResolveSignedFile(object, c.QueryParam("expires"), c.QueryParam("signature"))
+	if err != nil {
+		return utils.ForbiddenResponse(c, err.Error())
+	}
+
+	// private documents: never cached by shared caches, never rendered as a page
+	c.Response().Header().Set("Cache-Control", "private, no-store")
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	c.Response().Header().Set("X-Robots-Tag", "noindex, nofollow")
+	return c.File(file)
+}
+
+func fileErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidFileName):
+		return utils.BadRequestResponse(c, "object must be a relative file path like registrations/12/akta.pdf")
+	case errors.Is(err, service.ErrPrivateFilesDisabled):
+		return utils.ServiceUnavailableResponse(c, "private storage not configured: set PRIVATE_BUCKET (gcs, s3) or STORAGE_BACKEND=local")
+	}
+	return utils.InternalServerErrorResponse(c, err.Error())
+}
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"darulabror/internal/repository"
+	"darulabror/internal/service"
+	"darulabror/internal/utils"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type MediaHandler struct {
+	svc service.MediaService
+}
+
+func NewMediaHandler(svc service.MediaService) *MediaHandler {
+	return &MediaHandler{svc: svc}
+}
+
+// ADMIN: GET /admin/media
+// List godoc
+// @Summary Admin list media library
+// @Description Newest first, each item with usage_count (articles using it, trashed ones included).
+// @Tags Media (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Page size" default(10)
+// @Param q query string false "Search in file name"
+// @Param type query string false "Filter by kind" Enums(image, video, audio, document)
+// @Success 200 {object} MediaListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/media [get]
+func (h *MediaHandler) List(c echo.Context) error {
+	page, limit := utils.ParsePagination(c)
+
+	filter := repository.MediaFilter{
+		Query: strings.TrimSpace(c.QueryParam("q")),
+		Type:  strings.TrimSpace(c.QueryParam("type")),
+	}
+	switch filter.Type {
+	case "", "image", "video", "audio", "document":
+	default:
+		return utils.BadRequestResponse(c, "type must be image, video, audio or document")
+	}
+
+	items, total, err := h.svc.GetAllMedia(page, limit, filter)
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, err.Error())
+	}
+
+	return utils.SuccessResponse(c, "media fetched", map[string]interface{}{
+		"items": items,
+		"meta": map[string]interface{}{
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
+
+// ADMIN: GET /admin/media/:id
+// Get godoc
+// @Summary Admin get media item
+// @Tags Media (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Media ID" minimum(1)
+// @Success 200 {object} SuccessResponse[dto.MediaDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/media/{id} [get]
+func (h *MediaHandler) Get(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	item, err := h.svc.GetMedia(uint(id64))
+	if err != nil {
+		return mediaErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, "media fetched", item)
+}
+
+// ADMIN: GET /admin/media/:id/usage
+// Usage godoc
+// @Summary Admin list articles using a media item
+// @Description Articles whose content (by media_id or URL) or header use the item, most recently updated first. Trashed articles are included with trashed=true.
+// @Tags Media (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Media ID" minimum(1)
+// @Success 200 {object} MediaUsageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/media/{id}/usage [get]
+func (h *MediaHandler) Usage(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	usage, err := h.svc.GetMediaUsage(uint(id64))
+	if err != nil {
+		return mediaErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, "media usage fetched", usage)
+}
+
+// ADMIN: POST /admin/media
+// Upload godoc
+// @Summary Admin upload to media library (multipart)
+// @Description JPEG, PNG and WebP images are auto-rotated, stripped of EXIF/GPS metadata and stored as resized variants (srcset, widest = url); the original is not kept.
+// @Description Other files (GIF included) are stored unchanged. The returned id can be used as media_id in article content blocks or as photo_header_media_id.
+// @Description The type is sniffed from the file content (images, video and PDF by default); other types get 415, files over the per-type size limit 413.
+// @Tags Media (Admin)
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "File to upload"
+// @Success 201 {object} SuccessResponse[dto.MediaDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/media [post]
+func (h *MediaHandler) Upload(c echo.Context) error {
+	adminID, ok := utils.GetAdminID(c)
+	if !ok {
+		return utils.UnauthorizedResponse(c, "unauthorized")
+	}
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return utils.BadRequestResponse(c, "file is required")
+	}
+	src, err := fh.Open()
+	if err != nil {
+		return utils.BadRequestResponse(c, "failed to open file")
+	}
+	defer src.Close()
+
+	item, err := h.svc.Upload(c.Request().Context(), adminID, src, fh.Filename, service.UploadSlotLibrary)
+	if err != nil {
+		return uploadErrorResponse(c, err, "failed to upload file")
+	}
+	return utils.CreatedResponse(c, "media uploaded", item)
+}
+
+// ADMIN: DELETE /admin/media/:id
+// Delete godoc
+// @Summary Admin delete media item
+// @Description Deletes the item and its file. Items still used by an article (see /usage) are refused with 409.
+// @Tags Media (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Media ID" minimum(1)
+// @Success 204 {string} string "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/media/{id} [delete]
+func (h *MediaHandler) Delete(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	if err := h.svc.DeleteMedia(c.Request().Context(), uint(id64)); err != nil {
+		return mediaErrorResponse(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// SUPERADMIN: GET /admin/media/orphans
+// Orphans godoc
+// @Summary Superadmin orphaned media report (dry run)
+// @Description Lists article uploads (objects under articles/) that no article, trashed ones included, references as photo_header or in content.
+// @Description Only objects older than MEDIA_GC_GRACE are listed as orphans; nothing is deleted. The media-gc job deletes exactly these.
+// @Description References are matched by object name, so URLs saved under an older bucket or public URL still count. 409 when articles reference uploads but none through the store's current base URL; the job then deletes nothing.
+// @Tags Media (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} SuccessResponse[dto.MediaGCReportDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/media/orphans [get]
+func (h *MediaHandler) Orphans(c echo.Context) error {
+	report, err := h.svc.CollectOrphans(c.Request().Context(), time.Now(), true)
+	if err != nil {
+		if errors.Is(err, repository.ErrStorageNotConfigured) {
+			return utils.BadRequestResponse(c, "storage not configured: set PUBLIC_BUCKET (gcs, s3) or STORAGE_BACKEND=local")
+		}
+		if errors.Is(err, service.ErrMediaGCBaseMismatch) {
+			return utils.ConflictResponse(c, "articles reference uploads, but none through "+report.BaseURL+"; check the storage settings (nothing is collected until they match)")
+		}
+		return utils.InternalServerErrorResponse(c, err.Error())
+	}
+	return utils.SuccessResponse(c, "orphaned media report", report)
+}
+
+func mediaErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrNotFoundMedia):
+		return utils.NotFoundResponse(c, err.Error())
+	case errors.Is(err, service.ErrMediaInUse):
+		return utils.ConflictResponse(c, "media is used by articles; remove it from them first (see /admin/media/:id/usage)")
+	}
+	return utils.InternalServerErrorResponse(c, err.Error())
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"darulabror/internal/models"
+	"darulabror/internal/repository"
+	"darulabror/internal/service"
+	"darulabror/internal/utils"
+	"errors"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type NotificationHandler struct {
+	svc service.DeliveryService
+}
+
+func NewNotificationHandler(svc service.DeliveryService) *NotificationHandler {
+	return &NotificationHandler{svc: svc}
+}
+
+// ADMIN: GET /admin/notifications/deliveries
+// AdminListDeliveries godoc
+// @Summary Admin list WhatsApp/SMS deliveries
+// @Description Every message sent (or to be sent) to a registration's phone numbers, newest first, with its retry state: pending deliveries are retried with a growing delay until they are sent or fail.
+// @Tags Notifications (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Page size" default(10)
+// @Param status query string false "Filter by status" Enums(pending, sent, failed)
+// @Param registration_id query int false "Filter by registration ID"
+// @Success 200 {object} NotificationDeliveryListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/notifications/deliveries [get]
+func (h *NotificationHandler) AdminListDeliveries(c echo.Context) error {
+	page, limit := utils.ParsePagination(c)
+
+	var filter repository.DeliveryFilter
+	switch status := models.DeliveryStatus(c.QueryParam("status")); status {
+	case "", models.DeliveryPending, models.DeliverySent, models.DeliveryFailed:
+		filter.Status = string(status)
+	default:
+		return utils.BadRequestResponse(c, "status must be one of pending, sent, failed")
+	}
+	if raw := c.QueryParam("registration_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return utils.BadRequestResponse(c, "invalid registration_id")
+		}
+		filter.RegistrationID = uint(id)
+	}
+
+	items, total, err := h.svc.ListDeliveries(page, limit, filter)
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, "failed to fetch deliveries")
+	}
+
+	return utils.SuccessResponse(c, "deliveries fetched", map[string]interface{}{
+		"items": items,
+		"meta": map[string]interface{}{
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
+
+// ADMIN: POST /admin/notifications/deliveries/:id/retry
+// AdminRetryDelivery godoc
+// @Summary Admin retry a WhatsApp/SMS delivery
+// @Description Queues a failed (or pending) delivery for a fresh round of attempts, starting with the next delivery run.
+// @Tags Notifications (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Delivery ID" minimum(1)
+// @Success 200 {object} SuccessResponse[dto.NotificationDeliveryDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/notifications/deliveries/{id}/retry [post]
+func (h *NotificationHandler) AdminRetryDelivery(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	item, err := h.svc.RetryDelivery(uint(id64))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotFoundDelivery):
+			return utils.NotFoundResponse(c, err.Error())
+		case errors.Is(err, service.ErrDeliveryAlreadySent):
+			return utils.ConflictResponse(c, err.Error())
+		}
+		logrus.WithError(err).Error("failed retry delivery")
+		return utils.InternalServerErrorResponse(c, "failed to retry delivery")
+	}
+	return utils.SuccessResponse(c, "delivery queued for retry", item)
+}
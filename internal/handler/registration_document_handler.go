@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"darulabror/internal/dto"
+	"darulabror/internal/models"
+	"darulabror/internal/service"
+	"darulabror/internal/utils"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// RegistrationTokenHeader carries the upload token returned by POST /registrations.
+const RegistrationTokenHeader = "X-Registration-Token"
+
+type RegistrationDocumentHandler struct {
+	svc service.RegistrationDocumentService
+}
+
+func NewRegistrationDocumentHandler(svc service.RegistrationDocumentService) *RegistrationDocumentHandler {
+	return &RegistrationDocumentHandler{svc: svc}
+}
+
+// PUBLIC: GET /registrations/documents
+// Checklist godoc
+// @Summary Get the documents of my registration
+// @Description Lists the uploaded documents with their review status, and the document types still missing (never uploaded, or rejected: upload those again).
+// @Tags Registrations (Public)
+// @Produce json
+// @Param X-Registration-Token header string true "Upload token returned by POST /registrations"
+// @Success 200 {object} SuccessResponse[dto.RegistrationDocumentChecklistDTO]
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /registrations/documents [get]
+func (h *RegistrationDocumentHandler) Checklist(c echo.Context) error {
+	checklist, err := h.svc.GetChecklist(c.Request().Header.Get(RegistrationTokenHeader))
+	if err != nil {
+		return registrationDocumentErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, "documents fetched", checklist)
+}
+
+// PUBLIC: POST /registrations/documents
+// Upload godoc
+// @Summary Upload a registration document (multipart)
+// @Description One document per type; uploading a type again replaces it (and resets its review), unless it was already verified.
+// @Description Files are stored privately. Scans must be JPEG, PNG or PDF (UPLOAD_ALLOWED_DOCUMENT), pas_foto an image.
+// @Tags Registrations (Public)
+// @Accept multipart/form-data
+// @Produce json
+// @Param X-Registration-Token header string true "Upload token returned by POST /registrations"
+// @Param type formData string true "Document type" Enums(akta_kelahiran, kartu_keluarga, rapor, pas_foto)
+// @Param file formData file true "Document file"
+// @Success 201 {object} SuccessResponse[dto.RegistrationDocumentDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /registrations/documents [post]
+func (h *RegistrationDocumentHandler) Upload(c echo.Context) error {
+	docType := models.RegistrationDocumentType(strings.TrimSpace(c.FormValue("type")))
+	if docType == "" {
+		return utils.BadRequestResponse(c, "type is required")
+	}
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return utils.BadRequestResponse(c, "file is required")
+	}
+	src, err := fh.Open()
+	if err != nil {
+		return utils.BadRequestResponse(c, "failed to open file")
+	}
+	defer src.Close()
+
+	doc, err := h.svc.UploadDocument(c.Request().Context(), c.Request().Header.Get(RegistrationTokenHeader), docType, src, fh.Filename)
+	if err != nil {
+		return registrationDocumentErrorResponse(c, err)
+	}
+	return utils.CreatedResponse(c, "document uploaded", doc)
+}
+
+// ADMIN: GET /admin/registrations/:id/documents
+// AdminList godoc
+// @Summary Admin list documents of a registration
+// @Tags Registrations (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Registration ID" minimum(1)
+// @Success 200 {object} SuccessResponse[dto.RegistrationDocumentChecklistDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/registrations/{id}/documents [get]
+func (h *RegistrationDocumentHandler) AdminList(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	checklist, err := h.svc.ListDocuments(uint(id64))
+	if err != nil {
+		return registrationDocumentErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, "documents fetched", checklist)
+}
+
+// ADMIN: GET /admin/registrations/:id/documents/:docId/download
+// AdminDownload godoc
+// @Summary Admin get a download link for a registration document
+// @Description Returns a short-lived signed URL (PRIVATE_URL_TTL); every issued link is logged with the admin ID.
+// @Tags Registrations (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Registration ID" minimum(1)
+// @Param docId path int true "Document ID" minimum(1)
+// @Success 200 {object} SuccessResponse[dto.SignedURLDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/registrations/{id}/documents/{docId}/download [get]
+func (h *RegistrationDocumentHandler) AdminDownload(c echo.Context) error {
+	adminID, ok := utils.GetAdminID(c)
+	if !ok {
+		return utils.UnauthorizedResponse(c, "unauthorized")
+	}
+	regID, docID, ok := parseDocumentIDs(c)
+	if !ok {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	signed, err := h.svc.DocumentURL(c.Request().Context(), adminID, regID, docID)
+	if err != nil {
+		return registrationDocumentErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, "signed url created", signed)
+}
+
+// ADMIN: PATCH /admin/registrations/:id/documents/:docId
+// AdminReview godoc
+// @Summary Admin verify or reject a registration document
+// @Description A rejected document needs a reason; the applicant sees it and uploads the document again.
+// @Tags Registrations (Admin)
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Registration ID" minimum(1)
+// @Param docId path int true "Document ID" minimum(1)
+// @Param request body dto.RegistrationDocumentReviewRequest true "Review payload"
+// @Success 200 {object} SuccessResponse[dto.RegistrationDocumentDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/registrations/{id}/documents/{docId} [patch]
+func (h *RegistrationDocumentHandler) AdminReview(c echo.Context) error {
+	adminID, ok := utils.GetAdminID(c)
+	if !ok {
+		return utils.UnauthorizedResponse(c, "unauthorized")
+	}
+	regID, docID, ok := parseDocumentIDs(c)
+	if !ok {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	var body dto.RegistrationDocumentReviewRequest
+	if err := c.Bind(&body); err != nil {
+		return utils.BadRequestResponse(c, "invalid body")
+	}
+	if err := c.Validate(&body); err != nil {
+		return utils.UnprocessableEntityResponse(c, err.Error())
+	}
+
+	doc, err := h.svc.ReviewDocument(adminID, regID, docID, body)
+	if err != nil {
+		return registrationDocumentErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, "document reviewed", doc)
+}
+
+func parseDocumentIDs(c echo.Context) (uint, uint, bool) {
+	regID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	docID, err := strconv.ParseUint(c.Param("docId"), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return uint(regID), uint(docID), true
+}
+
+func registrationDocumentErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidUploadToken):
+		return utils.UnauthorizedResponse(c, err.Error())
+	case errors.Is(err, service.ErrInvalidDocumentType):
+		return utils.BadRequestResponse(c, "type must be one of akta_kelahiran, kartu_keluarga, rapor, pas_foto")
+	case errors.Is(err, service.ErrNotFoundRegistration), errors.Is(err, service.ErrNotFoundDocument):
+		return utils.NotFoundResponse(c, err.Error())
+	case errors.Is(err, service.ErrRegistrationClosed), errors.Is(err, service.ErrDocumentVerified):
+		return utils.ConflictResponse(c, err.Error())
+	case errors.Is(err, service.ErrDocumentReasonRequired), errors.Is(err, service.ErrInvalidDocumentStatus):
+		return utils.UnprocessableEntityResponse(c, err.Error())
+	case errors.Is(err, service.ErrUnsupportedMediaType):
+		return utils.UnsupportedMediaTypeResponse(c, err.Error())
+	case errors.Is(err, service.ErrMediaTooLarge):
+		return utils.RequestEntityTooLargeResponse(c, err.Error())
+	case errors.Is(err, service.ErrPrivateFilesDisabled), errors.Is(err, service.ErrInvalidFileName):
+		return fileErrorResponse(c, err)
+	}
+	logrus.WithError(err).Error("registration document request failed")
+	return utils.InternalServerErrorResponse(c, "failed to process document")
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"darulabror/internal/service"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestRegistrationDocumentErrorResponse(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{service.ErrInvalidUploadToken, http.StatusUnauthorized},
+		{service.ErrInvalidDocumentType, http.StatusBadRequest},
+		{service.ErrNotFoundDocument, http.StatusNotFound},
+		{service.ErrDocumentVerified, http.StatusConflict},
+		{service.ErrDocumentReasonRequired, http.StatusUnprocessableEntity},
+		{service.ErrInvalidDocumentStatus, http.StatusUnprocessableEntity},
+		{fmt.Errorf("%w: pas_foto must be an image", service.ErrUnsupportedMediaType), http.StatusUnsupportedMediaType},
+		{service.ErrPrivateFilesDisabled, http.StatusServiceUnavailable},
+		{errors.New("connection refused"), http.StatusInternalServerError},
+	}
+	e := echo.New()
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodPut, "/", nil), rec)
+		if err := registrationDocumentErrorResponse(c, tt.err); err != nil {
+			t.Fatal(err)
+		}
+		if rec.Code != tt.want {
+			t.Errorf("%v: status %d, want %d", tt.err, rec.Code, tt.want)
+		}
+	}
+}
@@ -2,11 +2,18 @@ package handler
 
 import (
 	"darulabror/internal/dto"
+	"darulabror/internal/mailer"
 	"darulabror/internal/models"
+	"darulabror/internal/repository"
 	"darulabror/internal/service"
 	"darulabror/internal/utils"
+	"errors"
+	"fmt"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
@@ -22,12 +29,15 @@ func NewRegistrationHandler(svc service.RegistrationService) *RegistrationHandle
 
 // Create godoc
 // @Summary Create registration
+// @Description Only accepted while an admission period is open (409 when none is, or its quota for the gender / student type is full); the registration is assigned to that period.
+// @Description Returns the reference number (for the office and POST /registrations/status-lookup) and the upload token for the registration's documents (POST /registrations/documents). The token is shown only once.
 // @Tags Registrations (Public)
 // @Accept json
 // @Produce json
 // @Param request body dto.RegistrationDTO true "Registration payload"
-// @Success 201 {string} string "Created"
+// @Success 201 {object} SuccessResponse[dto.RegistrationCreatedDTO]
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 422 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /registrations [post]
@@ -40,12 +50,58 @@ func (h *RegistrationHandler) Create(c echo.Context) error {
 		return utils.UnprocessableEntityResponse(c, err.Error())
 	}
 
-	if err := h.svc.CreateRegistration(body); err != nil {
+	created, err := h.svc.CreateRegistration(body)
+	if err != nil {
+		if errors.Is(err, service.ErrAdmissionClosed) || errors.Is(err, service.ErrAdmissionFull) {
+			return utils.ConflictResponse(c, err.Error())
+		}
 		logrus.WithError(err).Error("failed create registration")
 		return utils.InternalServerErrorResponse(c, "failed to process registration")
 	}
 
-	return c.NoContent(http.StatusCreated)
+	return utils.CreatedResponse(c, "registration created", created)
+}
+
+// PUBLIC: POST /registrations/status-lookup
+// StatusLookup godoc
+// @Summary Look up the status of my registration
+// @Description Identify the registration by reference_number (or nisn) plus date_of_birth. Returns the status, its timeline and the documents still to upload or rejected.
+// @Description Unknown identifiers and wrong dates of birth get the same 404. Rate limited per IP (STATUS_LOOKUP_RATE_LIMIT, 429 with Retry-After).
+// @Tags Registrations (Public)
+// @Accept json
+// @Produce json
+// @Param request body dto.RegistrationStatusLookupRequest true "Lookup payload"
+// @Success 200 {object} SuccessResponse[dto.RegistrationStatusLookupDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /registrations/status-lookup [post]
+func (h *RegistrationHandler) StatusLookup(c echo.Context) error {
+	var body dto.RegistrationStatusLookupRequest
+	if err := c.Bind(&body); err != nil {
+		return utils.BadRequestResponse(c, "invalid body")
+	}
+	if err := c.Validate(&body); err != nil {
+		return utils.UnprocessableEntityResponse(c, err.Error())
+	}
+	if strings.TrimSpace(body.ReferenceNumber) == "" && strings.TrimSpace(body.NISN) == "" {
+		return utils.UnprocessableEntityResponse(c, "reference_number or nisn is required")
+	}
+
+	// personal data: keep it out of shared caches
+	c.Response().Header().Set("Cache-Control", "no-store")
+
+	status, err := h.svc.LookupStatus(body)
+	if err != nil {
+		if errors.Is(err, service.ErrRegistrationLookupFailed) {
+			return utils.NotFoundResponse(c, err.Error())
+		}
+		logrus.WithError(err).Error("failed look up registration status")
+		return utils.InternalServerErrorResponse(c, "failed to look up registration")
+	}
+	return utils.SuccessResponse(c, "registration status fetched", status)
 }
 
 // ADMIN: GET /admin/registrations
@@ -56,17 +112,25 @@ func (h *RegistrationHandler) Create(c echo.Context) error {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Page size" default(10)
-// @Param status query string false "Filter by status" Enums(new, validate, process, done)
+// @Param status query string false "Filter by status" Enums(new, validate, process, done, rejected, withdrawn)
+// @Param period_id query int false "Filter by admission period ID"
+// @Param academic_year query string false "Filter by academic year of the admission period, e.g. 2025/2026"
+// @Param created_from query string false "Registered on or after this day (WIB), YYYY-MM-DD"
+// @Param created_to query string false "Registered on or before this day (WIB), YYYY-MM-DD"
 // @Success 200 {object} RegistrationListResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /admin/registrations [get]
 func (h *RegistrationHandler) AdminList(c echo.Context) error {
 	page, limit := utils.ParsePagination(c)
-	status := c.QueryParam("status")
-	
-	items, total, err := h.svc.GetAllRegistrations(page, limit, status)
+	filter, err := parseRegistrationFilter(c)
+	if err != nil {
+		return utils.BadRequestResponse(c, err.Error())
+	}
+
+	items, total, err := h.svc.GetAllRegistrations(page, limit, filter)
 	if err != nil {
 		logrus.WithError(err).Error("failed list registrations")
 		return utils.InternalServerErrorResponse(c, "failed to fetch registrations")
@@ -82,6 +146,171 @@ func (h *RegistrationHandler) AdminList(c echo.Context) error {
 	})
 }
 
+// ADMIN: GET /admin/registrations/export
+// AdminExport godoc
+// @Summary Admin export registrations (CSV / XLSX)
+// @Description All registrations matching the filters of GET /admin/registrations (no pagination), with Indonesian column headers, as a download. Rows are streamed from the database in batches.
+// @Tags Registrations (Admin)
+// @Security BearerAuth
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "File format" Enums(csv, xlsx) default(csv)
+// @Param status query string false "Filter by status" Enums(new, validate, process, done, rejected, withdrawn)
+// @Param period_id query int false "Filter by admission period ID"
+// @Param academic_year query string false "Filter by academic year of the admission period, e.g. 2025/2026"
+// @Param created_from query string false "Registered on or after this day (WIB), YYYY-MM-DD"
+// @Param created_to query string false "Registered on or before this day (WIB), YYYY-MM-DD"
+// @Success 200 {file} file "Spreadsheet"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/registrations/export [get]
+func (h *RegistrationHandler) AdminExport(c echo.Context) error {
+	adminID, ok := utils.GetAdminID(c)
+	if !ok {
+		return utils.UnauthorizedResponse(c, "unauthorized")
+	}
+	format := strings.ToLower(strings.TrimSpace(c.QueryParam("format")))
+	if format == "" {
+		format = service.ExportCSV
+	}
+	if !service.ValidExportFormat(format) {
+		return utils.BadRequestResponse(c, service.ErrInvalidExportFormat.Error())
+	}
+	filter, err := parseRegistrationFilter(c)
+	if err != nil {
+		return utils.BadRequestResponse(c, err.Error())
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == service.ExportXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, contentType)
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="pendaftaran-%s.%s"`, time.Now().In(mailer.WIB).Format("20060102-1504"), format))
+	res.Header().Set("Cache-Control", "no-store")
+
+	logrus.WithFields(logrus.Fields{
+		"admin_id": adminID,
+		"format":   format,
+		"filter":   fmt.Sprintf("%+v", filter),
+	}).Info("registration export requested")
+
+	if err := h.svc.ExportRegistrations(c.Request().Context(), filter, format, res); err != nil {
+		if res.Committed {
+			return nil // the download is cut short; nothing else can be sent now
+		}
+		res.Header().Del(echo.HeaderContentDisposition)
+		return utils.InternalServerErrorResponse(c, "failed to export registrations")
+	}
+	return nil
+}
+
+// ADMIN: POST /admin/registrations/import
+// AdminImport godoc
+// @Summary Admin import registrations (CSV / XLSX)
+// @Description Registrations from a spreadsheet, e.g. paper forms typed in by the office. The first row holds the column headers: those of GET /admin/registrations/export or the JSON field names of dto.RegistrationDTO (other columns are ignored), so an export can be edited and imported again.
+// @Description Every row is checked like POST /registrations (same field rules, email and NISN not used yet, also not by an earlier row) and reported on. Dates may be YYYY-MM-DD or DD/MM/YYYY. Imported registrations join the given admission period (default: the open one) within its quota; they get no upload token and no notifications.
+// @Description dry_run only checks the rows. atomic stores all rows or none: a single invalid row cancels the import. Otherwise the valid rows are stored and the invalid ones reported.
+// @Tags Registrations (Admin)
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Spreadsheet (.csv or .xlsx, at most 2000 rows)"
+// @Param period_id formData int false "Admission period ID (default: the open period)"
+// @Param dry_run formData bool false "Only check the rows" default(false)
+// @Param atomic formData bool false "Store all rows or none" default(false)
+// @Success 200 {object} SuccessResponse[dto.RegistrationImportReportDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/registrations/import [post]
+func (h *RegistrationHandler) AdminImport(c echo.Context) error {
+	adminID, ok := utils.GetAdminID(c)
+	if !ok {
+		return utils.UnauthorizedResponse(c, "unauthorized")
+	}
+
+	var opts service.RegistrationImportOptions
+	if raw := strings.TrimSpace(c.FormValue("period_id")); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil || id == 0 {
+			return utils.BadRequestResponse(c, "invalid period_id")
+		}
+		opts.PeriodID = uint(id)
+	}
+	for name, flag := range map[string]*bool{"dry_run": &opts.DryRun, "atomic": &opts.Atomic} {
+		if raw := strings.TrimSpace(c.FormValue(name)); raw != "" {
+			v, err := strconv.ParseBool(raw)
+			if err != nil {
+				return utils.BadRequestResponse(c, "invalid "+name)
+			}
+			*flag = v
+		}
+	}
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return utils.BadRequestResponse(c, "file is required")
+	}
+	var format string
+	switch strings.ToLower(filepath.Ext(fh.Filename)) {
+	case ".csv":
+		format = service.ExportCSV
+	case ".xlsx":
+		format = service.ExportXLSX
+	default:
+		return utils.UnsupportedMediaTypeResponse(c, service.ErrInvalidImportFormat.Error())
+	}
+	src, err := fh.Open()
+	if err != nil {
+		return utils.BadRequestResponse(c, "failed to open file")
+	}
+	defer src.Close()
+
+	logrus.WithFields(logrus.Fields{
+		"admin_id": adminID,
+		"file":     fh.Filename,
+		"dry_run":  opts.DryRun,
+		"atomic":   opts.Atomic,
+	}).Info("registration import requested")
+
+	report, err := h.svc.ImportRegistrations(c.Request().Context(), src, format, opts, c.Validate)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotFoundAdmissionPeriod):
+			return utils.NotFoundResponse(c, err.Error())
+		case errors.Is(err, service.ErrAdmissionClosed):
+			return utils.ConflictResponse(c, err.Error())
+		case errors.Is(err, service.ErrImportTooLarge):
+			return utils.RequestEntityTooLargeResponse(c, err.Error())
+		case errors.Is(err, service.ErrInvalidImportFormat):
+			return utils.UnsupportedMediaTypeResponse(c, err.Error())
+		case errors.Is(err, service.ErrInvalidImportFile):
+			return utils.UnprocessableEntityResponse(c, err.Error())
+		}
+		logrus.WithError(err).Error("failed import registrations")
+		return utils.InternalServerErrorResponse(c, "failed to import registrations")
+	}
+
+	message := "registrations imported"
+	switch {
+	case opts.DryRun:
+		message = "registrations checked, nothing stored"
+	case !report.Committed:
+		message = "nothing imported"
+	}
+	return utils.SuccessResponse(c, message, report)
+}
+
 // ADMIN: GET /admin/registrations/:id
 // AdminGetByID godoc
 // @Summary Admin get registration by ID
@@ -138,6 +367,7 @@ func (h *RegistrationHandler) AdminDelete(c echo.Context) error {
 // ADMIN: PATCH /admin/registrations/:id/status
 // AdminUpdateStatus godoc
 // @Summary Admin update registration status
+// @Description Allowed transitions: new → validate → process → done; rejected from new/validate/process; withdrawn from new/validate/process/done. rejected and withdrawn are final; other changes get 409. Every change is recorded in the timeline with the admin and the note.
 // @Tags Registrations (Admin)
 // @Security BearerAuth
 // @Accept json
@@ -149,10 +379,16 @@ func (h *RegistrationHandler) AdminDelete(c echo.Context) error {
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 422 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /admin/registrations/{id}/status [patch]
 func (h *RegistrationHandler) AdminUpdateStatus(c echo.Context) error {
+	adminID, ok := utils.GetAdminID(c)
+	if !ok {
+		return utils.UnauthorizedResponse(c, "unauthorized")
+	}
+
 	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
 		return utils.BadRequestResponse(c, "invalid id")
@@ -166,11 +402,81 @@ func (h *RegistrationHandler) AdminUpdateStatus(c echo.Context) error {
 		return utils.UnprocessableEntityResponse(c, err.Error())
 	}
 
-	if err := h.svc.UpdateRegistrationStatus(uint(id64), models.RegistrationStatus(body.Status)); err != nil {
-		if err.Error() == "registration not found" {
+	if err := h.svc.UpdateRegistrationStatus(adminID, uint(id64), models.RegistrationStatus(body.Status), body.Note); err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotFoundRegistration):
 			return utils.NotFoundResponse(c, err.Error())
+		case errors.Is(err, service.ErrInvalidStatusTransition):
+			return utils.ConflictResponse(c, err.Error())
 		}
 		return utils.InternalServerErrorResponse(c, err.Error())
 	}
 	return c.NoContent(http.StatusNoContent)
 }
+
+// ADMIN: GET /admin/registrations/:id/timeline
+// AdminTimeline godoc
+// @Summary Admin get the status timeline of a registration
+// @Description Every status change, oldest first: from/to status, the admin who made it and their note. The first entry is the creation.
+// @Tags Registrations (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Registration ID" minimum(1)
+// @Success 200 {object} RegistrationTimelineResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/registrations/{id}/timeline [get]
+func (h *RegistrationHandler) AdminTimeline(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	timeline, err := h.svc.GetStatusTimeline(uint(id64))
+	if err != nil {
+		if errors.Is(err, service.ErrNotFoundRegistration) {
+			return utils.NotFoundResponse(c, err.Error())
+		}
+		logrus.WithError(err).Error("failed get registration timeline")
+		return utils.InternalServerErrorResponse(c, "failed to fetch timeline")
+	}
+	return utils.SuccessResponse(c, "timeline fetched", timeline)
+}
+
+// parseRegistrationFilter reads the admin listing / export filters (status,
+// period_id, academic_year, created_from, created_to).
+func parseRegistrationFilter(c echo.Context) (repository.RegistrationFilter, error) {
+	filter := repository.RegistrationFilter{
+		Status:       strings.TrimSpace(c.QueryParam("status")),
+		AcademicYear: strings.TrimSpace(c.QueryParam("academic_year")),
+	}
+	if raw := strings.TrimSpace(c.QueryParam("period_id")); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil || id == 0 {
+			return filter, errors.New("invalid period_id")
+		}
+		filter.AdmissionPeriodID = uint(id)
+	}
+	// dates (inclusive) are days in WIB, like the admins' calendar
+	if raw := strings.TrimSpace(c.QueryParam("created_from")); raw != "" {
+		from, err := time.ParseInLocation("2006-01-02", raw, mailer.WIB)
+		if err != nil {
+			return filter, errors.New("invalid created_from, expected YYYY-MM-DD")
+		}
+		filter.CreatedFrom = from
+	}
+	if raw := strings.TrimSpace(c.QueryParam("created_to")); raw != "" {
+		to, err := time.ParseInLocation("2006-01-02", raw, mailer.WIB)
+		if err != nil {
+			return filter, errors.New("invalid created_to, expected YYYY-MM-DD")
+		}
+		filter.CreatedBefore = to.AddDate(0, 0, 1)
+	}
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedBefore.IsZero() && !filter.CreatedBefore.After(filter.CreatedFrom) {
+		return filter, errors.New("created_to must not be before created_from")
+	}
+	return filter, nil
+}
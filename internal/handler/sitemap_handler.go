@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"darulabror/internal/service"
+	"darulabror/internal/utils"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SitemapHandler struct {
+	svc service.SitemapService
+}
+
+func NewSitemapHandler(svc service.SitemapService) *SitemapHandler {
+	return &SitemapHandler{svc: svc}
+}
+
+// PUBLIC: GET /sitemap.xml
+// Sitemap godoc
+// @Summary XML sitemap
+// @Description Static pages + every published article (lastmod = updated_at), with URLs on SITE_URL.
+// @Description Beyond 50,000 URLs this is a sitemap index pointing to /sitemaps/{n}.xml.
+// @Tags SEO (Public)
+// @Produce xml
+// @Success 200 {string} string "urlset or sitemapindex document"
+// @Failure 503 {object} ErrorResponse
+// @Router /sitemap.xml [get]
+func (h *SitemapHandler) Sitemap(c echo.Context) error {
+	body, err := h.svc.Sitemap()
+	if err != nil {
+		return sitemapErrorResponse(c, err)
+	}
+	c.Response().Header().Set("Cache-Control", "public, max-age=3600")
+	return c.Blob(http.StatusOK, echo.MIMEApplicationXMLCharsetUTF8, body)
+}
+
+// PUBLIC: GET /sitemaps/:page
+// SitemapPage godoc
+// @Summary XML sitemap part
+// @Description One part of a split sitemap, e.g. /sitemaps/2.xml.
+// @Tags SEO (Public)
+// @Produce xml
+// @Param page path string true "Part file name, e.g. 2.xml"
+// @Success 200 {string} string "urlset document"
+// @Failure 404 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /sitemaps/{page} [get]
+func (h *SitemapHandler) SitemapPage(c echo.Context) error {
+	page, err := strconv.Atoi(strings.TrimSuffix(c.Param("page"), ".xml"))
+	if err != nil || !strings.HasSuffix(c.Param("page"), ".xml") {
+		return utils.NotFoundResponse(c, service.ErrNotFoundSitemapPage.Error())
+	}
+
+	body, err := h.svc.SitemapPage(page)
+	if err != nil {
+		return sitemapErrorResponse(c, err)
+	}
+	c.Response().Header().Set("Cache-Control", "public, max-age=3600")
+	return c.Blob(http.StatusOK, echo.MIMEApplicationXMLCharsetUTF8, body)
+}
+
+// PUBLIC: GET /robots.txt
+// Robots godoc
+// @Summary robots.txt
+// @Description Keeps crawlers out of /admin/ and points them to the sitemap.
+// @Tags SEO (Public)
+// @Produce plain
+// @Success 200 {string} string "robots.txt"
+// @Router /robots.txt [get]
+func (h *SitemapHandler) Robots(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "public, max-age=86400")
+	return c.String(http.StatusOK, h.svc.Robots())
+}
+
+func sitemapErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrSiteNotConfigured):
+		return utils.ServiceUnavailableResponse(c, err.Error())
+	case errors.Is(err, service.ErrNotFoundSitemapPage):
+		return utils.NotFoundResponse(c, err.Error())
+	}
+	return utils.InternalServerErrorResponse(c, "failed to build sitemap")
+}
@@ -49,7 +49,8 @@ type ContactStatusUpdateRequest struct {
 }
 
 type RegistrationStatusUpdateRequest struct {
-	Status string `json:"status" validate:"required,oneof=new validate process done" example:"validate"`
+	Status string `json:"status" validate:"required,oneof=new validate process done rejected withdrawn" example:"validate"`
+	Note   string `json:"note" validate:"max=1000" example:"Berkas lengkap"` // recorded in the timeline
 }
 
 type AdminChangePasswordRequest struct {
@@ -60,8 +61,18 @@ type AdminChangePasswordRequest struct {
 // ===== Typed responses used in annotations =====
 
 type ArticleListResponse = SuccessResponse[ListResponseData[dto.ArticleDTO]]
+type ArticleRevisionListResponse = SuccessResponse[ListResponseData[dto.ArticleRevisionDTO]]
+type ArticleSearchResponse = SuccessResponse[ListResponseData[dto.ArticleSearchResultDTO]]
 type RegistrationListResponse = SuccessResponse[ListResponseData[dto.RegistrationDTO]]
 type AdminListResponse = SuccessResponse[ListResponseData[dto.AdminDTO]]
+type TrashListResponse = SuccessResponse[ListResponseData[dto.TrashItemDTO]]
+type MediaListResponse = SuccessResponse[ListResponseData[dto.MediaDTO]]
+type CategoryListResponse = SuccessResponse[[]dto.CategoryDTO]
+type TagListResponse = SuccessResponse[[]dto.TagDTO]
+type MediaUsageResponse = SuccessResponse[[]dto.MediaUsageDTO]
+type RegistrationTimelineResponse = SuccessResponse[[]dto.RegistrationStatusHistoryDTO]
+type AdmissionPeriodListResponse = SuccessResponse[[]dto.AdmissionPeriodDTO]
+type NotificationDeliveryListResponse = SuccessResponse[ListResponseData[dto.NotificationDeliveryDTO]]
 
 type ContactListItem struct {
 	ID        uint   `json:"id" example:"1"`
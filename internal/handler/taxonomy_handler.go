@@ -0,0 +1,305 @@
+package handler
+
+import (
+	"darulabror/internal/dto"
+	"darulabror/internal/service"
+	"darulabror/internal/utils"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type TaxonomyHandler struct {
+	svc service.TaxonomyService
+}
+
+func NewTaxonomyHandler(svc service.TaxonomyService) *TaxonomyHandler {
+	return &TaxonomyHandler{svc: svc}
+}
+
+// PUBLIC: GET /categories
+// ListCategories godoc
+// @Summary List categories
+// @Description Each category includes article_count = number of published articles.
+// @Tags Categories (Public)
+// @Produce json
+// @Success 200 {object} CategoryListResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /categories [get]
+func (h *TaxonomyHandler) ListCategories(c echo.Context) error {
+	items, err := h.svc.GetAllCategories(true)
+	if err != nil {
+		logrus.WithError(err).Error("failed list categories")
+		return utils.InternalServerErrorResponse(c, "failed to fetch categories")
+	}
+	return utils.SuccessResponse(c, "categories fetched", items)
+}
+
+// PUBLIC: GET /tags
+// ListTags godoc
+// @Summary List tags
+// @Description Each tag includes article_count = number of published articles.
+// @Tags Tags (Public)
+// @Produce json
+// @Success 200 {object} TagListResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tags [get]
+func (h *TaxonomyHandler) ListTags(c echo.Context) error {
+	items, err := h.svc.GetAllTags(true)
+	if err != nil {
+		logrus.WithError(err).Error("failed list tags")
+		return utils.InternalServerErrorResponse(c, "failed to fetch tags")
+	}
+	return utils.SuccessResponse(c, "tags fetched", items)
+}
+
+// ADMIN: GET /admin/categories
+// AdminListCategories godoc
+// @Summary Admin list categories
+// @Description article_count includes drafts.
+// @Tags Categories (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} CategoryListResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/categories [get]
+func (h *TaxonomyHandler) AdminListCategories(c echo.Context) error {
+	items, err := h.svc.GetAllCategories(false)
+	if err != nil {
+		logrus.WithError(err).Error("failed admin list categories")
+		return utils.InternalServerErrorResponse(c, "failed to fetch categories")
+	}
+	return utils.SuccessResponse(c, "categories fetched", items)
+}
+
+// ADMIN: POST /admin/categories
+// AdminCreateCategory godoc
+// @Summary Admin create category
+// @Tags Categories (Admin)
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CategoryDTO true "Category payload (slug optional)"
+// @Success 201 {object} SuccessResponse[dto.CategoryDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/categories [post]
+func (h *TaxonomyHandler) AdminCreateCategory(c echo.Context) error {
+	var body dto.CategoryDTO
+	if err := c.Bind(&body); err != nil {
+		return utils.BadRequestResponse(c, "invalid body")
+	}
+	if err := c.Validate(&body); err != nil {
+		return utils.UnprocessableEntityResponse(c, err.Error())
+	}
+
+	item, err := h.svc.CreateCategory(body)
+	if err != nil {
+		return taxonomyErrorResponse(c, err)
+	}
+	return utils.CreatedResponse(c, "category created", item)
+}
+
+// ADMIN: PUT /admin/categories/:id
+// AdminUpdateCategory godoc
+// @Summary Admin update category
+// @Tags Categories (Admin)
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID" minimum(1)
+// @Param request body dto.CategoryDTO true "Category payload (slug optional)"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/categories/{id} [put]
+func (h *TaxonomyHandler) AdminUpdateCategory(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	var body dto.CategoryDTO
+	if err := c.Bind(&body); err != nil {
+		return utils.BadRequestResponse(c, "invalid body")
+	}
+	if err := c.Validate(&body); err != nil {
+		return utils.UnprocessableEntityResponse(c, err.Error())
+	}
+
+	if err := h.svc.UpdateCategory(uint(id64), body); err != nil {
+		return taxonomyErrorResponse(c, err)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// ADMIN: DELETE /admin/categories/:id
+// AdminDeleteCategory godoc
+// @Summary Admin delete category
+// @Description Articles in the category are kept, only unlinked.
+// @Tags Categories (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Category ID" minimum(1)
+// @Success 204 {string} string "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/categories/{id} [delete]
+func (h *TaxonomyHandler) AdminDeleteCategory(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	if err := h.svc.DeleteCategory(uint(id64)); err != nil {
+		return taxonomyErrorResponse(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ADMIN: GET /admin/tags
+// AdminListTags godoc
+// @Summary Admin list tags
+// @Description article_count includes drafts.
+// @Tags Tags (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} TagListResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/tags [get]
+func (h *TaxonomyHandler) AdminListTags(c echo.Context) error {
+	items, err := h.svc.GetAllTags(false)
+	if err != nil {
+		logrus.WithError(err).Error("failed admin list tags")
+		return utils.InternalServerErrorResponse(c, "failed to fetch tags")
+	}
+	return utils.SuccessResponse(c, "tags fetched", items)
+}
+
+// ADMIN: POST /admin/tags
+// AdminCreateTag godoc
+// @Summary Admin create tag
+// @Tags Tags (Admin)
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.TagDTO true "Tag payload (slug optional)"
+// @Success 201 {object} SuccessResponse[dto.TagDTO]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/tags [post]
+func (h *TaxonomyHandler) AdminCreateTag(c echo.Context) error {
+	var body dto.TagDTO
+	if err := c.Bind(&body); err != nil {
+		return utils.BadRequestResponse(c, "invalid body")
+	}
+	if err := c.Validate(&body); err != nil {
+		return utils.UnprocessableEntityResponse(c, err.Error())
+	}
+
+	item, err := h.svc.CreateTag(body)
+	if err != nil {
+		return taxonomyErrorResponse(c, err)
+	}
+	return utils.CreatedResponse(c, "tag created", item)
+}
+
+// ADMIN: PUT /admin/tags/:id
+// AdminUpdateTag godoc
+// @Summary Admin update tag
+// @Tags Tags (Admin)
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID" minimum(1)
+// @Param request body dto.TagDTO true "Tag payload (slug optional)"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/tags/{id} [put]
+func (h *TaxonomyHandler) AdminUpdateTag(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	var body dto.TagDTO
+	if err := c.Bind(&body); err != nil {
+		return utils.BadRequestResponse(c, "invalid body")
+	}
+	if err := c.Validate(&body); err != nil {
+		return utils.UnprocessableEntityResponse(c, err.Error())
+	}
+
+	if err := h.svc.UpdateTag(uint(id64), body); err != nil {
+		return taxonomyErrorResponse(c, err)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// ADMIN: DELETE /admin/tags/:id
+// AdminDeleteTag godoc
+// @Summary Admin delete tag
+// @Tags Tags (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Tag ID" minimum(1)
+// @Success 204 {string} string "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/tags/{id} [delete]
+func (h *TaxonomyHandler) AdminDeleteTag(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	if err := h.svc.DeleteTag(uint(id64)); err != nil {
+		return taxonomyErrorResponse(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func taxonomyErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrNotFoundCategory), errors.Is(err, service.ErrNotFoundTag):
+		return utils.NotFoundResponse(c, err.Error())
+	case errors.Is(err, service.ErrCategoryExists), errors.Is(err, service.ErrTagExists):
+		return utils.ConflictResponse(c, err.Error())
+	case errors.Is(err, service.ErrInvalidCategory), errors.Is(err, service.ErrInvalidTag):
+		return utils.UnprocessableEntityResponse(c, "slug must contain at least one letter or digit")
+	default:
+		return utils.InternalServerErrorResponse(c, err.Error())
+	}
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"darulabror/internal/service"
+	"darulabror/internal/utils"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TrashHandler struct {
+	svc service.TrashService
+}
+
+func NewTrashHandler(svc service.TrashService) *TrashHandler {
+	return &TrashHandler{svc: svc}
+}
+
+// ADMIN: GET /admin/trash/:type
+// List godoc
+// @Summary Admin list trashed items
+// @Description Soft-deleted items of one type, most recently deleted first. purge_at is when the item is deleted for good.
+// @Tags Trash (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param type path string true "Entity type" Enums(articles, registrations, contacts)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Page size" default(10)
+// @Success 200 {object} TrashListResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/trash/{type} [get]
+func (h *TrashHandler) List(c echo.Context) error {
+	page, limit := utils.ParsePagination(c)
+	items, total, err := h.svc.ListTrash(c.Param("type"), page, limit)
+	if err != nil {
+		return trashErrorResponse(c, err)
+	}
+
+	return utils.SuccessResponse(c, "trash fetched", map[string]interface{}{
+		"items": items,
+		"meta": map[string]interface{}{
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
+
+// ADMIN: POST /admin/trash/:type/:id/restore
+// Restore godoc
+// @Summary Admin restore trashed item
+// @Tags Trash (Admin)
+// @Security BearerAuth
+// @Produce json
+// @Param type path string true "Entity type" Enums(articles, registrations, contacts)
+// @Param id path int true "Item ID" minimum(1)
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/trash/{type}/{id}/restore [post]
+func (h *TrashHandler) Restore(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	if err := h.svc.RestoreTrash(c.Param("type"), uint(id64)); err != nil {
+		return trashErrorResponse(c, err)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// SUPERADMIN: DELETE /admin/trash/:type/:id
+// Purge godoc
+// @Summary Superadmin permanently delete trashed item
+// @Description Only items already in the trash can be purged. This cannot be undone.
+// @Tags Trash (Superadmin)
+// @Security BearerAuth
+// @Produce json
+// @Param type path string true "Entity type" Enums(articles, registrations, contacts)
+// @Param id path int true "Item ID" minimum(1)
+// @Success 204 {string} string "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/trash/{type}/{id} [delete]
+func (h *TrashHandler) Purge(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, "invalid id")
+	}
+
+	if err := h.svc.PurgeTrash(c.Param("type"), uint(id64)); err != nil {
+		return trashErrorResponse(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func trashErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidTrashType), errors.Is(err, service.ErrNotFoundTrashItem):
+		return utils.NotFoundResponse(c, err.Error())
+	}
+	return utils.InternalServerErrorResponse(c, "failed to process trash request")
+}
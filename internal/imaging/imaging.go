@@ -0,0 +1,166 @@
+// Package imaging turns uploaded photos into web-sized variants: decoded,
+// auto-rotated from the EXIF orientation and re-encoded without any metadata
+// (EXIF, GPS, ...), at several widths. Everything is pure Go (the image is built
+// with CGO_ENABLED=0).
+//
+// Opaque images become JPEG. Images with transparency become WebP; the only
+// pure-Go WebP encoder is lossless, which suits graphics but would make photos
+// larger than JPEG.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png" // register decoders for image.Decode
+	"io"
+	"sort"
+
+	"github.com/HugoSmits86/nativewebp"
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// DefaultWidths are the variant widths in pixels. Images are never upscaled:
+// narrower images get the widths below their own plus one at their own width.
+var DefaultWidths = []int{320, 640, 1024, 1600}
+
+// JPEGQuality is used for every JPEG variant.
+const JPEGQuality = 82
+
+// MaxPixels guards against decompression bombs (a small file declaring a huge
+// canvas). Decoding takes 4 bytes per pixel, twice over while the image is
+// rotated: about 200 MB at the cap, enough for a 24 MP camera photo.
+const MaxPixels = 24_000_000
+
+// MaxConcurrent is how many images are decoded at once; further calls to Process
+// wait for a slot, so a burst of uploads can't take more than
+// MaxConcurrent × the memory of one image.
+const MaxConcurrent = 2
+
+var decodeSlots = make(chan struct{}, MaxConcurrent)
+
+var (
+	ErrInvalidImage  = errors.New("image could not be decoded")
+	ErrImageTooLarge = errors.New("image dimensions too large")
+)
+
+// Variant is one encoded rendition of an image.
+type Variant struct {
+	Width    int
+	Height   int
+	MimeType string // image/jpeg or image/webp
+	Ext      string // .jpg or .webp
+	Data     []byte
+}
+
+// Processable reports whether files of this MIME type are turned into variants.
+// GIFs pass through unchanged so animations survive.
+func Processable(mimeType string) bool {
+	switch mimeType {
+	case "image/jpeg", "image/png", "image/webp":
+		return true
+	}
+	return false
+}
+
+// Process decodes the image in r and returns its variants, narrowest first.
+func Process(r io.Reader, widths []int) ([]Variant, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	decodeSlots <- struct{}{}
+	defer func() { <-decodeSlots }()
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	img := orient(toNRGBA(decoded), jpegOrientation(data))
+
+	mimeType, ext := "image/jpeg", ".jpg"
+	if !img.Opaque() {
+		mimeType, ext = "image/webp", ".webp"
+	}
+
+	srcW, srcH := img.Bounds().Dx(), img.Bounds().Dy()
+	variants := make([]Variant, 0, len(widths)+1)
+	for _, w := range targetWidths(srcW, widths) {
+		h := srcH * w / srcW
+		if h < 1 {
+			h = 1
+		}
+
+		var scaled *image.NRGBA
+		if w == srcW {
+			scaled = img
+		} else {
+			scaled = image.NewNRGBA(image.Rect(0, 0, w, h))
+			xdraw.CatmullRom.Scale(scaled, scaled.Bounds(), img, img.Bounds(), xdraw.Src, nil)
+		}
+
+		var buf bytes.Buffer
+		if mimeType == "image/jpeg" {
+			err = jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: JPEGQuality})
+		} else {
+			err = nativewebp.Encode(&buf, scaled, nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		variants = append(variants, Variant{
+			Width:    w,
+			Height:   h,
+			MimeType: mimeType,
+			Ext:      ext,
+			Data:     buf.Bytes(),
+		})
+	}
+	return variants, nil
+}
+
+// targetWidths returns the widths to render for an image srcW pixels wide:
+// every width below srcW, capped by the largest one (or srcW itself when smaller).
+func targetWidths(srcW int, widths []int) []int {
+	sorted := append([]int(nil), widths...)
+	sort.Ints(sorted)
+
+	top := srcW
+	if len(sorted) > 0 && sorted[len(sorted)-1] < top {
+		top = sorted[len(sorted)-1]
+	}
+
+	out := make([]int, 0, len(sorted)+1)
+	for _, w := range sorted {
+		if w > 0 && w < top && (len(out) == 0 || out[len(out)-1] != w) {
+			out = append(out, w)
+		}
+	}
+	return append(out, top)
+}
+
+func toNRGBA(img image.Image) *image.NRGBA {
+	if n, ok := img.(*image.NRGBA); ok && n.Rect.Min == (image.Point{}) {
+		return n
+	}
+	b := img.Bounds()
+	out := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(out, out.Bounds(), img, b.Min, draw.Src)
+	return out
+}
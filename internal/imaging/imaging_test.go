@@ -0,0 +1,161 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/HugoSmits86/nativewebp"
+)
+
+// photo is a w×h image with a gradient; alpha below 255 makes it transparent.
+func photo(w, h int, alpha uint8) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x * 255 / w), G: uint8(y * 255 / h), B: 100, A: alpha})
+		}
+	}
+	return img
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodeWebP(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := nativewebp.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// withEXIF inserts an EXIF segment holding orientation right after the JPEG's SOI.
+func withEXIF(data []byte, orientation uint16) []byte {
+	exif := exifJPEG(binary.BigEndian, orientation)
+	out := append([]byte(nil), exif[:len(exif)-2]...) // without its EOI
+	return append(out, data[2:]...)
+}
+
+// pngHeader is a PNG declaring a w×h canvas, without any image data.
+func pngHeader(w, h uint32) []byte {
+	ihdr := make([]byte, 4+13)
+	copy(ihdr, "IHDR")
+	binary.BigEndian.PutUint32(ihdr[4:], w)
+	binary.BigEndian.PutUint32(ihdr[8:], h)
+	ihdr[12], ihdr[13] = 8, 6 // 8-bit RGBA
+
+	data := []byte("\x89PNG\r\n\x1a\n")
+	data = binary.BigEndian.AppendUint32(data, 13)
+	data = append(data, ihdr...)
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(ihdr))
+}
+
+func TestProcess(t *testing.T) {
+	photoJPEG := encodeJPEG(t, photo(400, 200, 255))
+
+	tests := []struct {
+		name     string
+		data     []byte
+		widths   []int
+		wantMIME string
+		want     []image.Point // variant sizes, narrowest first
+	}{
+		{"jpeg", photoJPEG, []int{100, 200, 1024}, "image/jpeg", []image.Point{{100, 50}, {200, 100}, {400, 200}}},
+		{"capped at the widest width", photoJPEG, []int{100, 300}, "image/jpeg", []image.Point{{100, 50}, {300, 150}}},
+		{"exif rotated", withEXIF(photoJPEG, 6), []int{100}, "image/jpeg", []image.Point{{100, 200}}},
+		{"opaque png", encodePNG(t, photo(400, 200, 255)), []int{200}, "image/jpeg", []image.Point{{200, 100}}},
+		{"transparent png", encodePNG(t, photo(400, 200, 128)), []int{200}, "image/webp", []image.Point{{200, 100}}},
+		{"opaque webp", encodeWebP(t, photo(400, 200, 255)), []int{200}, "image/jpeg", []image.Point{{200, 100}}},
+		{"transparent webp", encodeWebP(t, photo(400, 200, 0)), []int{200}, "image/webp", []image.Point{{200, 100}}},
+		{"never upscaled", encodePNG(t, photo(80, 40, 255)), []int{200, 400}, "image/jpeg", []image.Point{{80, 40}}},
+	}
+	for _, tt := range tests {
+		variants, err := Process(bytes.NewReader(tt.data), tt.widths)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if len(variants) != len(tt.want) {
+			t.Errorf("%s: got %d variants, want %d", tt.name, len(variants), len(tt.want))
+			continue
+		}
+		for i, v := range variants {
+			if v.MimeType != tt.wantMIME {
+				t.Errorf("%s: variant %d is %s, want %s", tt.name, i, v.MimeType, tt.wantMIME)
+			}
+			decoded, format, err := image.Decode(bytes.NewReader(v.Data))
+			if err != nil {
+				t.Errorf("%s: variant %d does not decode: %v", tt.name, i, err)
+				continue
+			}
+			if "image/"+format != v.MimeType {
+				t.Errorf("%s: variant %d is labelled %s but encoded as %s", tt.name, i, v.MimeType, format)
+			}
+			if got := decoded.Bounds().Size(); got != tt.want[i] || got != (image.Point{v.Width, v.Height}) {
+				t.Errorf("%s: variant %d is %v (labelled %dx%d), want %v", tt.name, i, got, v.Width, v.Height, tt.want[i])
+			}
+			if bytes.Contains(v.Data, []byte("Exif\x00\x00")) {
+				t.Errorf("%s: variant %d still carries EXIF", tt.name, i)
+			}
+		}
+	}
+}
+
+func TestProcessTransparencyKept(t *testing.T) {
+	variants, err := Process(bytes.NewReader(encodePNG(t, photo(40, 20, 0))), []int{40})
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(variants[0].Data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, a := decoded.At(10, 10).RGBA(); a != 0 {
+		t.Errorf("alpha %d, want fully transparent", a)
+	}
+}
+
+func TestProcessRejects(t *testing.T) {
+	side := uint32(4_000) // MaxPixels is a multiple of 4000
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"not an image", []byte("%PDF-1.7"), ErrInvalidImage},
+		{"empty", nil, ErrInvalidImage},
+		{"truncated", encodeJPEG(t, photo(40, 20, 255))[:100], ErrInvalidImage},
+		{"zero width", pngHeader(0, 10), ErrInvalidImage},
+		{"at the pixel cap", pngHeader(side, MaxPixels/side), ErrInvalidImage}, // passes the cap, has no pixel data
+		{"over the pixel cap", pngHeader(side, MaxPixels/side+1), ErrImageTooLarge},
+		{"decompression bomb", pngHeader(100_000, 100_000), ErrImageTooLarge},
+	}
+	for _, tt := range tests {
+		if _, err := Process(bytes.NewReader(tt.data), DefaultWidths); !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
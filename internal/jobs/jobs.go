@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Every runs fn once right away and then every interval until ctx is cancelled.
+// Errors and panics are logged and never stop the loop. Call it in its own goroutine.
+func Every(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	if interval <= 0 {
+		logrus.WithField("job", name).Warn("job disabled (interval <= 0)")
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job":      name,
+		"interval": interval.String(),
+	}).Info("job started")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		runOnce(ctx, name, fn)
+
+		select {
+		case <-ctx.Done():
+			logrus.WithField("job", name).Info("job stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func runOnce(ctx context.Context, name string, fn func(ctx context.Context) error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.WithFields(logrus.Fields{
+				"job":   name,
+				"panic": r,
+			}).Error("job panicked")
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		logrus.WithError(err).WithField("job", name).Error("job run failed")
+	}
+}
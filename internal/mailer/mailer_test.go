@@ -0,0 +1,79 @@
+package mailer
+
+import (
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildMessage(t *testing.T) {
+	from := mail.Address{Name: "Panitia PSB Darul Abror", Address: "psb@darulabror.example"}
+	msg := Message{
+		To:      []string{"wali@example.com", "admin@darulabror.example"},
+		Subject: "Status pendaftaran DA-25-K7M2QX: Sedang divalidasi – mohon cek",
+		Body:    "Assalamu'alaikum,\n\nBaris yang sangat panjang " + strings.Repeat("dokumen ", 20) + "selesai.\r\nSalam\n",
+	}
+	now := time.Date(2025, 7, 1, 10, 0, 0, 0, WIB)
+
+	data, err := buildMessage(from, msg, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != msg.Subject {
+		t.Errorf("subject %q, want %q", subject, msg.Subject)
+	}
+	if got, err := parsed.Header.AddressList("From"); err != nil || len(got) != 1 || *got[0] != from {
+		t.Errorf("from %v, %v", got, err)
+	}
+	if got := parsed.Header.Get("To"); got != "wali@example.com, admin@darulabror.example" {
+		t.Errorf("to %q", got)
+	}
+	if got, err := parsed.Header.Date(); err != nil || !got.Equal(now) {
+		t.Errorf("date %v, %v", got, err)
+	}
+	if id := parsed.Header.Get("Message-ID"); !strings.HasPrefix(id, "<") || !strings.HasSuffix(id, "@darulabror.example>") {
+		t.Errorf("message-id %q", id)
+	}
+	if got := parsed.Header.Get("Content-Type"); got != `text/plain; charset="utf-8"` {
+		t.Errorf("content-type %q", got)
+	}
+
+	raw, err := io.ReadAll(parsed.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(string(raw), "\r\n") {
+		if len(line) > 76 {
+			t.Errorf("line of %d characters: %q", len(line), line)
+		}
+	}
+	body, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(string(raw))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n")
+	if string(body) != want {
+		t.Errorf("body %q, want %q", body, want)
+	}
+
+	other, err := buildMessage(from, msg, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reparsed, err := mail.ReadMessage(strings.NewReader(string(other))); err != nil || reparsed.Header.Get("Message-ID") == parsed.Header.Get("Message-ID") {
+		t.Errorf("message-id repeated: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package mailer
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	sendTimeout = 30 * time.Second
+	sendRetries = 3
+)
+
+// Queue sends messages in the background, so requests don't wait for the SMTP
+// server. Failed sends are retried a few times; messages still queued when the
+// process stops are lost.
+type Queue struct {
+	sender Sender
+	jobs   chan Message
+}
+
+func NewQueue(sender Sender, size int) *Queue {
+	return &Queue{sender: sender, jobs: make(chan Message, size)}
+}
+
+// Enqueue queues msg without blocking; it is dropped (and logged) when the
+// queue is full.
+func (q *Queue) Enqueue(msg Message) {
+	select {
+	case q.jobs <- msg:
+	default:
+		logrus.WithFields(logrus.Fields{
+			"to":      strings.Join(msg.To, ","),
+			"subject": msg.Subject,
+		}).Error("mail queue full, message dropped")
+	}
+}
+
+// Run sends queued messages with the given number of workers until ctx is
+// cancelled. Call it in its own goroutine.
+func (q *Queue) Run(ctx context.Context, workers int) {
+	done := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case msg := <-q.jobs:
+					q.send(ctx, msg)
+				}
+			}
+		}()
+	}
+	for i := 0; i < workers; i++ {
+		<-done
+	}
+}
+
+func (q *Queue) send(ctx context.Context, msg Message) {
+	log := logrus.WithFields(logrus.Fields{
+		"to":      strings.Join(msg.To, ","),
+		"subject": msg.Subject,
+	})
+
+	backoff := 5 * time.Second
+	for attempt := 1; ; attempt++ {
+		sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+		err := q.sender.Send(sendCtx, msg)
+		cancel()
+		if err == nil {
+			log.Debug("mail sent")
+			return
+		}
+		if attempt == sendRetries {
+			log.WithError(err).Error("failed send mail, giving up")
+			return
+		}
+		log.WithError(err).WithField("attempt", attempt).Warn("failed send mail, retrying")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 5
+	}
+}
@@ -0,0 +1,69 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Mail templates (Indonesian), one file per mail in templates/, each defining
+// a "subject" and a "body" template.
+const (
+	TemplateRegistrationReceived      = "registration_received"
+	TemplateRegistrationStatusChanged = "registration_status_changed"
+	TemplateContactAcknowledged       = "contact_acknowledged"
+	TemplateAdminDigest               = "admin_digest"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// WIB is the time zone dates in mails are written in (Indonesia has no DST).
+var WIB = time.FixedZone("WIB", 7*60*60)
+
+var months = [...]string{"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+
+var funcs = template.FuncMap{
+	// tanggal formats t as "2 Januari 2025 pukul 10.00 WIB"
+	"tanggal": func(t time.Time) string {
+		t = t.In(WIB)
+		return fmt.Sprintf("%d %s %d pukul %s WIB", t.Day(), months[t.Month()-1], t.Year(), t.Format("15.04"))
+	},
+}
+
+var templates = func() map[string]*template.Template {
+	out := make(map[string]*template.Template)
+	for _, name := range []string{
+		TemplateRegistrationReceived,
+		TemplateRegistrationStatusChanged,
+		TemplateContactAcknowledged,
+		TemplateAdminDigest,
+	} {
+		out[name] = template.Must(template.New(name).Funcs(funcs).ParseFS(templateFiles, "templates/"+name+".tmpl"))
+	}
+	return out
+}()
+
+// Render builds the mail named name (one of the Template* constants) for data.
+func Render(name string, data any, to ...string) (Message, error) {
+	tmpl, ok := templates[name]
+	if !ok {
+		return Message{}, fmt.Errorf("mailer: unknown template %q", name)
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return Message{}, err
+	}
+	return Message{
+		To:      to,
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Body:    strings.TrimSpace(body.String()) + "\n",
+	}, nil
+}
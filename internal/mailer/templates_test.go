@@ -0,0 +1,202 @@
+package mailer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// notice has the fields the registration templates use.
+type notice struct {
+	SiteName        string
+	SiteURL         string
+	FullName        string
+	ReferenceNumber string
+	AcademicYear    string
+	WaveName        string
+	StatusLabel     string
+	StatusInfo      string
+}
+
+type digest struct {
+	SiteName          string
+	From, To          time.Time
+	RegistrationTotal int64
+	Registrations     []map[string]string
+	MoreRegistrations int64
+	ContactTotal      int64
+	Contacts          []map[string]string
+	MoreContacts      int64
+}
+
+func TestRender(t *testing.T) {
+	reg := notice{
+		SiteName:        "Darul Abror",
+		SiteURL:         "https://darulabror.example",
+		FullName:        "Ahmad Fauzi",
+		ReferenceNumber: "DA-25-K7M2QX",
+		AcademicYear:    "2025/2026",
+		WaveName:        "Gelombang 1",
+		StatusLabel:     "Selesai",
+		StatusInfo:      "Informasi daftar ulang akan disampaikan oleh panitia.",
+	}
+	bare := reg
+	bare.SiteURL, bare.WaveName, bare.AcademicYear = "", "", ""
+	spaced := reg
+	spaced.FullName = "  Ahmad\n\tFauzi "
+
+	day := time.Date(2025, 7, 1, 17, 0, 0, 0, time.UTC) // 2 Juli 00.00 WIB
+
+	tests := []struct {
+		name        string
+		template    string
+		data        any
+		wantSubject string
+		wantBody    []string
+		notBody     []string
+	}{
+		{
+			name:        "received",
+			template:    TemplateRegistrationReceived,
+			data:        reg,
+			wantSubject: "Pendaftaran Ahmad Fauzi telah kami terima (DA-25-K7M2QX)",
+			wantBody: []string{
+				"atas nama Ahmad Fauzi telah kami terima pada Gelombang 1 tahun ajaran 2025/2026.\n",
+				"Nomor pendaftaran: DA-25-K7M2QX\n",
+				"status pendaftaran di https://darulabror.example.\n",
+				"\nDarul Abror\n",
+			},
+		},
+		{
+			name:        "received without a period or site url",
+			template:    TemplateRegistrationReceived,
+			data:        bare,
+			wantSubject: "Pendaftaran Ahmad Fauzi telah kami terima (DA-25-K7M2QX)",
+			wantBody:    []string{"atas nama Ahmad Fauzi telah kami terima.\n", "status pendaftaran.\n"},
+			notBody:     []string{"tahun ajaran", " di "},
+		},
+		{
+			name:        "subject whitespace collapsed",
+			template:    TemplateRegistrationReceived,
+			data:        spaced,
+			wantSubject: "Pendaftaran Ahmad Fauzi telah kami terima (DA-25-K7M2QX)",
+		},
+		{
+			name:        "status changed",
+			template:    TemplateRegistrationStatusChanged,
+			data:        reg,
+			wantSubject: "Status pendaftaran DA-25-K7M2QX: Selesai",
+			wantBody: []string{
+				"(nomor pendaftaran DA-25-K7M2QX) kini: Selesai.\n",
+				"\nInformasi daftar ulang akan disampaikan oleh panitia.\n",
+				"dapat dicek di https://darulabror.example dengan",
+			},
+		},
+		{
+			name:        "status changed without site url",
+			template:    TemplateRegistrationStatusChanged,
+			data:        bare,
+			wantSubject: "Status pendaftaran DA-25-K7M2QX: Selesai",
+			wantBody:    []string{"dapat dicek dengan nomor pendaftaran"},
+		},
+		{
+			name:        "contact acknowledged",
+			template:    TemplateContactAcknowledged,
+			data:        struct{ SiteName string }{"Darul Abror"},
+			wantSubject: "Pesan Anda telah kami terima - Darul Abror",
+			wantBody:    []string{"Terima kasih telah menghubungi Darul Abror.", "\nDarul Abror\n"},
+		},
+		{
+			name:     "admin digest",
+			template: TemplateAdminDigest,
+			data: digest{
+				SiteName:          "Darul Abror",
+				From:              day.Add(-24 * time.Hour),
+				To:                day,
+				RegistrationTotal: 5,
+				Registrations: []map[string]string{
+					{"ReferenceNumber": "DA-25-K7M2QX", "FullName": "Ahmad Fauzi", "Email": "ahmad@example.com"},
+					{"ReferenceNumber": "DA-25-P3R8TW", "FullName": "Siti & Aisyah", "Email": "siti@example.com"},
+				},
+				MoreRegistrations: 3,
+			},
+			wantSubject: "[Darul Abror] Ringkasan: 5 pendaftaran, 0 pesan baru",
+			wantBody: []string{
+				"Ringkasan 1 Juli 2025 pukul 00.00 WIB s.d. 2 Juli 2025 pukul 00.00 WIB\n",
+				"Pendaftaran baru: 5\n- DA-25-K7M2QX Ahmad Fauzi (ahmad@example.com)\n- DA-25-P3R8TW Siti & Aisyah (siti@example.com)\n- ... dan 3 lainnya\n",
+				"Pesan kontak baru: 0\n\nDetailnya ada di panel admin.\n",
+			},
+		},
+		{
+			name:     "admin digest with contacts only",
+			template: TemplateAdminDigest,
+			data: digest{
+				SiteName:     "Darul Abror",
+				From:         day.Add(-24 * time.Hour),
+				To:           day,
+				ContactTotal: 1,
+				Contacts:     []map[string]string{{"Email": "wali@example.com", "Subject": "Biaya pendaftaran"}},
+			},
+			wantSubject: "[Darul Abror] Ringkasan: 0 pendaftaran, 1 pesan baru",
+			wantBody: []string{
+				"Pendaftaran baru: 0\n\nPesan kontak baru: 1\n- wali@example.com: Biaya pendaftaran\n\nDetailnya",
+			},
+		},
+	}
+	for _, tt := range tests {
+		msg, err := Render(tt.template, tt.data, "wali@example.com")
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if msg.Subject != tt.wantSubject {
+			t.Errorf("%s: subject %q, want %q", tt.name, msg.Subject, tt.wantSubject)
+		}
+		if !reflect.DeepEqual(msg.To, []string{"wali@example.com"}) {
+			t.Errorf("%s: to %q", tt.name, msg.To)
+		}
+		if msg.Body != strings.TrimSpace(msg.Body)+"\n" {
+			t.Errorf("%s: body not trimmed to a single trailing newline: %q", tt.name, msg.Body)
+		}
+		for _, want := range tt.wantBody {
+			if !strings.Contains(msg.Body, want) {
+				t.Errorf("%s: body lacks %q:\n%s", tt.name, want, msg.Body)
+			}
+		}
+		for _, unwanted := range append(tt.notBody, "<no value>", "&amp;") {
+			if strings.Contains(msg.Body, unwanted) {
+				t.Errorf("%s: body contains %q:\n%s", tt.name, unwanted, msg.Body)
+			}
+		}
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	if _, err := Render("registration_approved", notice{}); err == nil || !strings.Contains(err.Error(), "unknown template") {
+		t.Errorf("unknown template: got %v", err)
+	}
+	// a field the data lacks fails the render instead of mailing a blank
+	if _, err := Render(TemplateRegistrationReceived, struct{ SiteName string }{"Darul Abror"}); err == nil {
+		t.Error("missing fields: got no error")
+	}
+}
+
+func TestTanggal(t *testing.T) {
+	tanggal := funcs["tanggal"].(func(time.Time) string)
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{time.Date(2025, 1, 2, 3, 0, 0, 0, time.UTC), "2 Januari 2025 pukul 10.00 WIB"},
+		{time.Date(2025, 8, 17, 9, 5, 0, 0, time.UTC), "17 Agustus 2025 pukul 16.05 WIB"},
+		{time.Date(2025, 12, 31, 17, 30, 0, 0, time.UTC), "1 Januari 2026 pukul 00.30 WIB"}, // next day in WIB
+		{time.Date(2025, 3, 1, 0, 0, 0, 0, WIB), "1 Maret 2025 pukul 00.00 WIB"},
+		{time.Date(2025, 5, 20, 10, 0, 0, 0, time.FixedZone("WITA", 8*60*60)), "20 Mei 2025 pukul 09.00 WIB"},
+	}
+	for _, tt := range tests {
+		if got := tanggal(tt.t); got != tt.want {
+			t.Errorf("%v: got %q, want %q", tt.t, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GatewayConfig describes an HTTP messaging gateway. Messages are POSTed to URL
+// as JSON:
+//
+//	{"channel": "whatsapp", "to": "+6281234567890", "message": "..."}
+//
+// with AuthHeader: AuthToken (e.g. "Authorization: Bearer <token>") and the
+// delivery ID as Idempotency-Key. Any 2xx is a success.
+type GatewayConfig struct {
+	Channel    string // name sent to the gateway and recorded on deliveries
+	URL        string
+	AuthHeader string // default "Authorization"
+	AuthToken  string
+	Timeout    time.Duration // default 15s
+}
+
+type httpGateway struct {
+	cfg    GatewayConfig
+	client *http.Client
+}
+
+func NewHTTPGateway(cfg GatewayConfig) Channel {
+	if cfg.AuthHeader == "" {
+		cfg.AuthHeader = "Authorization"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 15 * time.Second
+	}
+	return &httpGateway{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}
+}
+
+func (g *httpGateway) Name() string {
+	return g.cfg.Channel
+}
+
+func (g *httpGateway) Send(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(map[string]string{
+		"channel": g.cfg.Channel,
+		"to":      msg.To,
+		"message": msg.Text,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.cfg.AuthToken != "" {
+		req.Header.Set(g.cfg.AuthHeader, g.cfg.AuthToken)
+	}
+	if msg.ID != "" {
+		req.Header.Set("Idempotency-Key", msg.ID)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("gateway responded %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	// other 4xx: the request itself is wrong, sending it again won't help
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return fmt.Errorf("%w: %v", ErrPermanent, err)
+	}
+	return err
+}
@@ -0,0 +1,87 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPGatewaySend(t *testing.T) {
+	var (
+		status int
+		got    *http.Request
+		body   map[string]string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body = nil
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("detail"))
+	}))
+	defer srv.Close()
+
+	gw := NewHTTPGateway(GatewayConfig{Channel: "whatsapp", URL: srv.URL, AuthHeader: "X-Api-Key", AuthToken: "secret"})
+	msg := Message{ID: "delivery-7", To: "+6281234567890", Text: "Assalamu'alaikum"}
+
+	tests := []struct {
+		status    int
+		ok        bool
+		permanent bool
+	}{
+		{http.StatusOK, true, false},
+		{http.StatusAccepted, true, false},
+		{http.StatusBadRequest, false, true},
+		{http.StatusUnauthorized, false, true},
+		{http.StatusNotFound, false, true},
+		{http.StatusUnprocessableEntity, false, true},
+		{http.StatusRequestTimeout, false, false},
+		{http.StatusTooManyRequests, false, false},
+		{http.StatusInternalServerError, false, false},
+		{http.StatusBadGateway, false, false},
+		{http.StatusServiceUnavailable, false, false},
+	}
+	for _, tt := range tests {
+		status = tt.status
+		err := gw.Send(context.Background(), msg)
+		switch {
+		case tt.ok && err != nil:
+			t.Errorf("%d: unexpected error %v", tt.status, err)
+		case !tt.ok && err == nil:
+			t.Errorf("%d: expected an error", tt.status)
+		case !tt.ok && errors.Is(err, ErrPermanent) != tt.permanent:
+			t.Errorf("%d: permanent = %v, want %v (%v)", tt.status, errors.Is(err, ErrPermanent), tt.permanent, err)
+		}
+	}
+
+	// the request the gateway receives
+	if got.Method != http.MethodPost {
+		t.Errorf("method = %s", got.Method)
+	}
+	if v := got.Header.Get("X-Api-Key"); v != "secret" {
+		t.Errorf("auth header = %q", v)
+	}
+	if v := got.Header.Get("Idempotency-Key"); v != "delivery-7" {
+		t.Errorf("Idempotency-Key = %q", v)
+	}
+	if v := got.Header.Get("Content-Type"); v != "application/json" {
+		t.Errorf("Content-Type = %q", v)
+	}
+	if body["channel"] != "whatsapp" || body["to"] != msg.To || body["message"] != msg.Text {
+		t.Errorf("body = %v", body)
+	}
+}
+
+func TestHTTPGatewaySendUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	err := NewHTTPGateway(GatewayConfig{Channel: "sms", URL: url}).Send(context.Background(), Message{To: "+6281234567890", Text: "x"})
+	if err == nil || errors.Is(err, ErrPermanent) {
+		t.Errorf("unreachable gateway: got %v, want a retryable error", err)
+	}
+}
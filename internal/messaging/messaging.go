@@ -0,0 +1,26 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+)
+
+// Message is a short text to a phone number (E.164).
+type Message struct {
+	// ID identifies the delivery; gateways get it as Idempotency-Key so retries
+	// of an already accepted message can be dropped on their side.
+	ID   string
+	To   string
+	Text string
+}
+
+// Channel delivers text messages, e.g. over WhatsApp or SMS.
+type Channel interface {
+	// Name is the channel recorded on deliveries ("whatsapp", "sms", ...).
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// ErrPermanent marks send failures retrying won't fix (rejected number,
+// invalid credentials, ...).
+var ErrPermanent = errors.New("permanent delivery failure")
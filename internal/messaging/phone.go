@@ -0,0 +1,48 @@
+package messaging
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidPhone = errors.New("invalid phone number")
+
+// NormalizePhone turns an Indonesian phone number as people write it
+// ("0812-3456-7890", "+62 812 3456 7890", "62812...", "812...") into E.164
+// ("+6281234567890"). Numbers with another country code are kept as given.
+func NormalizePhone(raw string) (string, error) {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(raw) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", ErrInvalidPhone
+		}
+	}
+	n := b.String()
+
+	switch {
+	case strings.HasPrefix(n, "+"):
+		n = n[1:]
+	case strings.HasPrefix(n, "0"):
+		n = "62" + n[1:]
+	case strings.HasPrefix(n, "8"):
+		n = "62" + n
+	}
+	if strings.HasPrefix(n, "620") {
+		n = "62" + n[3:] // "+62 (0)812..."
+	}
+
+	// E.164: at most 15 digits; Indonesian numbers have 8 to 12 after +62
+	if len(n) < 8 || len(n) > 15 || strings.HasPrefix(n, "0") {
+		return "", ErrInvalidPhone
+	}
+	if strings.HasPrefix(n, "62") && (len(n) < 10 || len(n) > 14 || n[2] == '0') {
+		return "", ErrInvalidPhone
+	}
+	return "+" + n, nil
+}
@@ -0,0 +1,52 @@
+package messaging
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string // empty: ErrInvalidPhone
+	}{
+		// the ways Indonesian numbers are written
+		{"081234567890", "+6281234567890"},
+		{"0812-3456-7890", "+6281234567890"},
+		{"0812 3456 7890", "+6281234567890"},
+		{"(0812) 3456.7890", "+6281234567890"},
+		{"+6281234567890", "+6281234567890"},
+		{"+62 812 3456 7890", "+6281234567890"},
+		{"6281234567890", "+6281234567890"},
+		{"81234567890", "+6281234567890"},
+		{"+62 (0)812 3456 7890", "+6281234567890"},
+		{"62081234567890", "+6281234567890"},
+		{"  081234567890 ", "+6281234567890"},
+		{"0215551234", "+62215551234"}, // Jakarta landline
+		// other country codes are kept
+		{"+60 12-345 6789", "+60123456789"},
+		{"+14155552671", "+14155552671"},
+		// invalid
+		{"", ""},
+		{"0000000000", ""},
+		{"0812", ""},
+		{"08123456789012345", ""},
+		{"0812a4567890", ""},
+		{"0812+4567890", ""},
+		{"++6281234567890", ""},
+		{"+0812345678", ""},
+		{"+62812345", ""},
+	}
+	for _, tt := range tests {
+		got, err := NormalizePhone(tt.raw)
+		if tt.want == "" {
+			if !errors.Is(err, ErrInvalidPhone) {
+				t.Errorf("NormalizePhone(%q) = %q, %v; want ErrInvalidPhone", tt.raw, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizePhone(%q) = %q, %v; want %q", tt.raw, got, err, tt.want)
+		}
+	}
+}
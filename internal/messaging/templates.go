@@ -0,0 +1,35 @@
+package messaging
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Message templates (Indonesian), one file per event in templates/. They're
+// kept short: WhatsApp and SMS, read on a phone.
+const (
+	TemplateRegistrationReceived      = "registration_received"
+	TemplateRegistrationStatusChanged = "registration_status_changed"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+var templates = template.Must(template.ParseFS(templateFiles, "templates/*.tmpl"))
+
+// Render builds the text of the message for event name (one of the Template*
+// constants).
+func Render(name string, data any) (string, error) {
+	tmpl := templates.Lookup(name + ".tmpl")
+	if tmpl == nil {
+		return "", fmt.Errorf("messaging: unknown template %q", name)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
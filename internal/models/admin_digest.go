@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// AdminDigest records a sent admin digest mail, one per window, so restarts
+// and other instances don't send it again.
+type AdminDigest struct {
+	PeriodEnd time.Time `gorm:"primaryKey" json:"period_end"`
+	SentAt    time.Time `gorm:"not null" json:"sent_at"`
+}
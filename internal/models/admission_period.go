@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// AdmissionPeriod is an admission wave (gelombang) of an academic year. New
+// registrations are assigned to the period open at that moment; periods never
+// overlap. A nil quota means no limit.
+type AdmissionPeriod struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	AcademicYear string    `gorm:"not null;index" json:"academic_year"` // e.g. "2025/2026"
+	WaveName     string    `gorm:"not null" json:"wave_name"`           // e.g. "Gelombang 1"
+	OpensAt      time.Time `gorm:"not null" json:"opens_at"`
+	ClosesAt     time.Time `gorm:"not null" json:"closes_at"`
+
+	QuotaMale     *int `json:"quota_male"`
+	QuotaFemale   *int `json:"quota_female"`
+	QuotaNew      *int `json:"quota_new"`
+	QuotaTransfer *int `json:"quota_transfer"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// AdmissionSeats counts the registrations holding a seat in a period (status in
+// SeatStatuses), per gender and per student type.
+type AdmissionSeats struct {
+	Male     int64
+	Female   int64
+	New      int64
+	Transfer int64
+}
+
+// IsOpen reports whether registrations are accepted at t (OpensAt inclusive,
+// ClosesAt exclusive).
+func (p AdmissionPeriod) IsOpen(t time.Time) bool {
+	return !t.Before(p.OpensAt) && t.Before(p.ClosesAt)
+}
+
+// HasSeat reports whether one more registration of this gender and student
+// type fits the period's quotas, given the seats already taken.
+func (p AdmissionPeriod) HasSeat(gender Gender, studentType StudentType, taken AdmissionSeats) bool {
+	var genderQuota, typeQuota *int
+	var genderTaken, typeTaken int64
+	switch gender {
+	case Male:
+		genderQuota, genderTaken = p.QuotaMale, taken.Male
+	case Female:
+		genderQuota, genderTaken = p.QuotaFemale, taken.Female
+	}
+	switch studentType {
+	case StudentNew:
+		typeQuota, typeTaken = p.QuotaNew, taken.New
+	case StudentTransfer:
+		typeQuota, typeTaken = p.QuotaTransfer, taken.Transfer
+	}
+	if genderQuota != nil && genderTaken >= int64(*genderQuota) {
+		return false
+	}
+	if typeQuota != nil && typeTaken >= int64(*typeQuota) {
+		return false
+	}
+	return true
+}
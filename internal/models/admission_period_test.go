@@ -0,0 +1,58 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdmissionPeriodIsOpen(t *testing.T) {
+	opens := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := AdmissionPeriod{OpensAt: opens, ClosesAt: opens.AddDate(0, 3, 0)}
+
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{opens.Add(-time.Second), false},
+		{opens, true}, // inclusive
+		{opens.AddDate(0, 1, 0), true},
+		{p.ClosesAt.Add(-time.Second), true},
+		{p.ClosesAt, false}, // exclusive
+		{p.ClosesAt.AddDate(1, 0, 0), false},
+	}
+	for _, tt := range tests {
+		if got := p.IsOpen(tt.at); got != tt.want {
+			t.Errorf("%v: got %v, want %v", tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestAdmissionPeriodHasSeat(t *testing.T) {
+	quota := func(n int) *int { return &n }
+	p := AdmissionPeriod{QuotaMale: quota(2), QuotaFemale: quota(0), QuotaTransfer: quota(1)} // no limit on new students
+
+	tests := []struct {
+		name        string
+		gender      Gender
+		studentType StudentType
+		taken       AdmissionSeats
+		want        bool
+	}{
+		{"seats left", Male, StudentNew, AdmissionSeats{Male: 1, New: 50}, true},
+		{"male quota full", Male, StudentNew, AdmissionSeats{Male: 2}, false},
+		{"over quota after it was lowered", Male, StudentNew, AdmissionSeats{Male: 5}, false},
+		{"zero quota", Female, StudentNew, AdmissionSeats{}, false},
+		{"transfer quota full", Male, StudentTransfer, AdmissionSeats{Male: 1, Transfer: 1}, false},
+		{"transfer seat left", Male, StudentTransfer, AdmissionSeats{Male: 1}, true},
+	}
+	for _, tt := range tests {
+		if got := p.HasSeat(tt.gender, tt.studentType, tt.taken); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	unlimited := AdmissionPeriod{}
+	if !unlimited.HasSeat(Female, StudentTransfer, AdmissionSeats{Female: 1000, Transfer: 1000}) {
+		t.Error("a period without quotas should always have a seat")
+	}
+}
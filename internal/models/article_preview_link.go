@@ -0,0 +1,14 @@
+package models
+
+// ArticlePreviewLink grants read access to one article, published or not, to
+// anyone holding the token. Only the token's SHA-256 is stored.
+type ArticlePreviewLink struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement" json:"id"`
+	ArticleID uint   `gorm:"not null;index" json:"article_id"`
+	TokenHash string `gorm:"not null;uniqueIndex" json:"-"`
+	Note      string `gorm:"type:text;not null;default:''" json:"note"` // who it was shared with, e.g. "Kepala sekolah"
+	CreatedBy uint   `gorm:"not null" json:"created_by"`                // admin ID
+	ExpiresAt int64  `gorm:"not null" json:"expires_at"`                // unix seconds
+	RevokedAt *int64 `json:"revoked_at"`                                // unix seconds
+	CreatedAt int64  `gorm:"autoCreateTime" json:"created_at"`
+}
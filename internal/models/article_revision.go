@@ -0,0 +1,20 @@
+package models
+
+import "gorm.io/datatypes"
+
+// ArticleRevision is an immutable snapshot of an article, written on every create/update/restore.
+// Revision numbers are sequential per article, starting at 1.
+type ArticleRevision struct {
+	ID           uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	ArticleID    uint           `gorm:"not null;uniqueIndex:idx_article_revisions_article_revision" json:"article_id"`
+	Revision     int            `gorm:"not null;uniqueIndex:idx_article_revisions_article_revision" json:"revision"`
+	Title        string         `gorm:"not null" json:"title"`
+	Slug         string         `gorm:"not null" json:"slug"`
+	PhotoHeader  string         `gorm:"type:text" json:"photo_header"`
+	Content      datatypes.JSON `gorm:"type:jsonb;not null" json:"content"`
+	Author       string         `gorm:"not null" json:"author"`
+	Status       string         `gorm:"not null" json:"status"`
+	AdminID      *uint          `json:"admin_id"`      // nil for snapshots taken of pre-existing content
+	RestoredFrom *int           `json:"restored_from"` // revision number this one was restored from
+	CreatedAt    int64          `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -1,14 +1,43 @@
 package models
 
-import "gorm.io/datatypes"
+import (
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	ArticleStatusDraft     = "draft"
+	ArticleStatusPublished = "published"
+	ArticleStatusScheduled = "scheduled" // goes live at PublishAt
+)
 
 type Article struct {
 	ID          uint           `gorm:"primaryKey;autoIncrement" json:"id"`
 	Title       string         `gorm:"not null" json:"title"`
+	Slug        string         `gorm:"uniqueIndex;not null" json:"slug"`
 	PhotoHeader string         `gorm:"type:text" json:"photo_header"`
 	Content     datatypes.JSON `gorm:"type:jsonb;not null" json:"content"`
+	Excerpt     string         `gorm:"type:text;not null;default:''" json:"excerpt"` // plain-text start of Content, refreshed on save
 	Author      string         `gorm:"not null" json:"author"`
-	Status      string         `gorm:"not null;default:'draft'" json:"status"` // draft / published
+	Status      string         `gorm:"not null;default:'draft'" json:"status"` // draft / published / scheduled
+	PublishAt   *int64         `json:"publish_at"`                             // unix seconds; set for scheduled articles
+	UnpublishAt *int64         `json:"unpublish_at"`                           // unix seconds; hidden (back to draft) from then on
 	CreatedAt   int64          `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt   int64          `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"` // set while the article is in the trash
+
+	// Resized renditions of PhotoHeader when it is a processed library image (widest = PhotoHeader)
+	PhotoHeaderSrcset datatypes.JSONSlice[ImageSource] `gorm:"type:jsonb;not null;default:'[]'" json:"photo_header_srcset"`
+
+	Categories []Category `gorm:"many2many:article_categories;" json:"categories,omitempty"`
+	Tags       []Tag      `gorm:"many2many:article_tags;" json:"tags,omitempty"`
+	Media      []Media    `gorm:"many2many:article_media;" json:"-"` // library items used in content or as header
+}
+
+// ArticleSlugRedirect keeps old slugs resolvable after an article is renamed.
+type ArticleSlugRedirect struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement" json:"id"`
+	OldSlug   string `gorm:"uniqueIndex;not null" json:"old_slug"`
+	ArticleID uint   `gorm:"not null;index" json:"article_id"`
+	CreatedAt int64  `gorm:"autoCreateTime" json:"created_at"`
 }
@@ -1,5 +1,7 @@
 package models
 
+import "gorm.io/gorm"
+
 type ContactStatus string
 
 const (
@@ -9,10 +11,11 @@ const (
 )
 
 type Contact struct {
-	ID        uint          `gorm:"primaryKey;autoIncrement" json:"id"`
-	Email     string        `gorm:"not null" json:"email"`
-	Subject   string        `gorm:"not null" json:"subject"`
-	Message   string        `gorm:"type:text;not null" json:"message"`
-	Status    ContactStatus `gorm:"type:text;not null;default:'new';check:status IN ('new','in_progress','done')" json:"status"`
-	CreatedAt int64         `gorm:"autoCreateTime" json:"created_at"`
+	ID        uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	Email     string         `gorm:"not null" json:"email"`
+	Subject   string         `gorm:"not null" json:"subject"`
+	Message   string         `gorm:"type:text;not null" json:"message"`
+	Status    ContactStatus  `gorm:"type:text;not null;default:'new';check:status IN ('new','in_progress','done')" json:"status"`
+	CreatedAt int64          `gorm:"autoCreateTime" json:"created_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"` // set while the contact is in the trash
 }
@@ -0,0 +1,48 @@
+package models
+
+import "gorm.io/datatypes"
+
+// Media is a file in the media library. Every upload (library, article header,
+// inline content) gets a row; articles link the items they use via article_media.
+type Media struct {
+	ID         uint   `gorm:"primaryKey;autoIncrement" json:"id"`
+	ObjectName string `gorm:"type:text;not null;uniqueIndex" json:"object_name"` // storage object, e.g. media/1734567890_foto.jpg
+	URL        string `gorm:"type:text;not null;index" json:"url"`
+	FileName   string `gorm:"type:text;not null;default:''" json:"file_name"` // original name as uploaded
+	MimeType   string `gorm:"not null" json:"mime_type"`
+	Size       int64  `gorm:"not null" json:"size"` // bytes
+	Width      *int   `json:"width"`                // images only
+	Height     *int   `json:"height"`
+	UploadedBy uint   `gorm:"not null" json:"uploaded_by"` // admin ID
+	CreatedAt  int64  `gorm:"autoCreateTime" json:"created_at"`
+
+	// Processed images: every resized rendition, narrowest first. The item itself
+	// (ObjectName, URL, size) is the widest one; the original upload is not kept.
+	Variants datatypes.JSONSlice[MediaVariant] `gorm:"type:jsonb;not null;default:'[]'" json:"variants"`
+}
+
+func (Media) TableName() string { return "media" }
+
+// ImageSource is one srcset entry: a rendition of an image and its size in pixels.
+type ImageSource struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// MediaVariant is a stored rendition of a processed image.
+type MediaVariant struct {
+	ImageSource
+	ObjectName string `json:"object_name"`
+	MimeType   string `json:"mime_type"`
+	Size       int64  `json:"size"`
+}
+
+// Srcset returns the variants as srcset entries (empty for unprocessed files).
+func (m Media) Srcset() []ImageSource {
+	out := make([]ImageSource, 0, len(m.Variants))
+	for _, v := range m.Variants {
+		out = append(out, v.ImageSource)
+	}
+	return out
+}
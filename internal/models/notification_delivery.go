@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending DeliveryStatus = "pending" // waiting for its (next) attempt
+	DeliverySent    DeliveryStatus = "sent"    // accepted by the gateway
+	DeliveryFailed  DeliveryStatus = "failed"  // gave up; admins can retry it
+)
+
+// NotificationDelivery is one WhatsApp/SMS message to one phone number, with
+// its send attempts.
+type NotificationDelivery struct {
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	Channel        string         `gorm:"not null" json:"channel"`
+	Event          string         `gorm:"not null" json:"event"` // template name, e.g. registration_received
+	RegistrationID *uint          `gorm:"index" json:"registration_id"`
+	Recipient      string         `gorm:"not null" json:"recipient"` // E.164, or as entered when it couldn't be normalized
+	Message        string         `gorm:"type:text;not null" json:"message"`
+	Status         DeliveryStatus `gorm:"type:text;not null;default:'pending';check:status IN ('pending','sent','failed')" json:"status"`
+	Attempts       int            `gorm:"not null;default:0" json:"attempts"`
+	LastError      string         `gorm:"type:text;not null;default:''" json:"last_error"`
+	NextAttemptAt  *time.Time     `gorm:"index" json:"next_attempt_at"` // nil once sent or failed
+	SentAt         *time.Time     `json:"sent_at"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+}
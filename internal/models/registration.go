@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type StudentType string
 
@@ -23,14 +27,72 @@ const (
 	RegistrationStatusValidate RegistrationStatus = "validate"
 	RegistrationStatusProcess  RegistrationStatus = "process"
 	RegistrationStatusDone     RegistrationStatus = "done"
+	// terminal: the registration ends here
+	RegistrationStatusRejected  RegistrationStatus = "rejected"
+	RegistrationStatusWithdrawn RegistrationStatus = "withdrawn"
 )
 
+// registrationTransitions lists the statuses each status can move to. Rejecting
+// and withdrawing are possible from every open status; nothing leaves them.
+var registrationTransitions = map[RegistrationStatus][]RegistrationStatus{
+	RegistrationStatusNew:      {RegistrationStatusValidate, RegistrationStatusRejected, RegistrationStatusWithdrawn},
+	RegistrationStatusValidate: {RegistrationStatusProcess, RegistrationStatusRejected, RegistrationStatusWithdrawn},
+	RegistrationStatusProcess:  {RegistrationStatusDone, RegistrationStatusRejected, RegistrationStatusWithdrawn},
+	RegistrationStatusDone:     {RegistrationStatusWithdrawn},
+}
+
+// Valid reports whether s is a known status.
+func (s RegistrationStatus) Valid() bool {
+	switch s {
+	case RegistrationStatusNew, RegistrationStatusValidate, RegistrationStatusProcess, RegistrationStatusDone,
+		RegistrationStatusRejected, RegistrationStatusWithdrawn:
+		return true
+	}
+	return false
+}
+
+// NextStatuses returns the statuses s can move to (none for terminal ones).
+func (s RegistrationStatus) NextStatuses() []RegistrationStatus {
+	return registrationTransitions[s]
+}
+
+// CanTransitionTo reports whether a registration in status s may move to next.
+func (s RegistrationStatus) CanTransitionTo(next RegistrationStatus) bool {
+	for _, allowed := range registrationTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTerminal reports whether s ends the registration (rejected, withdrawn).
+func (s RegistrationStatus) IsTerminal() bool {
+	return s == RegistrationStatusRejected || s == RegistrationStatusWithdrawn
+}
+
+// SeatStatuses lists the statuses counted against an admission period's quotas
+// (everything but rejected and withdrawn).
+var SeatStatuses = []RegistrationStatus{
+	RegistrationStatusNew,
+	RegistrationStatusValidate,
+	RegistrationStatusProcess,
+	RegistrationStatusDone,
+}
+
 type Registration struct {
 	ID uint `gorm:"primaryKey" json:"id"`
+	// ReferenceNumber is given to the applicant on create (e.g. "DA25-7K3M9Q"); with
+	// the date of birth it opens the public status lookup.
+	ReferenceNumber string `gorm:"not null;uniqueIndex" json:"reference_number"`
 
 	StudentType StudentType        `gorm:"type:text;check:student_type IN ('new','transfer');not null" json:"student_type"`
 	Gender      Gender             `gorm:"type:text;check:gender IN ('male','female');not null" json:"gender"`
-	Status      RegistrationStatus `gorm:"type:text;not null;default:'new';check:status IN ('new','validate','process','done')" json:"status"`
+	Status      RegistrationStatus `gorm:"type:text;not null;default:'new';check:status IN ('new','validate','process','done','rejected','withdrawn')" json:"status"`
+
+	// Admission period the registration was submitted in (nil for older registrations)
+	AdmissionPeriodID *uint            `gorm:"index" json:"admission_period_id"`
+	AdmissionPeriod   *AdmissionPeriod `gorm:"constraint:OnDelete:RESTRICT" json:"-"`
 
 	Email    string `gorm:"not null;uniqueIndex" json:"email"`
 	FullName string `gorm:"not null" json:"full_name"`
@@ -54,5 +116,13 @@ type Registration struct {
 	PhoneMother       string    `gorm:"not null" json:"phone_mother"`
 	DateOfBirthMother time.Time `gorm:"not null" json:"date_of_birth_mother"`
 
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"` // set while the registration is in the trash
+
+	// Document uploads (POST /registrations/documents) are authorized by the token
+	// handed out on create; only its SHA-256 is stored.
+	UploadTokenHash      string     `gorm:"type:text;not null;default:''" json:"-"`
+	UploadTokenExpiresAt *time.Time `json:"-"`
+
+	Documents []RegistrationDocument `gorm:"foreignKey:RegistrationID;constraint:OnDelete:CASCADE" json:"-"`
 }
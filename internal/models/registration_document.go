@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// RegistrationDocumentType is a document applicants upload for admission.
+type RegistrationDocumentType string
+
+const (
+	DocumentBirthCertificate RegistrationDocumentType = "akta_kelahiran"
+	DocumentFamilyCard       RegistrationDocumentType = "kartu_keluarga"
+	DocumentReportCard       RegistrationDocumentType = "rapor"
+	DocumentPhoto            RegistrationDocumentType = "pas_foto"
+)
+
+// RegistrationDocumentTypes lists every document type, in the order applicants are asked for them.
+var RegistrationDocumentTypes = []RegistrationDocumentType{
+	DocumentBirthCertificate,
+	DocumentFamilyCard,
+	DocumentReportCard,
+	DocumentPhoto,
+}
+
+type DocumentStatus string
+
+const (
+	DocumentPending  DocumentStatus = "pending"
+	DocumentVerified DocumentStatus = "verified"
+	DocumentRejected DocumentStatus = "rejected"
+)
+
+// RegistrationDocument is one uploaded document of a registration (one per type;
+// uploading again replaces it). The file lives in the private store.
+type RegistrationDocument struct {
+	ID             uint                     `gorm:"primaryKey" json:"id"`
+	RegistrationID uint                     `gorm:"not null;uniqueIndex:idx_registration_documents_type" json:"registration_id"`
+	Type           RegistrationDocumentType `gorm:"type:text;not null;uniqueIndex:idx_registration_documents_type;check:type IN ('akta_kelahiran','kartu_keluarga','rapor','pas_foto')" json:"type"`
+
+	ObjectName string `gorm:"not null" json:"-"`
+	FileName   string `gorm:"not null" json:"file_name"`
+	MimeType   string `gorm:"not null" json:"mime_type"`
+	Size       int64  `gorm:"not null" json:"size"` // bytes
+
+	Status       DocumentStatus `gorm:"type:text;not null;default:'pending';check:status IN ('pending','verified','rejected')" json:"status"`
+	RejectReason string         `gorm:"type:text;not null;default:''" json:"reject_reason"`
+	ReviewedBy   *uint          `json:"reviewed_by"` // admin ID
+	ReviewedAt   *time.Time     `json:"reviewed_at"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// RegistrationStatusHistory is one status change of a registration. The first
+// entry of every registration records its creation (FromStatus empty, no admin).
+type RegistrationStatusHistory struct {
+	ID             uint               `gorm:"primaryKey" json:"id"`
+	RegistrationID uint               `gorm:"not null;index" json:"registration_id"`
+	FromStatus     RegistrationStatus `gorm:"type:text;not null;default:''" json:"from_status"`
+	ToStatus       RegistrationStatus `gorm:"type:text;not null" json:"to_status"`
+	AdminID        *uint              `json:"admin_id"` // nil for changes not made by an admin
+	Note           string             `gorm:"type:text;not null;default:''" json:"note"`
+	CreatedAt      time.Time          `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (RegistrationStatusHistory) TableName() string { return "registration_status_history" }
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegistrationStatusTransitions(t *testing.T) {
+	const (
+		n = RegistrationStatusNew
+		v = RegistrationStatusValidate
+		p = RegistrationStatusProcess
+		d = RegistrationStatusDone
+		r = RegistrationStatusRejected
+		w = RegistrationStatusWithdrawn
+	)
+	all := []RegistrationStatus{n, v, p, d, r, w}
+	tests := []struct {
+		from RegistrationStatus
+		next []RegistrationStatus
+	}{
+		{n, []RegistrationStatus{v, r, w}},
+		{v, []RegistrationStatus{p, r, w}},
+		{p, []RegistrationStatus{d, r, w}},
+		{d, []RegistrationStatus{w}},
+		{r, nil},
+		{w, nil},
+		{"archived", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := tt.from.NextStatuses(); !reflect.DeepEqual(got, tt.next) {
+			t.Errorf("%q next: got %v, want %v", tt.from, got, tt.next)
+		}
+		for _, to := range all {
+			want := false
+			for _, allowed := range tt.next {
+				want = want || allowed == to
+			}
+			if got := tt.from.CanTransitionTo(to); got != want {
+				t.Errorf("%q -> %q: got %v, want %v", tt.from, to, got, want)
+			}
+		}
+		if tt.from.IsTerminal() != (tt.from == r || tt.from == w) {
+			t.Errorf("%q terminal: got %v", tt.from, tt.from.IsTerminal())
+		}
+		if tt.from.Valid() != (tt.from != "archived" && tt.from != "") {
+			t.Errorf("%q valid: got %v", tt.from, tt.from.Valid())
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package models
+
+// Category groups articles into site sections (Berita, Kegiatan, Pengumuman, ...).
+type Category struct {
+	ID          uint   `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name        string `gorm:"not null" json:"name"`
+	Slug        string `gorm:"uniqueIndex;not null" json:"slug"`
+	Description string `gorm:"type:text" json:"description"`
+	CreatedAt   int64  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   int64  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// Tag is a free-form label; an article can have many.
+type Tag struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name      string `gorm:"not null" json:"name"`
+	Slug      string `gorm:"uniqueIndex;not null" json:"slug"`
+	CreatedAt int64  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt int64  `gorm:"autoUpdateTime" json:"updated_at"`
+}
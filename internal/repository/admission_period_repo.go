@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"darulabror/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrAdmissionPeriodFull is returned by RegistrationRepo.Create when the
+// registration's admission period has no seat left for it.
+var ErrAdmissionPeriodFull = errors.New("admission period quota reached")
+
+type AdmissionPeriodRepo interface {
+	GetAll(academicYear string) ([]models.AdmissionPeriod, error)
+	GetByID(id uint) (models.AdmissionPeriod, error)
+	// GetOpenAt returns the period open at t; GetNextAfter the first one opening after t.
+	GetOpenAt(t time.Time) (models.AdmissionPeriod, error)
+	GetNextAfter(t time.Time) (models.AdmissionPeriod, error)
+	// Any reports whether at least one period has been set up.
+	Any() (bool, error)
+	Create(period *models.AdmissionPeriod) error
+	Update(period models.AdmissionPeriod) error
+	Delete(id uint) error
+	// Overlaps reports whether another period (not excludeID) shares time with [opensAt, closesAt).
+	Overlaps(opensAt, closesAt time.Time, excludeID uint) (bool, error)
+	CountSeats(periodID uint) (models.AdmissionSeats, error)
+	// CountRegistrations counts every registration of the period, trashed ones included.
+	CountRegistrations(periodID uint) (int64, error)
+}
+
+type admissionPeriodRepo struct {
+	db *gorm.DB
+}
+
+func NewAdmissionPeriodRepo(db *gorm.DB) AdmissionPeriodRepo {
+	return &admissionPeriodRepo{db: db}
+}
+
+func (r *admissionPeriodRepo) GetAll(academicYear string) ([]models.AdmissionPeriod, error) {
+	var periods []models.AdmissionPeriod
+	q := r.db.Model(&models.AdmissionPeriod{})
+	if academicYear != "" {
+		q = q.Where("academic_year = ?", academicYear)
+	}
+	err := q.Order("opens_at DESC").Find(&periods).Error
+	return periods, err
+}
+
+func (r *admissionPeriodRepo) GetByID(id uint) (models.AdmissionPeriod, error) {
+	var period models.AdmissionPeriod
+	err := r.db.First(&period, id).Error
+	return period, err
+}
+
+func (r *admissionPeriodRepo) GetOpenAt(t time.Time) (models.AdmissionPeriod, error) {
+	var period models.AdmissionPeriod
+	err := r.db.Where("opens_at <= ? AND closes_at > ?", t, t).Order("opens_at DESC").First(&period).Error
+	return period, err
+}
+
+func (r *admissionPeriodRepo) GetNextAfter(t time.Time) (models.AdmissionPeriod, error) {
+	var period models.AdmissionPeriod
+	err := r.db.Where("opens_at > ?", t).Order("opens_at ASC").First(&period).Error
+	return period, err
+}
+
+func (r *admissionPeriodRepo) Create(period *models.AdmissionPeriod) error {
+	return r.db.Create(period).Error
+}
+
+func (r *admissionPeriodRepo) Update(period models.AdmissionPeriod) error {
+	if period.ID == 0 {
+		return errors.New("admission period id is required")
+	}
+	result := r.db.Model(&models.AdmissionPeriod{}).Where("id = ?", period.ID).Updates(map[string]interface{}{
+		"academic_year":  period.AcademicYear,
+		"wave_name":      period.WaveName,
+		"opens_at":       period.OpensAt,
+		"closes_at":      period.ClosesAt,
+		"quota_male":     period.QuotaMale,
+		"quota_female":   period.QuotaFemale,
+		"quota_new":      period.QuotaNew,
+		"quota_transfer": period.QuotaTransfer,
+		"updated_at":     time.Now(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *admissionPeriodRepo) Delete(id uint) error {
+	result := r.db.Delete(&models.AdmissionPeriod{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *admissionPeriodRepo) Any() (bool, error) {
+	var count int64
+	err := r.db.Model(&models.AdmissionPeriod{}).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *admissionPeriodRepo) Overlaps(opensAt, closesAt time.Time, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.AdmissionPeriod{}).
+		Where("id <> ? AND opens_at < ? AND closes_at > ?", excludeID, closesAt, opensAt).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *admissionPeriodRepo) CountSeats(periodID uint) (models.AdmissionSeats, error) {
+	return countSeats(r.db, periodID)
+}
+
+func (r *admissionPeriodRepo) CountRegistrations(periodID uint) (int64, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&models.Registration{}).Where("admission_period_id = ?", periodID).Count(&count).Error
+	return count, err
+}
+
+// countSeats counts the registrations holding a seat in the period (trashed
+// ones don't), per gender and per student type.
+func countSeats(db *gorm.DB, periodID uint) (models.AdmissionSeats, error) {
+	var rows []struct {
+		Gender      models.Gender
+		StudentType models.StudentType
+		Count       int64
+	}
+	err := db.Model(&models.Registration{}).
+		Select("gender, student_type, COUNT(*) AS count").
+		Where("admission_period_id = ? AND status IN ?", periodID, models.SeatStatuses).
+		Group("gender, student_type").
+		Scan(&rows).Error
+	if err != nil {
+		return models.AdmissionSeats{}, err
+	}
+
+	var seats models.AdmissionSeats
+	for _, row := range rows {
+		switch row.Gender {
+		case models.Male:
+			seats.Male += row.Count
+		case models.Female:
+			seats.Female += row.Count
+		}
+		switch row.StudentType {
+		case models.StudentNew:
+			seats.New += row.Count
+		case models.StudentTransfer:
+			seats.Transfer += row.Count
+		}
+	}
+	return seats, nil
+}
@@ -3,17 +3,82 @@ package repository
 import (
 	"darulabror/internal/models"
 	"darulabror/internal/utils"
+	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ArticleFilter narrows article listings. Empty fields are ignored.
+type ArticleFilter struct {
+	Category string // category slug
+	Tag      string // tag slug
+	Query    string // full-text search (websearch syntax: "kata kunci", -exclude, OR)
+}
+
+// ArticleSearchResult is an article matched by full-text search.
+type ArticleSearchResult struct {
+	Article        models.Article
+	Rank           float64
+	TitleHighlight string
+	Snippet        string
+}
+
+// RevisionMeta describes the revision recorded alongside an article write.
+type RevisionMeta struct {
+	AdminID      uint // 0 = unknown
+	RestoredFrom *int // revision number, when restoring
+}
+
+// searchConfig is the Postgres text search configuration used for articles.search_vector.
+// Must match migrations/004_article_search.sql.
+const searchConfig = "indonesian"
+
 type ArticleRepo interface {
-	Create(article models.Article) error
-	GetAll(page, limit int) ([]models.Article, int64, error)
-	GetPublished(page, limit int) ([]models.Article, int64, error)
+	Create(article models.Article, meta RevisionMeta) error
+	GetAll(page, limit int, filter ArticleFilter) ([]models.Article, int64, error)
+	GetPublished(page, limit int, filter ArticleFilter) ([]models.Article, int64, error)
+	SearchPublished(page, limit int, filter ArticleFilter) ([]ArticleSearchResult, int64, error)
+	// Sitemap: published articles with only id, slug and updated_at, oldest first
+	CountPublished() (int64, error)
+	GetPublishedSlugs(offset, limit int) ([]models.Article, error)
 	GetByID(id uint) (models.Article, error)
+	GetBySlug(slug string) (models.Article, error)
 	Update(article models.Article) error
-	Delete(id uint) error
+	Delete(id uint) error // moves the article to the trash
+
+	// Trash
+	GetTrashed(page, limit int) ([]models.Article, int64, error)
+	Restore(id uint) error
+	Purge(id uint) error
+	PurgeTrashedBefore(cutoff time.Time) (int64, error)
+
+	// Slug management
+	SlugExists(slug string, excludeID uint) (bool, error)
+	GetSlugRedirect(oldSlug string) (models.ArticleSlugRedirect, error)
+
+	// UpdateWithRevision saves the article, records oldSlug as a redirect (if changed)
+	// and appends a revision, all in one transaction.
+	UpdateWithRevision(article models.Article, oldSlug string, meta RevisionMeta) error
+
+	// Revisions
+	GetRevisions(articleID uint, page, limit int) ([]models.ArticleRevision, int64, error)
+	GetRevision(articleID uint, revision int) (models.ArticleRevision, error)
+
+	// Preview links
+	CreatePreviewLink(link *models.ArticlePreviewLink) error
+	GetPreviewLinks(articleID uint) ([]models.ArticlePreviewLink, error)
+	GetPreviewLinkByTokenHash(tokenHash string) (models.ArticlePreviewLink, error)
+	RevokePreviewLink(articleID, linkID uint, now int64) error
+
+	// GetContentBatch returns id, photo_header(_srcset) and content of up to limit articles with
+	// id > afterID (trashed included), by id; for scanning every media reference.
+	GetContentBatch(afterID uint, limit int) ([]models.Article, error)
+
+	// Scheduling (now = unix seconds)
+	PublishDue(now int64) ([]models.Article, error)
+	UnpublishExpired(now int64) ([]models.Article, error)
 }
 
 type articleRepo struct {
@@ -24,11 +89,18 @@ func NewArticleRepo(db *gorm.DB) ArticleRepo {
 	return &articleRepo{db: db}
 }
 
-func (a *articleRepo) Create(article models.Article) error {
-	return a.db.Create(&article).Error
+// Create inserts the article, links its Categories/Tags/Media (which must already exist)
+// and records revision 1.
+func (a *articleRepo) Create(article models.Article, meta RevisionMeta) error {
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Categories.*", "Tags.*", "Media.*").Create(&article).Error; err != nil {
+			return err
+		}
+		return tx.Create(newRevision(article, 1, meta)).Error
+	})
 }
 
-func (a *articleRepo) GetAll(page, limit int) ([]models.Article, int64, error) {
+func (a *articleRepo) GetAll(page, limit int, filter ArticleFilter) ([]models.Article, int64, error) {
 	var (
 		articles []models.Article
 		total    int64
@@ -36,15 +108,17 @@ func (a *articleRepo) GetAll(page, limit int) ([]models.Article, int64, error) {
 
 	_, limit, offset := utils.NormalizePageLimit(page, limit)
 
-	if err := a.db.Model(&models.Article{}).Count(&total).Error; err != nil {
+	q := applyArticleFilter(a.db.Model(&models.Article{}), filter)
+
+	if err := q.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := a.db.Order("id DESC").Limit(limit).Offset(offset).Find(&articles).Error
+	err := orderArticles(withTaxonomy(q), filter, "articles.id DESC").Limit(limit).Offset(offset).Find(&articles).Error
 	return articles, total, err
 }
 
-func (a *articleRepo) GetPublished(page, limit int) ([]models.Article, int64, error) {
+func (a *articleRepo) GetPublished(page, limit int, filter ArticleFilter) ([]models.Article, int64, error) {
 	var (
 		articles []models.Article
 		total    int64
@@ -52,26 +126,400 @@ func (a *articleRepo) GetPublished(page, limit int) ([]models.Article, int64, er
 
 	_, limit, offset := utils.NormalizePageLimit(page, limit)
 
-	q := a.db.Model(&models.Article{}).Where("status = ?", "published")
+	q := applyArticleFilter(published(a.db.Model(&models.Article{})), filter)
 
 	if err := q.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := q.Order("id DESC").Limit(limit).Offset(offset).Find(&articles).Error
+	err := orderArticles(withTaxonomy(q), filter, publishedTime+" DESC, articles.id DESC").Limit(limit).Offset(offset).Find(&articles).Error
 	return articles, total, err
 }
 
+// SearchPublished ranks published articles against filter.Query (title weighted above body)
+// and returns highlighted title/snippet with matches wrapped in <mark>...</mark>.
+func (a *articleRepo) SearchPublished(page, limit int, filter ArticleFilter) ([]ArticleSearchResult, int64, error) {
+	var total int64
+
+	_, limit, offset := utils.NormalizePageLimit(page, limit)
+
+	q := applyArticleFilter(published(a.db.Model(&models.Article{})), filter)
+
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []ArticleSearchResult{}, 0, nil
+	}
+
+	// rank + paginate first, then compute ts_headline only for the page (it's the expensive part)
+	type hit struct {
+		ID             uint
+		Rank           float64
+		TitleHighlight string
+		Snippet        string
+	}
+	var hits []hit
+
+	pageQuery := q.Session(&gorm.Session{}).
+		Select("articles.id, articles.title, articles.content, ts_rank_cd(articles.search_vector, websearch_to_tsquery(?, ?)) AS rank",
+			searchConfig, filter.Query).
+		Order("rank DESC").Order("articles.id DESC").
+		Limit(limit).Offset(offset)
+
+	err := a.db.Table("(?) AS hits", pageQuery).
+		Select(`hits.id, hits.rank,
+			ts_headline(?, hits.title, websearch_to_tsquery(?, ?), 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS title_highlight,
+			ts_headline(?, article_content_text(hits.content), websearch_to_tsquery(?, ?),
+				'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" … "') AS snippet`,
+			searchConfig, searchConfig, filter.Query,
+			searchConfig, searchConfig, filter.Query).
+		Order("hits.rank DESC").Order("hits.id DESC").
+		Scan(&hits).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]uint, 0, len(hits))
+	for _, h := range hits {
+		ids = append(ids, h.ID)
+	}
+
+	var articles []models.Article
+	if err := withTaxonomy(a.db).Where("id IN ?", ids).Find(&articles).Error; err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[uint]models.Article, len(articles))
+	for _, art := range articles {
+		byID[art.ID] = art
+	}
+
+	out := make([]ArticleSearchResult, 0, len(hits))
+	for _, h := range hits {
+		art, ok := byID[h.ID]
+		if !ok {
+			continue // deleted between queries
+		}
+		out = append(out, ArticleSearchResult{
+			Article:        art,
+			Rank:           h.Rank,
+			TitleHighlight: h.TitleHighlight,
+			Snippet:        h.Snippet,
+		})
+	}
+	return out, total, nil
+}
+
 func (a *articleRepo) GetByID(id uint) (models.Article, error) {
 	var article models.Article
-	err := a.db.First(&article, id).Error
+	err := withTaxonomy(a.db).First(&article, id).Error
+	return article, err
+}
+
+func (a *articleRepo) GetBySlug(slug string) (models.Article, error) {
+	var article models.Article
+	err := withTaxonomy(a.db).Where("slug = ?", slug).First(&article).Error
 	return article, err
 }
 
+// Update saves the article and replaces its category/tag/media links with article.Categories/Tags/Media.
 func (a *articleRepo) Update(article models.Article) error {
-	return a.db.Save(&article).Error
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		return saveArticle(tx, &article)
+	})
 }
 
 func (a *articleRepo) Delete(id uint) error {
 	return a.db.Delete(&models.Article{}, id).Error
 }
+
+func (a *articleRepo) GetTrashed(page, limit int) ([]models.Article, int64, error) {
+	return listTrashed[models.Article](a.db, page, limit)
+}
+
+func (a *articleRepo) Restore(id uint) error {
+	return restoreTrashed(a.db, &models.Article{}, id)
+}
+
+// Purge permanently deletes a trashed article; revisions, redirects and
+// category/tag links go with it (ON DELETE CASCADE).
+func (a *articleRepo) Purge(id uint) error {
+	return purgeTrashed(a.db, &models.Article{}, id)
+}
+
+func (a *articleRepo) PurgeTrashedBefore(cutoff time.Time) (int64, error) {
+	return purgeTrashedBefore(a.db, &models.Article{}, cutoff)
+}
+
+// SlugExists reports whether slug is taken by another article, either as its
+// current slug or as a redirect. Redirects owned by excludeID don't count, so an
+// article can take back one of its own old slugs. Trashed articles keep their
+// slug, so they can be restored without a conflict.
+func (a *articleRepo) SlugExists(slug string, excludeID uint) (bool, error) {
+	var count int64
+	if err := a.db.Unscoped().Model(&models.Article{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	err := a.db.Model(&models.ArticleSlugRedirect{}).
+		Where("old_slug = ? AND article_id <> ?", slug, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (a *articleRepo) GetSlugRedirect(oldSlug string) (models.ArticleSlugRedirect, error) {
+	var redirect models.ArticleSlugRedirect
+	err := a.db.Where("old_slug = ?", oldSlug).First(&redirect).Error
+	return redirect, err
+}
+
+func (a *articleRepo) UpdateWithRevision(article models.Article, oldSlug string, meta RevisionMeta) error {
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		// lock the row: serializes concurrent edits so revision numbers don't collide
+		var current models.Article
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, article.ID).Error; err != nil {
+			return err
+		}
+
+		var last int
+		if err := tx.Model(&models.ArticleRevision{}).
+			Where("article_id = ?", article.ID).
+			Select("COALESCE(MAX(revision), 0)").
+			Scan(&last).Error; err != nil {
+			return err
+		}
+
+		// article predates revision history: keep what's being overwritten as revision 1
+		if last == 0 {
+			last = 1
+			if err := tx.Create(newRevision(current, last, RevisionMeta{})).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := saveArticle(tx, &article); err != nil {
+			return err
+		}
+		if err := tx.Create(newRevision(article, last+1, meta)).Error; err != nil {
+			return err
+		}
+
+		// new slug may be one of this article's old slugs: it's live again, drop the redirect
+		if err := tx.Where("old_slug = ?", article.Slug).Delete(&models.ArticleSlugRedirect{}).Error; err != nil {
+			return err
+		}
+
+		if oldSlug == "" || oldSlug == article.Slug {
+			return nil
+		}
+		return tx.Create(&models.ArticleSlugRedirect{
+			OldSlug:   oldSlug,
+			ArticleID: article.ID,
+		}).Error
+	})
+}
+
+// GetRevisions lists revisions newest first, without content.
+func (a *articleRepo) GetRevisions(articleID uint, page, limit int) ([]models.ArticleRevision, int64, error) {
+	var (
+		revisions []models.ArticleRevision
+		total     int64
+	)
+
+	_, limit, offset := utils.NormalizePageLimit(page, limit)
+
+	q := a.db.Model(&models.ArticleRevision{}).Where("article_id = ?", articleID)
+
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := q.Omit("content").Order("revision DESC").Limit(limit).Offset(offset).Find(&revisions).Error
+	return revisions, total, err
+}
+
+func (a *articleRepo) GetRevision(articleID uint, revision int) (models.ArticleRevision, error) {
+	var rev models.ArticleRevision
+	err := a.db.Where("article_id = ? AND revision = ?", articleID, revision).First(&rev).Error
+	return rev, err
+}
+
+func newRevision(article models.Article, number int, meta RevisionMeta) *models.ArticleRevision {
+	rev := &models.ArticleRevision{
+		ArticleID:    article.ID,
+		Revision:     number,
+		Title:        article.Title,
+		Slug:         article.Slug,
+		PhotoHeader:  article.PhotoHeader,
+		Content:      article.Content,
+		Author:       article.Author,
+		Status:       article.Status,
+		RestoredFrom: meta.RestoredFrom,
+	}
+	if meta.AdminID != 0 {
+		adminID := meta.AdminID
+		rev.AdminID = &adminID
+	}
+	return rev
+}
+
+func (a *articleRepo) CreatePreviewLink(link *models.ArticlePreviewLink) error {
+	return a.db.Create(link).Error
+}
+
+// GetPreviewLinks lists an article's preview links, newest first (revoked/expired included).
+func (a *articleRepo) GetPreviewLinks(articleID uint) ([]models.ArticlePreviewLink, error) {
+	var links []models.ArticlePreviewLink
+	err := a.db.Where("article_id = ?", articleID).Order("id DESC").Find(&links).Error
+	return links, err
+}
+
+func (a *articleRepo) GetPreviewLinkByTokenHash(tokenHash string) (models.ArticlePreviewLink, error) {
+	var link models.ArticlePreviewLink
+	err := a.db.Where("token_hash = ?", tokenHash).First(&link).Error
+	return link, err
+}
+
+// RevokePreviewLink marks the link revoked; revoking twice keeps the first time.
+func (a *articleRepo) RevokePreviewLink(articleID, linkID uint, now int64) error {
+	result := a.db.Model(&models.ArticlePreviewLink{}).
+		Where("id = ? AND article_id = ?", linkID, articleID).
+		Update("revoked_at", gorm.Expr("COALESCE(revoked_at, ?)", now))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (a *articleRepo) CountPublished() (int64, error) {
+	var total int64
+	err := published(a.db.Model(&models.Article{})).Count(&total).Error
+	return total, err
+}
+
+func (a *articleRepo) GetPublishedSlugs(offset, limit int) ([]models.Article, error) {
+	var articles []models.Article
+	err := published(a.db.Model(&models.Article{})).
+		Select("id", "slug", "updated_at").
+		Order("id ASC").
+		Offset(offset).Limit(limit).
+		Find(&articles).Error
+	return articles, err
+}
+
+// PublishDue flips scheduled articles whose publish_at has passed to published
+// and returns them (id, title, slug).
+func (a *articleRepo) PublishDue(now int64) ([]models.Article, error) {
+	var articles []models.Article
+	err := a.db.Model(&articles).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "title"}, {Name: "slug"}}}).
+		Where("status = ? AND publish_at <= ?", models.ArticleStatusScheduled, now).
+		Update("status", models.ArticleStatusPublished).Error
+	return articles, err
+}
+
+// UnpublishExpired moves visible articles whose unpublish_at has passed back to draft
+// (clearing unpublish_at so a later re-publish isn't hidden immediately) and returns them.
+func (a *articleRepo) UnpublishExpired(now int64) ([]models.Article, error) {
+	var articles []models.Article
+	err := a.db.Model(&articles).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "title"}, {Name: "slug"}}}).
+		Where("status IN ? AND unpublish_at <= ?",
+			[]string{models.ArticleStatusPublished, models.ArticleStatusScheduled}, now).
+		Updates(map[string]interface{}{
+			"status":       models.ArticleStatusDraft,
+			"unpublish_at": nil,
+		}).Error
+	return articles, err
+}
+
+func (a *articleRepo) GetContentBatch(afterID uint, limit int) ([]models.Article, error) {
+	var articles []models.Article
+	err := a.db.Unscoped().
+		Select("id", "photo_header", "photo_header_srcset", "content").
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&articles).Error
+	return articles, err
+}
+
+func saveArticle(tx *gorm.DB, article *models.Article) error {
+	if err := tx.Omit(clause.Associations).Save(article).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(article).Omit("Categories.*").Association("Categories").Replace(article.Categories); err != nil {
+		return err
+	}
+	if err := tx.Model(article).Omit("Tags.*").Association("Tags").Replace(article.Tags); err != nil {
+		return err
+	}
+	return tx.Model(article).Omit("Media.*").Association("Media").Replace(article.Media)
+}
+
+// published limits q to articles visible to the public.
+func published(q *gorm.DB) *gorm.DB {
+	now := time.Now().Unix()
+	return q.Where(publishedCondition("articles"), now, now)
+}
+
+// publishedCondition is the SQL for "publicly visible now" on the given table alias.
+// Takes two args: now, now (unix seconds). Due scheduled articles count as published even
+// before the scheduler flips their status; expired ones are hidden before it unpublishes them.
+func publishedCondition(alias string) string {
+	return fmt.Sprintf(`(%[1]s.status = '%[2]s' OR (%[1]s.status = '%[3]s' AND %[1]s.publish_at <= ?))
+		AND (%[1]s.unpublish_at IS NULL OR %[1]s.unpublish_at > ?)`,
+		alias, models.ArticleStatusPublished, models.ArticleStatusScheduled)
+}
+
+func withTaxonomy(q *gorm.DB) *gorm.DB {
+	return q.Preload("Categories", func(db *gorm.DB) *gorm.DB {
+		return db.Order("categories.name ASC")
+	}).Preload("Tags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("tags.name ASC")
+	})
+}
+
+func applyArticleFilter(q *gorm.DB, filter ArticleFilter) *gorm.DB {
+	if filter.Category != "" {
+		q = q.Where(`EXISTS (
+			SELECT 1 FROM article_categories ac
+			JOIN categories c ON c.id = ac.category_id
+			WHERE ac.article_id = articles.id AND c.slug = ?)`, filter.Category)
+	}
+	if filter.Tag != "" {
+		q = q.Where(`EXISTS (
+			SELECT 1 FROM article_tags atg
+			JOIN tags t ON t.id = atg.tag_id
+			WHERE atg.article_id = articles.id AND t.slug = ?)`, filter.Tag)
+	}
+	if filter.Query != "" {
+		q = q.Where("articles.search_vector @@ websearch_to_tsquery(?, ?)", searchConfig, filter.Query)
+	}
+	return q
+}
+
+// publishedTime is when an article went public: publish_at once it was
+// scheduled, else its creation. An old draft scheduled today sorts as today's.
+const publishedTime = "COALESCE(articles.publish_at, articles.created_at)"
+
+// orderArticles sorts by newest (an ORDER BY list), or by relevance when searching.
+// (single ORDER BY expression: gorm drops an Expr-based OrderBy when another Order() is merged)
+func orderArticles(q *gorm.DB, filter ArticleFilter, newest string) *gorm.DB {
+	if filter.Query == "" {
+		return q.Order(newest)
+	}
+	return q.Clauses(clause.OrderBy{Expression: clause.Expr{
+		SQL:                "ts_rank_cd(articles.search_vector, websearch_to_tsquery(?, ?)) DESC, articles.id DESC",
+		Vars:               []interface{}{searchConfig, filter.Query},
+		WithoutParentheses: true,
+	}})
+}
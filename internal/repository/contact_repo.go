@@ -3,6 +3,7 @@ package repository
 import (
 	"darulabror/internal/models"
 	"darulabror/internal/utils"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -15,7 +16,15 @@ type ContactRepository interface {
 	GetContactByID(id uint) (*models.Contact, error)
 	UpdateContact(id uint, email, subject, message string) error
 	UpdateContactStatus(id uint, status models.ContactStatus) error
-	DeleteContact(id uint) error
+	// GetCreatedBetween returns the first limit contacts created in [from, to)
+	// and how many there are.
+	GetCreatedBetween(from, to time.Time, limit int) ([]models.Contact, int64, error)
+	DeleteContact(id uint) error // moves the contact to the trash
+	// Trash
+	GetTrashedContacts(page, limit int) ([]models.Contact, int64, error)
+	RestoreContact(id uint) error
+	PurgeContact(id uint) error
+	PurgeTrashedContactsBefore(cutoff time.Time) (int64, error)
 }
 
 type contactRepository struct {
@@ -35,6 +44,22 @@ func (r *contactRepository) CreateContact(email, subject, message string) error
 	}).Error
 }
 
+func (r *contactRepository) GetCreatedBetween(from, to time.Time, limit int) ([]models.Contact, int64, error) {
+	var (
+		contacts []models.Contact
+		total    int64
+	)
+
+	// created_at is unix seconds
+	query := r.db.Model(&models.Contact{}).Where("created_at >= ? AND created_at < ?", from.Unix(), to.Unix())
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("id ASC").Limit(limit).Find(&contacts).Error
+	return contacts, total, err
+}
+
 func (r *contactRepository) GetAllContacts(page, limit int, status string) ([]models.Contact, int64, error) {
 	var (
 		contacts []models.Contact
@@ -80,6 +105,22 @@ func (r *contactRepository) DeleteContact(id uint) error {
 	return r.db.Delete(&models.Contact{}, id).Error
 }
 
+func (r *contactRepository) GetTrashedContacts(page, limit int) ([]models.Contact, int64, error) {
+	return listTrashed[models.Contact](r.db, page, limit)
+}
+
+func (r *contactRepository) RestoreContact(id uint) error {
+	return restoreTrashed(r.db, &models.Contact{}, id)
+}
+
+func (r *contactRepository) PurgeContact(id uint) error {
+	return purgeTrashed(r.db, &models.Contact{}, id)
+}
+
+func (r *contactRepository) PurgeTrashedContactsBefore(cutoff time.Time) (int64, error) {
+	return purgeTrashedBefore(r.db, &models.Contact{}, cutoff)
+}
+
 func (r *contactRepository) UpdateContactStatus(id uint, status models.ContactStatus) error {
 	result := r.db.Model(&models.Contact{}).Where("id = ?", id).Update("status", status)
 	if result.Error != nil {
@@ -2,32 +2,54 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"time"
 
 	"cloud.google.com/go/storage"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/api/iterator"
 )
 
-var ErrStorageNotConfigured = errors.New("gcs storage is not configured")
+// GCSSigner is the service account used to sign URLs of private objects. Without
+// one the client's own credentials are used, which falls back to the IAM
+// SignBlob API through the metadata server on Cloud Run.
+type GCSSigner struct {
+	AccessID   string // service account email
+	PrivateKey []byte // PEM
+}
 
-type GCPStorageRepo interface {
-	UploadFile(ctx context.Context, file io.Reader, objectName string) (string, error)
-	GenerateSignedURL(ctx context.Context, objectName string, expire time.Duration) (string, error)
+// ParseGCSServiceAccountKey reads a signer from a service account JSON key file.
+func ParseGCSServiceAccountKey(data []byte) (*GCSSigner, error) {
+	var key struct {
+		ClientEmail string `json:"client_email"`
+		PrivateKey  string `json:"private_key"`
+	}
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, err
+	}
+	if key.ClientEmail == "" || key.PrivateKey == "" {
+		return nil, errors.New("service account key needs client_email and private_key")
+	}
+	return &GCSSigner{AccessID: key.ClientEmail, PrivateKey: []byte(key.PrivateKey)}, nil
 }
 
 type gcpStorageRepo struct {
 	client     *storage.Client
 	bucketName string
 	isPublic   bool
+	signer     *GCSSigner
 }
 
-func NewGCPStorageRepo(client *storage.Client, bucketName string, isPublic bool) GCPStorageRepo {
+// NewGCPStorageRepo stores objects in a GCS bucket. signer is only used for
+// private buckets and may be nil.
+func NewGCPStorageRepo(client *storage.Client, bucketName string, isPublic bool, signer *GCSSigner) StorageRepo {
 	return &gcpStorageRepo{
 		client:     client,
 		bucketName: bucketName,
 		isPublic:   isPublic,
+		signer:     signer,
 	}
 }
 
@@ -69,7 +91,7 @@ func (r *gcpStorageRepo) UploadFile(ctx context.Context, file io.Reader, objectN
 
 	// PUBLIC bucket → return URL
 	if r.isPublic {
-		url := "https://storage.googleapis.com/" + r.bucketName + "/" + objectName
+		url := r.fileURL(objectName)
 		logrus.WithFields(logrus.Fields{
 			"bucket": r.bucketName,
 			"object": objectName,
@@ -88,21 +110,24 @@ func (r *gcpStorageRepo) UploadFile(ctx context.Context, file io.Reader, objectN
 
 // GenerateSignedURL — generate signed URL for private objects
 func (r *gcpStorageRepo) GenerateSignedURL(ctx context.Context, objectName string, expire time.Duration) (string, error) {
-	_ = ctx // reserved (kalau nanti mau pakai IAM SignBlob / per-request trace)
-
 	if err := r.validate(); err != nil {
 		return "", err
 	}
 
 	if r.isPublic {
-		return "https://storage.googleapis.com/" + r.bucketName + "/" + objectName, nil
+		return r.fileURL(objectName), nil
 	}
 
-	// NOTE: SignedURL butuh konfigurasi credentials signing (GoogleAccessID/PrivateKey atau IAM SignBlob).
-	url, err := storage.SignedURL(r.bucketName, objectName, &storage.SignedURLOptions{
+	opts := &storage.SignedURLOptions{
+		Scheme:  storage.SigningSchemeV4,
 		Method:  "GET",
 		Expires: time.Now().Add(expire),
-	})
+	}
+	if r.signer != nil {
+		opts.GoogleAccessID = r.signer.AccessID
+		opts.PrivateKey = r.signer.PrivateKey
+	}
+	url, err := r.client.Bucket(r.bucketName).SignedURL(objectName, opts)
 	if err != nil {
 		logrus.WithError(err).WithFields(logrus.Fields{
 			"bucket": r.bucketName,
@@ -114,6 +139,69 @@ func (r *gcpStorageRepo) GenerateSignedURL(ctx context.Context, objectName strin
 	return url, nil
 }
 
+// DeleteFile — remove an object; deleting a missing object is not an error
+func (r *gcpStorageRepo) DeleteFile(ctx context.Context, objectName string) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	err := r.client.Bucket(r.bucketName).Object(objectName).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"bucket": r.bucketName,
+			"object": objectName,
+		}).Error("gcs delete failed")
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"bucket": r.bucketName,
+		"object": objectName,
+	}).Info("file deleted from gcs")
+	return nil
+}
+
+// ListFiles — list every object whose name starts with prefix
+func (r *gcpStorageRepo) ListFiles(ctx context.Context, prefix string) ([]StorageObject, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+
+	objects := make([]StorageObject, 0)
+	it := r.client.Bucket(r.bucketName).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{
+				"bucket": r.bucketName,
+				"prefix": prefix,
+			}).Error("gcs list failed")
+			return nil, err
+		}
+		objects = append(objects, StorageObject{
+			Name:    attrs.Name,
+			URL:     r.fileURL(attrs.Name),
+			Size:    attrs.Size,
+			Updated: attrs.Updated,
+		})
+	}
+	return objects, nil
+}
+
+// fileURL is the public URL of an object, or just its name for private buckets.
+func (r *gcpStorageRepo) fileURL(objectName string) string {
+	if !r.isPublic {
+		return objectName
+	}
+	return "https://storage.googleapis.com/" + r.bucketName + "/" + objectName
+}
+
 // NOTE:
 // - Kalau bucket public: GenerateSignedURL() cuma return public URL (signed URL tidak diperlukan).
-// - Mode private belum dipakai sekarang, tapi disiapkan untuk kebutuhan future (restricted media).
+// - Bucket private (PRIVATE_BUCKET) dipakai untuk dokumen sensitif; akses hanya lewat signed URL.
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrSignedURLNotSupported is returned when a store can't hand out links to its
+	// private files.
+	ErrSignedURLNotSupported = errors.New("signed urls are not supported by this storage")
+	ErrInvalidSignedURL      = errors.New("invalid or expired signed url")
+	ErrInvalidObjectName     = errors.New("invalid object name")
+)
+
+// SignedFileResolver is implemented by stores that serve their own signed URLs
+// (the local disk): it checks a link made by GenerateSignedURL and returns the
+// file to send.
+type SignedFileResolver interface {
+	ResolveSignedURL(objectName string, expires int64, signature string, now time.Time) (string, error)
+}
+
+type localStorageRepo struct {
+	root       string // directory holding the objects
+	baseURL    string // where root is served, e.g. "/uploads"
+	isPublic   bool
+	signingKey []byte // HMAC key of private links
+}
+
+// NewLocalStorageRepo stores objects as files under root. Public stores return
+// baseURL + "/" + objectName, so root must be served there (echo's Static).
+// Private stores sign links to baseURL + "/" + objectName with signingKey; the
+// route serving them checks the link with ResolveSignedURL.
+func NewLocalStorageRepo(root, baseURL string, isPublic bool, signingKey []byte) StorageRepo {
+	return &localStorageRepo{
+		root:       root,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		isPublic:   isPublic,
+		signingKey: signingKey,
+	}
+}
+
+// path maps an object name to its file, refusing names that leave root.
+func (r *localStorageRepo) path(objectName string) (string, error) {
+	if r.root == "" {
+		return "", ErrStorageNotConfigured
+	}
+	clean := path.Clean("/" + objectName)[1:]
+	if clean == "" || clean != objectName {
+		return "", ErrInvalidObjectName
+	}
+	return filepath.Join(r.root, filepath.FromSlash(clean)), nil
+}
+
+// UploadFile — write the file (via a temp file, so readers never see half of it)
+func (r *localStorageRepo) UploadFile(ctx context.Context, file io.Reader, objectName string) (string, error) {
+	dst, err := r.path(objectName)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name()) // no-op after the rename
+
+	if _, err := io.Copy(tmp, file); err != nil {
+		_ = tmp.Close()
+		logrus.WithError(err).WithField("object", objectName).Error("local upload failed")
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		logrus.WithError(err).WithField("object", objectName).Error("local upload failed")
+		return "", err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"root":   r.root,
+		"object": objectName,
+	}).Info("file stored on local disk")
+	return r.fileURL(objectName), nil
+}
+
+// GenerateSignedURL — public stores return the file URL; private ones an
+// HMAC-signed link served by the API
+func (r *localStorageRepo) GenerateSignedURL(ctx context.Context, objectName string, expire time.Duration) (string, error) {
+	if _, err := r.path(objectName); err != nil {
+		return "", err
+	}
+	if r.isPublic {
+		return r.fileURL(objectName), nil
+	}
+	if len(r.signingKey) == 0 {
+		return "", ErrSignedURLNotSupported
+	}
+
+	expires := time.Now().Add(expire).Unix()
+	query := url.Values{
+		"expires":   {strconv.FormatInt(expires, 10)},
+		"signature": {r.sign(objectName, expires)},
+	}
+	return r.baseURL + "/" + escapeObjectName(objectName) + "?" + query.Encode(), nil
+}
+
+func (r *localStorageRepo) ResolveSignedURL(objectName string, expires int64, signature string, now time.Time) (string, error) {
+	file, err := r.path(objectName)
+	if err != nil {
+		return "", err
+	}
+	if r.isPublic || len(r.signingKey) == 0 {
+		return "", ErrSignedURLNotSupported
+	}
+	if now.Unix() > expires || !hmac.Equal([]byte(signature), []byte(r.sign(objectName, expires))) {
+		return "", ErrInvalidSignedURL
+	}
+	return file, nil
+}
+
+func (r *localStorageRepo) sign(objectName string, expires int64) string {
+	mac := hmac.New(sha256.New, r.signingKey)
+	mac.Write([]byte(objectName + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// escapeObjectName escapes each path segment of an object name for use in a URL.
+func escapeObjectName(objectName string) string {
+	segments := strings.Split(objectName, "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+	return strings.Join(segments, "/")
+}
+
+// DeleteFile — remove a file; deleting a missing file is not an error
+func (r *localStorageRepo) DeleteFile(ctx context.Context, objectName string) error {
+	file, err := r.path(objectName)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		logrus.WithError(err).WithField("object", objectName).Error("local delete failed")
+		return err
+	}
+	return nil
+}
+
+// ListFiles — list every file whose object name starts with prefix
+func (r *localStorageRepo) ListFiles(ctx context.Context, prefix string) ([]StorageObject, error) {
+	if r.root == "" {
+		return nil, ErrStorageNotConfigured
+	}
+
+	// only walk the directory the prefix points into
+	dir := r.root
+	if i := strings.LastIndex(prefix, "/"); i > 0 {
+		dir = filepath.Join(r.root, filepath.FromSlash(path.Clean("/"+prefix[:i])))
+	}
+
+	objects := make([]StorageObject, 0)
+	err := filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+		rel, err := filepath.Rel(r.root, file)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if !strings.HasPrefix(name, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, StorageObject{
+			Name:    name,
+			URL:     r.fileURL(name),
+			Size:    info.Size(),
+			Updated: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"root":   r.root,
+			"prefix": prefix,
+		}).Error("local list failed")
+		return nil, err
+	}
+	return objects, nil
+}
+
+// fileURL is the public URL of an object, or just its name for private stores.
+func (r *localStorageRepo) fileURL(objectName string) string {
+	if !r.isPublic {
+		return objectName
+	}
+	return r.baseURL + "/" + objectName
+}
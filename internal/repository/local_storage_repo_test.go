@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocalStoragePath(t *testing.T) {
+	root := t.TempDir()
+	store := NewLocalStorageRepo(root, "/uploads", true, nil).(*localStorageRepo)
+
+	tests := []struct {
+		name string
+		want string // file under root; "" when refused
+	}{
+		{"articles/header/1_foto.jpg", "articles/header/1_foto.jpg"},
+		{"registrations/12/kk scan.pdf", "registrations/12/kk scan.pdf"},
+		{"../etc/passwd", ""},
+		{"articles/../../etc/passwd", ""},
+		{"articles/../media/a.jpg", ""}, // stays inside root but isn't clean
+		{"/etc/passwd", ""},
+		{"articles//a.jpg", ""},
+		{"articles/./a.jpg", ""},
+		{"articles/", ""},
+		{".", ""},
+		{"..", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got, err := store.path(tt.name)
+		if tt.want == "" {
+			if !errors.Is(err, ErrInvalidObjectName) {
+				t.Errorf("%q: got %q, %v; want ErrInvalidObjectName", tt.name, got, err)
+			}
+			continue
+		}
+		if err != nil || got != filepath.Join(root, filepath.FromSlash(tt.want)) {
+			t.Errorf("%q: got %q, %v", tt.name, got, err)
+		}
+	}
+
+	unconfigured := NewLocalStorageRepo("", "/uploads", true, nil).(*localStorageRepo)
+	if _, err := unconfigured.path("articles/a.jpg"); !errors.Is(err, ErrStorageNotConfigured) {
+		t.Errorf("no root: got %v", err)
+	}
+}
+
+func TestLocalStorageFiles(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store := NewLocalStorageRepo(root, "/uploads/", true, nil)
+
+	for _, name := range []string{"articles/header/1_a.jpg", "articles/content/2_b.png", "articles/header_3_c.jpg", "media/4_d.jpg"} {
+		url, err := store.UploadFile(ctx, strings.NewReader(name), name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if url != "/uploads/"+name {
+			t.Errorf("%s: url %q", name, url)
+		}
+	}
+	if _, err := store.UploadFile(ctx, strings.NewReader("x"), "../outside.txt"); !errors.Is(err, ErrInvalidObjectName) {
+		t.Errorf("upload outside root: got %v", err)
+	}
+	// a half-written upload is never listed
+	if err := os.WriteFile(filepath.Join(root, "articles", "header", ".upload-123"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	list := func(prefix string) []string {
+		t.Helper()
+		objects, err := store.ListFiles(ctx, prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names := make([]string, 0, len(objects))
+		for _, obj := range objects {
+			if obj.URL != "/uploads/"+obj.Name || obj.Size != int64(len(obj.Name)) || obj.Updated.IsZero() {
+				t.Errorf("%s: listed as %+v", obj.Name, obj)
+			}
+			names = append(names, obj.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"articles/", []string{"articles/content/2_b.png", "articles/header/1_a.jpg", "articles/header_3_c.jpg"}},
+		{"articles/header", []string{"articles/header/1_a.jpg", "articles/header_3_c.jpg"}},
+		{"articles/header/", []string{"articles/header/1_a.jpg"}},
+		{"", []string{"articles/content/2_b.png", "articles/header/1_a.jpg", "articles/header_3_c.jpg", "media/4_d.jpg"}},
+		{"registrations/", []string{}},
+	}
+	for _, tt := range tests {
+		if got := list(tt.prefix); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("prefix %q: got %q, want %q", tt.prefix, got, tt.want)
+		}
+	}
+
+	if err := store.DeleteFile(ctx, "articles/header/1_a.jpg"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.DeleteFile(ctx, "articles/header/1_a.jpg"); err != nil {
+		t.Errorf("deleting a missing file: %v", err)
+	}
+	if got := list("articles/header/"); len(got) != 0 {
+		t.Errorf("after delete: %q", got)
+	}
+}
+
+func TestLocalStoragePrivateURLs(t *testing.T) {
+	store := NewLocalStorageRepo(t.TempDir(), "/files", false, []byte("key"))
+	url, err := store.UploadFile(context.Background(), strings.NewReader("x"), "registrations/1/kk.pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if url != "registrations/1/kk.pdf" {
+		t.Errorf("private upload returned %q, want the object name", url)
+	}
+}
+
+func TestLocalStorageSignedURL(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store := NewLocalStorageRepo(root, "/files/", false, []byte("signing-key"))
+	resolver := store.(SignedFileResolver)
+
+	const object = "registrations/12/kk scan.pdf"
+	raw, err := store.GenerateSignedURL(ctx, object, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	link, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.EscapedPath() != "/files/registrations/12/kk%20scan.pdf" {
+		t.Errorf("link path %q", link.EscapedPath())
+	}
+	expires, err := strconv.ParseInt(link.Query().Get("expires"), 10, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := link.Query().Get("signature")
+
+	other := NewLocalStorageRepo(root, "/files", false, []byte("other-key")).(*localStorageRepo)
+	tests := []struct {
+		name      string
+		object    string
+		expires   int64
+		signature string
+		now       time.Time
+		want      error
+	}{
+		{"valid", object, expires, signature, time.Now(), nil},
+		{"last second", object, expires, signature, time.Unix(expires, 0), nil},
+		{"expired", object, expires, signature, time.Unix(expires+1, 0), ErrInvalidSignedURL},
+		{"expiry moved", object, expires + 3600, signature, time.Now(), ErrInvalidSignedURL},
+		{"other object", "registrations/12/akta.pdf", expires, signature, time.Now(), ErrInvalidSignedURL},
+		{"tampered", object, expires, signature[:len(signature)-1] + "0", time.Now(), ErrInvalidSignedURL},
+		{"other key", object, expires, other.sign(object, expires), time.Now(), ErrInvalidSignedURL},
+		{"empty signature", object, expires, "", time.Now(), ErrInvalidSignedURL},
+		{"outside root", "../" + object, expires, signature, time.Now(), ErrInvalidObjectName},
+	}
+	for _, tt := range tests {
+		file, err := resolver.ResolveSignedURL(tt.object, tt.expires, tt.signature, tt.now)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+			continue
+		}
+		if err == nil && file != filepath.Join(root, filepath.FromSlash(object)) {
+			t.Errorf("%s: file %q", tt.name, file)
+		}
+	}
+
+	if _, err := store.GenerateSignedURL(ctx, "../etc/passwd", time.Hour); !errors.Is(err, ErrInvalidObjectName) {
+		t.Errorf("signing a name outside root: got %v", err)
+	}
+	noKey := NewLocalStorageRepo(root, "/files", false, nil)
+	if _, err := noKey.GenerateSignedURL(ctx, object, time.Hour); !errors.Is(err, ErrSignedURLNotSupported) {
+		t.Errorf("no signing key: got %v", err)
+	}
+	public := NewLocalStorageRepo(root, "/uploads", true, []byte("signing-key"))
+	if raw, err := public.GenerateSignedURL(ctx, object, time.Hour); err != nil || raw != "/uploads/"+object {
+		t.Errorf("public store: got %q, %v", raw, err)
+	}
+	if _, err := public.(SignedFileResolver).ResolveSignedURL(object, expires, signature, time.Now()); !errors.Is(err, ErrSignedURLNotSupported) {
+		t.Errorf("public store resolving a link: got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"darulabror/internal/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// MediaFilter narrows media listings. Empty fields are ignored.
+type MediaFilter struct {
+	Query string // matches file name or object name
+	Type  string // image, video, audio or document (anything else)
+}
+
+// MediaWithUsage is a media item plus how many articles use it.
+type MediaWithUsage struct {
+	models.Media
+	UsageCount int64
+}
+
+type MediaRepo interface {
+	Create(media *models.Media) error
+	List(page, limit int, filter MediaFilter) ([]MediaWithUsage, int64, error)
+	GetByID(id uint) (models.Media, error)
+	// GetByRefs returns the items matching any of ids or urls.
+	GetByRefs(ids []uint, urls []string) ([]models.Media, error)
+	Delete(id uint) error
+	// GetByObjectNames returns the items stored as any of names, their resized
+	// variants included.
+	GetByObjectNames(names []string) ([]models.Media, error)
+	// DeleteByObjectNames deletes the items whose main object is one of names.
+	DeleteByObjectNames(names []string) (int64, error)
+
+	// Usage: articles (trashed included) linking the item
+	CountUsage(id uint) (int64, error)
+	GetUsage(id uint) ([]models.Article, error)
+}
+
+type mediaRepo struct {
+	db *gorm.DB
+}
+
+func NewMediaRepo(db *gorm.DB) MediaRepo {
+	return &mediaRepo{db: db}
+}
+
+func (r *mediaRepo) Create(media *models.Media) error {
+	return r.db.Create(media).Error
+}
+
+// List returns media newest first, each with its usage count.
+func (r *mediaRepo) List(page, limit int, filter MediaFilter) ([]MediaWithUsage, int64, error) {
+	var (
+		items []MediaWithUsage
+		total int64
+	)
+
+	q := applyMediaFilter(r.db.Model(&models.Media{}), filter)
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := applyMediaFilter(r.db.Table("media"), filter).
+		Select("media.*, COUNT(am.article_id) AS usage_count").
+		Joins("LEFT JOIN article_media am ON am.media_id = media.id").
+		Group("media.id").
+		Order("media.created_at DESC, media.id DESC").
+		Offset(offset).
+		Limit(limit).
+		Scan(&items).Error
+	return items, total, err
+}
+
+func (r *mediaRepo) GetByID(id uint) (models.Media, error) {
+	var media models.Media
+	err := r.db.First(&media, id).Error
+	return media, err
+}
+
+func (r *mediaRepo) GetByRefs(ids []uint, urls []string) ([]models.Media, error) {
+	items := make([]models.Media, 0)
+	if len(ids) == 0 && len(urls) == 0 {
+		return items, nil
+	}
+
+	q := r.db.Where("1 = 0")
+	if len(ids) > 0 {
+		q = q.Or("id IN ?", ids)
+	}
+	if len(urls) > 0 {
+		q = q.Or("url IN ?", urls)
+	}
+	err := q.Order("id ASC").Find(&items).Error
+	return items, err
+}
+
+func (r *mediaRepo) Delete(id uint) error {
+	result := r.db.Delete(&models.Media{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *mediaRepo) GetByObjectNames(names []string) ([]models.Media, error) {
+	items := make([]models.Media, 0)
+	if len(names) == 0 {
+		return items, nil
+	}
+	err := r.db.Where("object_name IN ? OR EXISTS (SELECT 1 FROM jsonb_array_elements(variants) v WHERE v->>'object_name' IN ?)", names, names).
+		Find(&items).Error
+	return items, err
+}
+
+func (r *mediaRepo) DeleteByObjectNames(names []string) (int64, error) {
+	if len(names) == 0 {
+		return 0, nil
+	}
+	result := r.db.Where("object_name IN ?", names).Delete(&models.Media{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *mediaRepo) CountUsage(id uint) (int64, error) {
+	var count int64
+	err := r.db.Table("article_media").Where("media_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// GetUsage lists the articles using the item (without content), trashed ones included
+// since they can still be restored.
+func (r *mediaRepo) GetUsage(id uint) ([]models.Article, error) {
+	var articles []models.Article
+	err := r.db.Unscoped().
+		Select("articles.id", "articles.title", "articles.slug", "articles.status", "articles.updated_at", "articles.deleted_at").
+		Joins("JOIN article_media am ON am.article_id = articles.id").
+		Where("am.media_id = ?", id).
+		Order("articles.updated_at DESC").
+		Find(&articles).Error
+	return articles, err
+}
+
+func applyMediaFilter(q *gorm.DB, filter MediaFilter) *gorm.DB {
+	if query := strings.TrimSpace(filter.Query); query != "" {
+		like := "%" + escapeLike(query) + "%"
+		q = q.Where("(media.file_name ILIKE ? OR media.object_name ILIKE ?)", like, like)
+	}
+	switch filter.Type {
+	case "image", "video", "audio":
+		q = q.Where("media.mime_type LIKE ?", filter.Type+"/%")
+	case "document":
+		q = q.Where("media.mime_type NOT LIKE 'image/%' AND media.mime_type NOT LIKE 'video/%' AND media.mime_type NOT LIKE 'audio/%'")
+	}
+	return q
+}
+
+// escapeLike escapes LIKE wildcards so user input matches literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
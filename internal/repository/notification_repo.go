@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"darulabror/internal/models"
+	"darulabror/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeliveryFilter struct {
+	Status         string
+	RegistrationID uint
+}
+
+type NotificationRepo interface {
+	// ClaimDigest records the admin digest of the window ending at periodEnd;
+	// false when it was claimed already.
+	ClaimDigest(periodEnd, now time.Time) (bool, error)
+
+	// WhatsApp/SMS delivery log
+	CreateDeliveries(deliveries []models.NotificationDelivery) error
+	// ClaimDueDeliveries returns up to limit pending deliveries of channel due at
+	// now and pushes their next attempt to now+lease, so other instances skip
+	// them while they are being sent.
+	ClaimDueDeliveries(channel string, now time.Time, lease time.Duration, limit int) ([]models.NotificationDelivery, error)
+	// UpdateDelivery stores the outcome of an attempt (status, attempts, error,
+	// next attempt, sent at).
+	UpdateDelivery(d models.NotificationDelivery) error
+	GetDeliveries(page, limit int, filter DeliveryFilter) ([]models.NotificationDelivery, int64, error)
+	GetDelivery(id uint) (models.NotificationDelivery, error)
+}
+
+type notificationRepo struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepo(db *gorm.DB) NotificationRepo {
+	return &notificationRepo{db: db}
+}
+
+func (r *notificationRepo) ClaimDigest(periodEnd, now time.Time) (bool, error) {
+	res := r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.AdminDigest{PeriodEnd: periodEnd, SentAt: now})
+	return res.RowsAffected == 1, res.Error
+}
+
+func (r *notificationRepo) CreateDeliveries(deliveries []models.NotificationDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.Create(&deliveries).Error
+}
+
+func (r *notificationRepo) ClaimDueDeliveries(channel string, now time.Time, lease time.Duration, limit int) ([]models.NotificationDelivery, error) {
+	var deliveries []models.NotificationDelivery
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND channel = ? AND next_attempt_at <= ?", models.DeliveryPending, channel, now).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(limit).
+			Find(&deliveries).Error; err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, 0, len(deliveries))
+		for _, d := range deliveries {
+			ids = append(ids, d.ID)
+		}
+		return tx.Model(&models.NotificationDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	return deliveries, err
+}
+
+func (r *notificationRepo) UpdateDelivery(d models.NotificationDelivery) error {
+	res := r.db.Model(&models.NotificationDelivery{}).Where("id = ?", d.ID).Updates(map[string]interface{}{
+		"status":          d.Status,
+		"attempts":        d.Attempts,
+		"last_error":      d.LastError,
+		"next_attempt_at": d.NextAttemptAt,
+		"sent_at":         d.SentAt,
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *notificationRepo) GetDeliveries(page, limit int, filter DeliveryFilter) ([]models.NotificationDelivery, int64, error) {
+	var (
+		deliveries []models.NotificationDelivery
+		total      int64
+	)
+
+	_, limit, offset := utils.NormalizePageLimit(page, limit)
+
+	query := r.db.Model(&models.NotificationDelivery{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.RegistrationID != 0 {
+		query = query.Where("registration_id = ?", filter.RegistrationID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+func (r *notificationRepo) GetDelivery(id uint) (models.NotificationDelivery, error) {
+	var d models.NotificationDelivery
+	err := r.db.First(&d, id).Error
+	return d, err
+}
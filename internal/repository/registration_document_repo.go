@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"darulabror/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RegistrationDocumentRepo interface {
+	ListByRegistration(registrationID uint) ([]models.RegistrationDocument, error)
+	GetByID(registrationID, id uint) (models.RegistrationDocument, error)
+	GetByType(registrationID uint, docType models.RegistrationDocumentType) (models.RegistrationDocument, error)
+	// Save stores the document, replacing the registration's document of that type.
+	Save(doc *models.RegistrationDocument) error
+	Review(registrationID, id uint, status models.DocumentStatus, reason string, adminID uint, at time.Time) error
+	// ExistingObjectNames returns which of names still belong to a document.
+	ExistingObjectNames(names []string) ([]string, error)
+}
+
+type registrationDocumentRepo struct {
+	db *gorm.DB
+}
+
+func NewRegistrationDocumentRepo(db *gorm.DB) RegistrationDocumentRepo {
+	return &registrationDocumentRepo{db: db}
+}
+
+func (r *registrationDocumentRepo) ListByRegistration(registrationID uint) ([]models.RegistrationDocument, error) {
+	var docs []models.RegistrationDocument
+	err := r.db.Where("registration_id = ?", registrationID).Order("id ASC").Find(&docs).Error
+	return docs, err
+}
+
+func (r *registrationDocumentRepo) GetByID(registrationID, id uint) (models.RegistrationDocument, error) {
+	var doc models.RegistrationDocument
+	err := r.db.Where("registration_id = ?", registrationID).First(&doc, id).Error
+	return doc, err
+}
+
+func (r *registrationDocumentRepo) GetByType(registrationID uint, docType models.RegistrationDocumentType) (models.RegistrationDocument, error) {
+	var doc models.RegistrationDocument
+	err := r.db.Where("registration_id = ? AND type = ?", registrationID, docType).First(&doc).Error
+	return doc, err
+}
+
+func (r *registrationDocumentRepo) Save(doc *models.RegistrationDocument) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "registration_id"}, {Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"object_name", "file_name", "mime_type", "size",
+			"status", "reject_reason", "reviewed_by", "reviewed_at", "updated_at",
+		}),
+	}).Create(doc).Error
+}
+
+func (r *registrationDocumentRepo) Review(registrationID, id uint, status models.DocumentStatus, reason string, adminID uint, at time.Time) error {
+	result := r.db.Model(&models.RegistrationDocument{}).
+		Where("id = ? AND registration_id = ?", id, registrationID).
+		Updates(map[string]interface{}{
+			"status":        status,
+			"reject_reason": reason,
+			"reviewed_by":   adminID,
+			"reviewed_at":   at,
+			"updated_at":    at,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *registrationDocumentRepo) ExistingObjectNames(names []string) ([]string, error) {
+	existing := make([]string, 0)
+	if len(names) == 0 {
+		return existing, nil
+	}
+	err := r.db.Model(&models.RegistrationDocument{}).Where("object_name IN ?", names).Pluck("object_name", &existing).Error
+	return existing, err
+}
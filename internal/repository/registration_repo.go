@@ -4,23 +4,59 @@ import (
 	"darulabror/internal/models"
 	"darulabror/internal/utils"
 	"errors"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// RegistrationFilter narrows admin registration listings; zero values match everything.
+type RegistrationFilter struct {
+	Status            string
+	AdmissionPeriodID uint
+	AcademicYear      string // registrations of any period of that year
+	// created_at range [CreatedFrom, CreatedBefore); zero = unbounded
+	CreatedFrom   time.Time
+	CreatedBefore time.Time
+}
+
+// ErrDuplicateReference is returned by RegistrationRepo.Create / CreateAll when
+// the registration's reference number is taken already; retry with another one.
+var ErrDuplicateReference = errors.New("reference number already used")
+
 type RegistrationRepo interface {
 	// Public Registration Management
-	Create(reg models.Registration) error
+	Create(reg *models.Registration) error // sets reg.ID
+	// CreateAll stores regs in one transaction (sets their IDs). On error nothing
+	// is stored and failed is the index of the registration that failed.
+	CreateAll(regs []*models.Registration) (failed int, err error)
 	// Admin Registration Management
-	GetAll(page, limit int, status string) ([]models.Registration, int64, error)
+	GetAll(page, limit int, filter RegistrationFilter) ([]models.Registration, int64, error)
+	// Each passes the registrations matching filter to fn in batches of size
+	// (in ID order), without loading them all at once.
+	Each(filter RegistrationFilter, size int, fn func(batch []models.Registration) error) error
 	GetByID(id uint) (models.Registration, error)
 	GetByEmail(email string) (models.Registration, error)
 	GetByNISN(nisn string) (models.Registration, error)
+	GetByReference(reference string) (models.Registration, error)
+	GetByUploadTokenHash(hash string) (models.Registration, error)
+	// GetCreatedBetween returns the first limit registrations created in [from, to)
+	// and how many there are.
+	GetCreatedBetween(from, to time.Time, limit int) ([]models.Registration, int64, error)
 
 	Update(reg models.Registration) error
-	UpdateStatus(id uint, status models.RegistrationStatus) error
-	Delete(id uint) error
-	// Existence Checks
+	// ChangeStatus moves the registration from status from to status to and
+	// records the change; gorm.ErrRecordNotFound when it isn't in status from.
+	ChangeStatus(id uint, from, to models.RegistrationStatus, adminID *uint, note string) error
+	GetStatusHistory(id uint) ([]models.RegistrationStatusHistory, error)
+	Delete(id uint) error // moves the registration to the trash
+	// Trash
+	GetTrashed(page, limit int) ([]models.Registration, int64, error)
+	Restore(id uint) error
+	Purge(id uint) error
+	PurgeTrashedBefore(cutoff time.Time) (int64, error)
+	// Existence Checks (trashed registrations count too)
 	ExistsByEmail(email string) (bool, error)
 	ExistsByNISN(nisn string) (bool, error)
 }
@@ -33,15 +69,68 @@ func NewRegistrationRepo(db *gorm.DB) RegistrationRepo {
 	return &registrationRepo{db: db}
 }
 
-func (r *registrationRepo) Create(reg models.Registration) error {
+func (r *registrationRepo) Create(reg *models.Registration) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return createRegistration(tx, reg)
+	})
+}
+
+func (r *registrationRepo) CreateAll(regs []*models.Registration) (int, error) {
+	failed := -1
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for i, reg := range regs {
+			if err := createRegistration(tx, reg); err != nil {
+				failed = i
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		for _, reg := range regs {
+			reg.ID = 0 // rolled back
+		}
+	}
+	return failed, err
+}
+
+// createRegistration stores reg and the first entry of its timeline within tx,
+// checking the quota of its admission period.
+func createRegistration(tx *gorm.DB, reg *models.Registration) error {
 	// Set default status if not provided
 	if reg.Status == "" {
 		reg.Status = models.RegistrationStatusNew
 	}
-	return r.db.Create(&reg).Error
+	if reg.AdmissionPeriodID != nil {
+		// the period row lock serializes concurrent registrations, so the
+		// quota can't be exceeded between the count and the insert
+		var period models.AdmissionPeriod
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&period, *reg.AdmissionPeriodID).Error; err != nil {
+			return err
+		}
+		seats, err := countSeats(tx, period.ID)
+		if err != nil {
+			return err
+		}
+		if !period.HasSeat(reg.Gender, reg.StudentType, seats) {
+			return ErrAdmissionPeriodFull
+		}
+	}
+	if err := tx.Create(reg).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_registrations_reference_number" {
+			return ErrDuplicateReference
+		}
+		return err
+	}
+	// the timeline starts with the creation
+	return tx.Create(&models.RegistrationStatusHistory{
+		RegistrationID: reg.ID,
+		ToStatus:       reg.Status,
+	}).Error
 }
 
-func (r *registrationRepo) GetAll(page, limit int, status string) ([]models.Registration, int64, error) {
+func (r *registrationRepo) GetAll(page, limit int, filter RegistrationFilter) ([]models.Registration, int64, error) {
 	var (
 		regs  []models.Registration
 		total int64
@@ -49,12 +138,7 @@ func (r *registrationRepo) GetAll(page, limit int, status string) ([]models.Regi
 
 	_, limit, offset := utils.NormalizePageLimit(page, limit)
 
-	query := r.db.Model(&models.Registration{})
-	
-	// Apply status filter if provided
-	if status != "" {
-		query = query.Where("status = ?", status)
-	}
+	query := applyRegistrationFilter(r.db.Model(&models.Registration{}), filter)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -64,6 +148,29 @@ func (r *registrationRepo) GetAll(page, limit int, status string) ([]models.Regi
 	return regs, total, err
 }
 
+func (r *registrationRepo) Each(filter RegistrationFilter, size int, fn func(batch []models.Registration) error) error {
+	var batch []models.Registration
+	return applyRegistrationFilter(r.db.Model(&models.Registration{}), filter).
+		FindInBatches(&batch, size, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
+
+func (r *registrationRepo) GetCreatedBetween(from, to time.Time, limit int) ([]models.Registration, int64, error) {
+	var (
+		regs  []models.Registration
+		total int64
+	)
+
+	query := r.db.Model(&models.Registration{}).Where("created_at >= ? AND created_at < ?", from, to)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("id ASC").Limit(limit).Find(&regs).Error
+	return regs, total, err
+}
+
 func (r *registrationRepo) GetByID(id uint) (models.Registration, error) {
 	var reg models.Registration
 	err := r.db.First(&reg, id).Error
@@ -82,6 +189,18 @@ func (r *registrationRepo) GetByNISN(nisn string) (models.Registration, error) {
 	return reg, err
 }
 
+func (r *registrationRepo) GetByReference(reference string) (models.Registration, error) {
+	var reg models.Registration
+	err := r.db.Where("reference_number = ?", reference).First(&reg).Error
+	return reg, err
+}
+
+func (r *registrationRepo) GetByUploadTokenHash(hash string) (models.Registration, error) {
+	var reg models.Registration
+	err := r.db.Where("upload_token_hash = ? AND upload_token_hash <> ''", hash).First(&reg).Error
+	return reg, err
+}
+
 func (r *registrationRepo) Update(reg models.Registration) error {
 	// Pastikan ID ada
 	if reg.ID == 0 {
@@ -94,25 +213,76 @@ func (r *registrationRepo) Delete(id uint) error {
 	return r.db.Delete(&models.Registration{}, id).Error
 }
 
-func (r *registrationRepo) UpdateStatus(id uint, status models.RegistrationStatus) error {
-	result := r.db.Model(&models.Registration{}).Where("id = ?", id).Update("status", status)
-	if result.Error != nil {
-		return result.Error
-	}
-	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
-	}
-	return nil
+func (r *registrationRepo) GetTrashed(page, limit int) ([]models.Registration, int64, error) {
+	return listTrashed[models.Registration](r.db, page, limit)
+}
+
+func (r *registrationRepo) Restore(id uint) error {
+	return restoreTrashed(r.db, &models.Registration{}, id)
+}
+
+func (r *registrationRepo) Purge(id uint) error {
+	return purgeTrashed(r.db, &models.Registration{}, id)
+}
+
+func (r *registrationRepo) PurgeTrashedBefore(cutoff time.Time) (int64, error) {
+	return purgeTrashedBefore(r.db, &models.Registration{}, cutoff)
+}
+
+func (r *registrationRepo) ChangeStatus(id uint, from, to models.RegistrationStatus, adminID *uint, note string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// guarded by the current status: a concurrent change makes this one fail
+		result := tx.Model(&models.Registration{}).Where("id = ? AND status = ?", id, from).Update("status", to)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Create(&models.RegistrationStatusHistory{
+			RegistrationID: id,
+			FromStatus:     from,
+			ToStatus:       to,
+			AdminID:        adminID,
+			Note:           note,
+		}).Error
+	})
+}
+
+func (r *registrationRepo) GetStatusHistory(id uint) ([]models.RegistrationStatusHistory, error) {
+	var history []models.RegistrationStatusHistory
+	err := r.db.Where("registration_id = ?", id).Order("created_at ASC, id ASC").Find(&history).Error
+	return history, err
 }
 
 func (r *registrationRepo) ExistsByEmail(email string) (bool, error) {
 	var count int64
-	err := r.db.Model(&models.Registration{}).Where("email = ?", email).Count(&count).Error
+	err := r.db.Unscoped().Model(&models.Registration{}).Where("email = ?", email).Count(&count).Error
 	return count > 0, err
 }
 
 func (r *registrationRepo) ExistsByNISN(nisn string) (bool, error) {
 	var count int64
-	err := r.db.Model(&models.Registration{}).Where("nisn = ?", nisn).Count(&count).Error
+	err := r.db.Unscoped().Model(&models.Registration{}).Where("nisn = ?", nisn).Count(&count).Error
 	return count > 0, err
 }
+
+func applyRegistrationFilter(q *gorm.DB, filter RegistrationFilter) *gorm.DB {
+	if filter.Status != "" {
+		q = q.Where("status = ?", filter.Status)
+	}
+	if filter.AdmissionPeriodID != 0 {
+		q = q.Where("admission_period_id = ?", filter.AdmissionPeriodID)
+	}
+	if filter.AcademicYear != "" {
+		q = q.Where("admission_period_id IN (?)",
+			q.Session(&gorm.Session{NewDB: true}).Model(&models.AdmissionPeriod{}).Select("id").Where("academic_year = ?", filter.AcademicYear))
+	}
+	if !filter.CreatedFrom.IsZero() {
+		q = q.Where("created_at >= ?", filter.CreatedFrom)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		q = q.Where("created_at < ?", filter.CreatedBefore)
+	}
+	return q
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/sirupsen/logrus"
+)
+
+// s3PartSize bounds the memory used for uploads of unknown size (multipart upload).
+const s3PartSize = 16 << 20
+
+type s3StorageRepo struct {
+	client     *minio.Client
+	bucketName string
+	publicURL  string // base URL of the bucket's objects, e.g. "https://cdn.example.com/media"
+	isPublic   bool
+}
+
+// NewS3StorageRepo stores objects in an S3-compatible bucket (AWS S3, MinIO, ...).
+// Public stores return publicURL + "/" + objectName; the bucket (or a CDN in
+// front of it) must allow anonymous reads there.
+func NewS3StorageRepo(client *minio.Client, bucketName, publicURL string, isPublic bool) StorageRepo {
+	return &s3StorageRepo{
+		client:     client,
+		bucketName: bucketName,
+		publicURL:  strings.TrimRight(publicURL, "/"),
+		isPublic:   isPublic,
+	}
+}
+
+func (r *s3StorageRepo) validate() error {
+	if r.client == nil || r.bucketName == "" {
+		return ErrStorageNotConfigured
+	}
+	return nil
+}
+
+// UploadFile — handle file upload to the bucket
+func (r *s3StorageRepo) UploadFile(ctx context.Context, file io.Reader, objectName string) (string, error) {
+	if err := r.validate(); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Second)
+	defer cancel()
+
+	// known sizes upload in one request; unknown ones in s3PartSize parts
+	size := int64(-1)
+	if seeker, ok := file.(io.Seeker); ok {
+		cur, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return "", err
+		}
+		end, err := seeker.Seek(0, io.SeekEnd)
+		if err != nil {
+			return "", err
+		}
+		if _, err := seeker.Seek(cur, io.SeekStart); err != nil {
+			return "", err
+		}
+		size = end - cur
+	}
+
+	// S3 doesn't guess the type like GCS does; browsers need it to display the file
+	buffered := bufio.NewReaderSize(file, 512)
+	head, _ := buffered.Peek(512)
+
+	_, err := r.client.PutObject(ctx, r.bucketName, objectName, buffered, size, minio.PutObjectOptions{
+		ContentType: http.DetectContentType(head),
+		PartSize:    s3PartSize,
+	})
+	if err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"bucket": r.bucketName,
+			"object": objectName,
+		}).Error("s3 upload failed")
+		return "", err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"bucket": r.bucketName,
+		"object": objectName,
+		"public": r.isPublic,
+	}).Info("file uploaded to s3")
+	return r.fileURL(objectName), nil
+}
+
+// GenerateSignedURL — presigned GET for private objects
+func (r *s3StorageRepo) GenerateSignedURL(ctx context.Context, objectName string, expire time.Duration) (string, error) {
+	if err := r.validate(); err != nil {
+		return "", err
+	}
+	if r.isPublic {
+		return r.fileURL(objectName), nil
+	}
+
+	u, err := r.client.PresignedGetObject(ctx, r.bucketName, objectName, expire, nil)
+	if err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"bucket": r.bucketName,
+			"object": objectName,
+		}).Error("failed generating signed url")
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// DeleteFile — remove an object; S3 treats deleting a missing object as success
+func (r *s3StorageRepo) DeleteFile(ctx context.Context, objectName string) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if err := r.client.RemoveObject(ctx, r.bucketName, objectName, minio.RemoveObjectOptions{}); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"bucket": r.bucketName,
+			"object": objectName,
+		}).Error("s3 delete failed")
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"bucket": r.bucketName,
+		"object": objectName,
+	}).Info("file deleted from s3")
+	return nil
+}
+
+// ListFiles — list every object whose name starts with prefix
+func (r *s3StorageRepo) ListFiles(ctx context.Context, prefix string) ([]StorageObject, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // stops the listing goroutine on early return
+
+	objects := make([]StorageObject, 0)
+	for obj := range r.client.ListObjects(ctx, r.bucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			logrus.WithError(obj.Err).WithFields(logrus.Fields{
+				"bucket": r.bucketName,
+				"prefix": prefix,
+			}).Error("s3 list failed")
+			return nil, obj.Err
+		}
+		objects = append(objects, StorageObject{
+			Name:    obj.Key,
+			URL:     r.fileURL(obj.Key),
+			Size:    obj.Size,
+			Updated: obj.LastModified,
+		})
+	}
+	return objects, nil
+}
+
+// fileURL is the public URL of an object, or just its name for private buckets.
+func (r *s3StorageRepo) fileURL(objectName string) string {
+	if !r.isPublic {
+		return objectName
+	}
+	return r.publicURL + "/" + objectName
+}
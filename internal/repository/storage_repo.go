@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrStorageNotConfigured is returned by a store whose backend isn't set up
+// (e.g. the gcs or s3 backend without PUBLIC_BUCKET).
+var ErrStorageNotConfigured = errors.New("storage is not configured")
+
+// StorageObject is an object listed from the bucket.
+type StorageObject struct {
+	Name    string
+	URL     string // what UploadFile returned for it (public URL, or the object name for private buckets)
+	Size    int64
+	Updated time.Time
+}
+
+// StorageRepo stores uploaded files. Implementations: GCS (NewGCPStorageRepo),
+// S3-compatible (NewS3StorageRepo) and local filesystem (NewLocalStorageRepo).
+//
+// Object names are slash-separated paths like "articles/header/<ts>_foto.jpg".
+// Public stores return the file's URL from UploadFile; private stores return the
+// object name, readable only through GenerateSignedURL.
+type StorageRepo interface {
+	UploadFile(ctx context.Context, file io.Reader, objectName string) (string, error)
+	GenerateSignedURL(ctx context.Context, objectName string, expire time.Duration) (string, error)
+	DeleteFile(ctx context.Context, objectName string) error
+	ListFiles(ctx context.Context, prefix string) ([]StorageObject, error)
+}
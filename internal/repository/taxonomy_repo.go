@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"darulabror/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CategoryWithCount is a category plus how many articles use it.
+type CategoryWithCount struct {
+	models.Category
+	ArticleCount int64
+}
+
+// TagWithCount is a tag plus how many articles use it.
+type TagWithCount struct {
+	models.Tag
+	ArticleCount int64
+}
+
+type TaxonomyRepo interface {
+	// Categories
+	CreateCategory(category *models.Category) error
+	ListCategories(publishedOnly bool) ([]CategoryWithCount, error)
+	GetCategoryByID(id uint) (models.Category, error)
+	GetCategoriesByIDs(ids []uint) ([]models.Category, error)
+	UpdateCategory(category models.Category) error
+	DeleteCategory(id uint) error
+	CategorySlugExists(slug string, excludeID uint) (bool, error)
+
+	// Tags
+	CreateTag(tag *models.Tag) error
+	ListTags(publishedOnly bool) ([]TagWithCount, error)
+	GetTagByID(id uint) (models.Tag, error)
+	GetTagsByIDs(ids []uint) ([]models.Tag, error)
+	UpdateTag(tag models.Tag) error
+	DeleteTag(id uint) error
+	TagSlugExists(slug string, excludeID uint) (bool, error)
+}
+
+type taxonomyRepo struct {
+	db *gorm.DB
+}
+
+func NewTaxonomyRepo(db *gorm.DB) TaxonomyRepo {
+	return &taxonomyRepo{db: db}
+}
+
+// ======================
+// Categories
+// ======================
+
+func (r *taxonomyRepo) CreateCategory(category *models.Category) error {
+	return r.db.Create(category).Error
+}
+
+func (r *taxonomyRepo) ListCategories(publishedOnly bool) ([]CategoryWithCount, error) {
+	var out []CategoryWithCount
+
+	q := r.db.Table("categories").
+		Select("categories.*, COUNT(a.id) AS article_count").
+		Joins("LEFT JOIN article_categories ac ON ac.category_id = categories.id")
+	if publishedOnly {
+		now := time.Now().Unix()
+		q = q.Joins("LEFT JOIN articles a ON a.id = ac.article_id AND a.deleted_at IS NULL AND "+publishedCondition("a"), now, now)
+	} else {
+		q = q.Joins("LEFT JOIN articles a ON a.id = ac.article_id AND a.deleted_at IS NULL")
+	}
+
+	err := q.
+		Group("categories.id").
+		Order("categories.name ASC").
+		Scan(&out).Error
+	return out, err
+}
+
+func (r *taxonomyRepo) GetCategoryByID(id uint) (models.Category, error) {
+	var category models.Category
+	err := r.db.First(&category, id).Error
+	return category, err
+}
+
+func (r *taxonomyRepo) GetCategoriesByIDs(ids []uint) ([]models.Category, error) {
+	var categories []models.Category
+	if len(ids) == 0 {
+		return categories, nil
+	}
+	err := r.db.Where("id IN ?", ids).Order("name ASC").Find(&categories).Error
+	return categories, err
+}
+
+func (r *taxonomyRepo) UpdateCategory(category models.Category) error {
+	return r.db.Save(&category).Error
+}
+
+// DeleteCategory removes the category and unlinks it from articles.
+func (r *taxonomyRepo) DeleteCategory(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM article_categories WHERE category_id = ?", id).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Category{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *taxonomyRepo) CategorySlugExists(slug string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Category{}).Where("slug = ? AND id <> ?", slug, excludeID).Count(&count).Error
+	return count > 0, err
+}
+
+// ======================
+// Tags
+// ======================
+
+func (r *taxonomyRepo) CreateTag(tag *models.Tag) error {
+	return r.db.Create(tag).Error
+}
+
+func (r *taxonomyRepo) ListTags(publishedOnly bool) ([]TagWithCount, error) {
+	var out []TagWithCount
+
+	q := r.db.Table("tags").
+		Select("tags.*, COUNT(a.id) AS article_count").
+		Joins("LEFT JOIN article_tags atg ON atg.tag_id = tags.id")
+	if publishedOnly {
+		now := time.Now().Unix()
+		q = q.Joins("LEFT JOIN articles a ON a.id = atg.article_id AND a.deleted_at IS NULL AND "+publishedCondition("a"), now, now)
+	} else {
+		q = q.Joins("LEFT JOIN articles a ON a.id = atg.article_id AND a.deleted_at IS NULL")
+	}
+
+	err := q.
+		Group("tags.id").
+		Order("tags.name ASC").
+		Scan(&out).Error
+	return out, err
+}
+
+func (r *taxonomyRepo) GetTagByID(id uint) (models.Tag, error) {
+	var tag models.Tag
+	err := r.db.First(&tag, id).Error
+	return tag, err
+}
+
+func (r *taxonomyRepo) GetTagsByIDs(ids []uint) ([]models.Tag, error) {
+	var tags []models.Tag
+	if len(ids) == 0 {
+		return tags, nil
+	}
+	err := r.db.Where("id IN ?", ids).Order("name ASC").Find(&tags).Error
+	return tags, err
+}
+
+func (r *taxonomyRepo) UpdateTag(tag models.Tag) error {
+	return r.db.Save(&tag).Error
+}
+
+// DeleteTag removes the tag and unlinks it from articles.
+func (r *taxonomyRepo) DeleteTag(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM article_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Tag{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *taxonomyRepo) TagSlugExists(slug string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Tag{}).Where("slug = ? AND id <> ?", slug, excludeID).Count(&count).Error
+	return count > 0, err
+}
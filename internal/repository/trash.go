@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"darulabror/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Shared helpers for soft-deleted ("trashed") rows. model is a pointer to the
+// model type, e.g. &models.Contact{}; it must have a gorm.DeletedAt field.
+
+// listTrashed pages through trashed rows, most recently deleted first.
+func listTrashed[T any](db *gorm.DB, page, limit int) ([]T, int64, error) {
+	var (
+		items []T
+		total int64
+	)
+
+	_, limit, offset := utils.NormalizePageLimit(page, limit)
+
+	q := db.Unscoped().Model(new(T)).Where("deleted_at IS NOT NULL")
+
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := q.Order("deleted_at DESC").Order("id DESC").Limit(limit).Offset(offset).Find(&items).Error
+	return items, total, err
+}
+
+// restoreTrashed takes a row out of the trash. gorm.ErrRecordNotFound if it isn't trashed.
+func restoreTrashed(db *gorm.DB, model interface{}, id uint) error {
+	result := db.Unscoped().Model(model).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// purgeTrashed permanently deletes a trashed row. Live rows are never touched
+// (gorm.ErrRecordNotFound), so purging always goes through the trash first.
+func purgeTrashed(db *gorm.DB, model interface{}, id uint) error {
+	result := db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Delete(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// purgeTrashedBefore permanently deletes rows trashed before cutoff and returns how many.
+func purgeTrashedBefore(db *gorm.DB, model interface{}, cutoff time.Time) (int64, error) {
+	result := db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).Delete(model)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"darulabror/internal/dto"
+	"darulabror/internal/models"
+	"darulabror/internal/repository"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// AdmissionPeriodService manages admission waves (gelombang). Once a period has
+// been set up, registrations are only accepted while a period is open.
+type AdmissionPeriodService interface {
+	// Public
+	// GetCurrent returns the period open at now with its seats left or, between
+	// periods, the next one to open (is_open false).
+	GetCurrent(now time.Time) (dto.AdmissionPeriodDTO, error)
+
+	// Admin
+	ListPeriods(academicYear string) ([]dto.AdmissionPeriodDTO, error)
+	GetPeriod(id uint) (dto.AdmissionPeriodDTO, error)
+	CreatePeriod(d dto.AdmissionPeriodDTO) (dto.AdmissionPeriodDTO, error)
+	UpdatePeriod(id uint, d dto.AdmissionPeriodDTO) (dto.AdmissionPeriodDTO, error)
+	DeletePeriod(id uint) error
+}
+
+type admissionPeriodService struct {
+	repo repository.AdmissionPeriodRepo
+}
+
+func NewAdmissionPeriodService(repo repository.AdmissionPeriodRepo) AdmissionPeriodService {
+	return &admissionPeriodService{repo: repo}
+}
+
+var academicYearPattern = regexp.MustCompile(`^(\d{4})/(\d{4})$`)
+
+func (s *admissionPeriodService) GetCurrent(now time.Time) (dto.AdmissionPeriodDTO, error) {
+	period, err := s.repo.GetOpenAt(now)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		period, err = s.repo.GetNextAfter(now)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.AdmissionPeriodDTO{}, ErrNotFoundAdmissionPeriod
+		}
+		logrus.WithError(err).Error("failed get current admission period")
+		return dto.AdmissionPeriodDTO{}, err
+	}
+
+	out := dto.AdmissionPeriodModelToDTO(period, now)
+	out.ServerTime = now.Format(time.RFC3339)
+	if out.IsOpen {
+		seats, err := s.repo.CountSeats(period.ID)
+		if err != nil {
+			logrus.WithError(err).WithField("id", period.ID).Error("failed count admission seats")
+			return dto.AdmissionPeriodDTO{}, err
+		}
+		remaining := dto.AdmissionSeatsLeft(period, seats)
+		out.Remaining = &remaining
+	}
+	return out, nil
+}
+
+func (s *admissionPeriodService) ListPeriods(academicYear string) ([]dto.AdmissionPeriodDTO, error) {
+	periods, err := s.repo.GetAll(strings.TrimSpace(academicYear))
+	if err != nil {
+		logrus.WithError(err).Error("failed list admission periods")
+		return nil, err
+	}
+
+	now := time.Now()
+	out := make([]dto.AdmissionPeriodDTO, 0, len(periods))
+	for _, p := range periods {
+		out = append(out, dto.AdmissionPeriodModelToDTO(p, now))
+	}
+	return out, nil
+}
+
+func (s *admissionPeriodService) GetPeriod(id uint) (dto.AdmissionPeriodDTO, error) {
+	period, err := s.getPeriod(id)
+	if err != nil {
+		return dto.AdmissionPeriodDTO{}, err
+	}
+	return dto.AdmissionPeriodModelToDTO(period, time.Now()), nil
+}
+
+func (s *admissionPeriodService) CreatePeriod(d dto.AdmissionPeriodDTO) (dto.AdmissionPeriodDTO, error) {
+	period, err := s.validate(0, d)
+	if err != nil {
+		return dto.AdmissionPeriodDTO{}, err
+	}
+	period.ID = 0 // assigned by the database
+
+	if err := s.repo.Create(&period); err != nil {
+		logrus.WithError(err).Error("failed create admission period")
+		return dto.AdmissionPeriodDTO{}, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"id":            period.ID,
+		"academic_year": period.AcademicYear,
+		"wave_name":     period.WaveName,
+	}).Info("admission period created")
+	return dto.AdmissionPeriodModelToDTO(period, time.Now()), nil
+}
+
+func (s *admissionPeriodService) UpdatePeriod(id uint, d dto.AdmissionPeriodDTO) (dto.AdmissionPeriodDTO, error) {
+	if _, err := s.getPeriod(id); err != nil {
+		return dto.AdmissionPeriodDTO{}, err
+	}
+	period, err := s.validate(id, d)
+	if err != nil {
+		return dto.AdmissionPeriodDTO{}, err
+	}
+	period.ID = id
+
+	if err := s.repo.Update(period); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.AdmissionPeriodDTO{}, ErrNotFoundAdmissionPeriod
+		}
+		logrus.WithError(err).WithField("id", id).Error("failed update admission period")
+		return dto.AdmissionPeriodDTO{}, err
+	}
+
+	logrus.WithField("id", id).Info("admission period updated")
+	return s.GetPeriod(id)
+}
+
+func (s *admissionPeriodService) DeletePeriod(id uint) error {
+	count, err := s.repo.CountRegistrations(id)
+	if err != nil {
+		logrus.WithError(err).WithField("id", id).Error("failed count admission period registrations")
+		return err
+	}
+	if count > 0 {
+		return ErrAdmissionPeriodInUse
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFoundAdmissionPeriod
+		}
+		logrus.WithError(err).WithField("id", id).Error("failed delete admission period")
+		return err
+	}
+	logrus.WithField("id", id).Info("admission period deleted")
+	return nil
+}
+
+// validate checks the academic year, the dates and overlaps with other periods
+// (all but excludeID) and returns the period to store.
+func (s *admissionPeriodService) validate(excludeID uint, d dto.AdmissionPeriodDTO) (models.AdmissionPeriod, error) {
+	d.AcademicYear = strings.TrimSpace(d.AcademicYear)
+	d.WaveName = strings.TrimSpace(d.WaveName)
+
+	m := academicYearPattern.FindStringSubmatch(d.AcademicYear)
+	if m == nil {
+		return models.AdmissionPeriod{}, ErrInvalidAcademicYear
+	}
+	first, _ := strconv.Atoi(m[1])
+	second, _ := strconv.Atoi(m[2])
+	if second != first+1 {
+		return models.AdmissionPeriod{}, ErrInvalidAcademicYear
+	}
+
+	period, err := dto.AdmissionPeriodDTOToModel(d)
+	if err != nil {
+		return models.AdmissionPeriod{}, err
+	}
+	if !period.ClosesAt.After(period.OpensAt) {
+		return models.AdmissionPeriod{}, ErrInvalidAdmissionDates
+	}
+
+	overlaps, err := s.repo.Overlaps(period.OpensAt, period.ClosesAt, excludeID)
+	if err != nil {
+		logrus.WithError(err).Error("failed check admission period overlap")
+		return models.AdmissionPeriod{}, err
+	}
+	if overlaps {
+		return models.AdmissionPeriod{}, ErrAdmissionPeriodOverlaps
+	}
+	return period, nil
+}
+
+func (s *admissionPeriodService) getPeriod(id uint) (models.AdmissionPeriod, error) {
+	period, err := s.repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.AdmissionPeriod{}, ErrNotFoundAdmissionPeriod
+		}
+		logrus.WithError(err).WithField("id", id).Error("failed get admission period")
+		return models.AdmissionPeriod{}, err
+	}
+	return period, nil
+}
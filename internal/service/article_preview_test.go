@@ -0,0 +1,92 @@
+package service
+
+import (
+	"darulabror/internal/dto"
+	"darulabror/internal/models"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// previewArticleRepo keeps preview links in memory by token hash.
+type previewArticleRepo struct {
+	*fakeArticleRepo
+	links map[string]*models.ArticlePreviewLink
+}
+
+func (r *previewArticleRepo) CreatePreviewLink(link *models.ArticlePreviewLink) error {
+	link.ID = uint(len(r.links) + 1)
+	r.links[link.TokenHash] = link
+	return nil
+}
+
+func (r *previewArticleRepo) GetPreviewLinkByTokenHash(tokenHash string) (models.ArticlePreviewLink, error) {
+	link, ok := r.links[tokenHash]
+	if !ok {
+		return models.ArticlePreviewLink{}, gorm.ErrRecordNotFound
+	}
+	return *link, nil
+}
+
+func TestPreviewLinks(t *testing.T) {
+	repo := &previewArticleRepo{
+		fakeArticleRepo: &fakeArticleRepo{articles: map[uint]models.Article{
+			1: {ID: 1, Slug: "draf", Title: "Draf", Status: models.ArticleStatusDraft},
+		}},
+		links: map[string]*models.ArticlePreviewLink{},
+	}
+	s := &articleService{repo: repo, site: SiteConfig{BaseURL: "https://www.darulabror.com"}}
+
+	link, err := s.CreatePreviewLink(2, 1, dto.PreviewLinkCreateRequest{Note: "  Kepala sekolah "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(link.Token) != 43 || link.URL != "https://www.darulabror.com/articles/preview/"+link.Token {
+		t.Errorf("token %q, url %q", link.Token, link.URL)
+	}
+	if ttl := time.Until(time.Unix(link.ExpiresAt, 0)); ttl < defaultPreviewLinkTTL-time.Minute || ttl > defaultPreviewLinkTTL {
+		t.Errorf("default ttl = %v", ttl)
+	}
+	if !link.Active || link.Note != "Kepala sekolah" || link.CreatedBy != 2 {
+		t.Errorf("link = %+v", link)
+	}
+	stored := repo.links[hashSecretToken(link.Token)]
+	if stored == nil || strings.Contains(stored.TokenHash, link.Token) {
+		t.Fatal("token not stored as a hash")
+	}
+
+	// a draft is readable through the token
+	article, err := s.GetArticleByPreviewToken(link.Token)
+	if err != nil || article.Slug != "draf" {
+		t.Errorf("preview = %q, %v", article.Slug, err)
+	}
+
+	if _, err := s.GetArticleByPreviewToken(link.Token + "x"); !errors.Is(err, ErrNotFoundPreviewLink) {
+		t.Errorf("wrong token: %v", err)
+	}
+
+	revokedAt := time.Now().Unix()
+	stored.RevokedAt = &revokedAt
+	if _, err := s.GetArticleByPreviewToken(link.Token); !errors.Is(err, ErrPreviewLinkInactive) {
+		t.Errorf("revoked: %v", err)
+	}
+
+	short, err := s.CreatePreviewLink(2, 1, dto.PreviewLinkCreateRequest{ExpiresInHours: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ttl := time.Until(time.Unix(short.ExpiresAt, 0)); ttl > time.Hour || ttl < 59*time.Minute {
+		t.Errorf("ttl = %v, want 1h", ttl)
+	}
+	repo.links[hashSecretToken(short.Token)].ExpiresAt = time.Now().Unix()
+	if _, err := s.GetArticleByPreviewToken(short.Token); !errors.Is(err, ErrPreviewLinkInactive) {
+		t.Errorf("expired: %v", err)
+	}
+
+	if _, err := s.CreatePreviewLink(2, 9, dto.PreviewLinkCreateRequest{}); !errors.Is(err, ErrNotFoundArticle) {
+		t.Errorf("unknown article: %v", err)
+	}
+}
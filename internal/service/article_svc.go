@@ -1,50 +1,82 @@
 package service
 
 import (
-	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"darulabror/internal/content"
 	"darulabror/internal/dto"
+	"darulabror/internal/models"
 	"darulabror/internal/repository"
+	"darulabror/internal/utils"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
-	"io"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
 type ArticleService interface {
 	// Public
-	GetPublishedArticles(page, limit int) ([]dto.ArticleDTO, int64, error)
+	GetPublishedArticles(page, limit int, filter repository.ArticleFilter) ([]dto.ArticleDTO, int64, error)
 	GetPublishedArticleByID(id uint) (dto.ArticleDTO, error)
+	GetPublishedArticleBySlug(slug string) (dto.ArticleDTO, error)
+	SearchPublishedArticles(page, limit int, filter repository.ArticleFilter) ([]dto.ArticleSearchResultDTO, int64, error)
+	// RenderArticleContent fills ContentHTML / ContentText for format "html" / "text" ("" or "json" = no-op).
+	RenderArticleContent(article *dto.ArticleDTO, format string) error
 
 	// Admin
-	CreateArticle(articleDTO dto.ArticleDTO) error
-	GetAllArticles(page, limit int) ([]dto.ArticleDTO, int64, error)
-	UpdateArticle(id uint, articleDTO dto.ArticleDTO) error
+	CreateArticle(adminID uint, articleDTO dto.ArticleDTO) error
+	GetAllArticles(page, limit int, filter repository.ArticleFilter) ([]dto.ArticleDTO, int64, error)
+	UpdateArticle(adminID uint, id uint, articleDTO dto.ArticleDTO) error
 	DeleteArticle(id uint) error
 
-	// ======================
-	//  METHODS FOR GCS
-	// ======================
-	UploadArticleMedia(ctx context.Context, file io.Reader, fileName string) (string, error)
-	GetArticleMediaURL(ctx context.Context, objectName string) (string, error)
+	// Admin: revision history
+	GetArticleRevisions(articleID uint, page, limit int) ([]dto.ArticleRevisionDTO, int64, error)
+	GetArticleRevision(articleID uint, revision int) (dto.ArticleRevisionDTO, error)
+	DiffArticleRevisions(articleID uint, from, to int) (dto.ArticleRevisionDiffDTO, error)
+	RestoreArticleRevision(adminID uint, articleID uint, revision int) error
+
+	// Admin: preview links (share an unpublished article without an admin account)
+	CreatePreviewLink(adminID uint, articleID uint, req dto.PreviewLinkCreateRequest) (dto.PreviewLinkDTO, error)
+	GetPreviewLinks(articleID uint) ([]dto.PreviewLinkDTO, error)
+	RevokePreviewLink(articleID, linkID uint) error
+	// Public: any article (draft included) through an active preview token
+	GetArticleByPreviewToken(token string) (dto.ArticleDTO, error)
+
+	// Scheduler: publish due / unpublish expired articles, returns how many changed
+	ProcessSchedule(now time.Time) (published int, unpublished int, err error)
 }
 
 type articleService struct {
 	repo         repository.ArticleRepo
-	privateStore repository.GCPStorageRepo
+	taxonomyRepo repository.TaxonomyRepo
+	mediaRepo    repository.MediaRepo
+	site         SiteConfig
 }
 
-func NewArticleService(repo repository.ArticleRepo, privateStore repository.GCPStorageRepo) ArticleService {
+func NewArticleService(repo repository.ArticleRepo, taxonomyRepo repository.TaxonomyRepo, mediaRepo repository.MediaRepo, site SiteConfig) ArticleService {
 	return &articleService{
 		repo:         repo,
-		privateStore: privateStore,
+		taxonomyRepo: taxonomyRepo,
+		mediaRepo:    mediaRepo,
+		site:         site,
 	}
 }
 
-func (s *articleService) CreateArticle(articleDTO dto.ArticleDTO) error {
+// Preview link lifetime when the admin doesn't choose one.
+const defaultPreviewLinkTTL = 72 * time.Hour
+
+func (s *articleService) CreateArticle(adminID uint, articleDTO dto.ArticleDTO) error {
 	if articleDTO.Status == "" {
-		articleDTO.Status = "draft"
+		articleDTO.Status = models.ArticleStatusDraft
+		if articleDTO.PublishAt != nil {
+			articleDTO.Status = models.ArticleStatusScheduled
+		}
 	}
 
 	article, err := dto.ArticleDTOToModel(articleDTO)
@@ -53,17 +85,40 @@ func (s *articleService) CreateArticle(articleDTO dto.ArticleDTO) error {
 		return err
 	}
 
-	if err := s.repo.Create(article); err != nil {
+	slugSource := articleDTO.Slug
+	if slugSource == "" {
+		slugSource = articleDTO.Title
+	}
+	article.Slug, err = s.uniqueSlug(slugSource, 0)
+	if err != nil {
+		return err
+	}
+
+	if err := normalizeSchedule(&article, time.Now().Unix(), true); err != nil {
+		return err
+	}
+	if err := s.resolveTaxonomy(&article, articleDTO); err != nil {
+		return err
+	}
+	if err := s.resolveMedia(&article); err != nil {
+		return err
+	}
+	article.Excerpt = content.Excerpt(article.Content, content.ExcerptLength)
+
+	if err := s.repo.Create(article, repository.RevisionMeta{AdminID: adminID}); err != nil {
 		logrus.WithError(err).WithField("title", article.Title).Error("failed to create article")
 		return ErrCreateArticle
 	}
 
-	logrus.WithField("title", article.Title).Info("article created")
+	logrus.WithFields(logrus.Fields{
+		"title": article.Title,
+		"slug":  article.Slug,
+	}).Info("article created")
 	return nil
 }
 
-func (s *articleService) GetAllArticles(page, limit int) ([]dto.ArticleDTO, int64, error) {
-	articles, total, err := s.repo.GetAll(page, limit)
+func (s *articleService) GetAllArticles(page, limit int, filter repository.ArticleFilter) ([]dto.ArticleDTO, int64, error) {
+	articles, total, err := s.repo.GetAll(page, limit, filter)
 	if err != nil {
 		logrus.WithError(err).Error("failed get all articles")
 		return nil, 0, err
@@ -76,8 +131,8 @@ func (s *articleService) GetAllArticles(page, limit int) ([]dto.ArticleDTO, int6
 	return out, total, nil
 }
 
-func (s *articleService) GetPublishedArticles(page, limit int) ([]dto.ArticleDTO, int64, error) {
-	articles, total, err := s.repo.GetPublished(page, limit)
+func (s *articleService) GetPublishedArticles(page, limit int, filter repository.ArticleFilter) ([]dto.ArticleDTO, int64, error) {
+	articles, total, err := s.repo.GetPublished(page, limit, filter)
 	if err != nil {
 		logrus.WithError(err).Error("failed get published articles")
 		return nil, 0, err
@@ -90,6 +145,42 @@ func (s *articleService) GetPublishedArticles(page, limit int) ([]dto.ArticleDTO
 	return out, total, nil
 }
 
+func (s *articleService) SearchPublishedArticles(page, limit int, filter repository.ArticleFilter) ([]dto.ArticleSearchResultDTO, int64, error) {
+	if filter.Query == "" {
+		return nil, 0, ErrInvalidSearchQuery
+	}
+
+	results, total, err := s.repo.SearchPublished(page, limit, filter)
+	if err != nil {
+		logrus.WithError(err).WithField("q", filter.Query).Error("failed search published articles")
+		return nil, 0, err
+	}
+
+	out := make([]dto.ArticleSearchResultDTO, 0, len(results))
+	for _, r := range results {
+		out = append(out, dto.ArticleSearchResultDTO{
+			ArticleDTO:     dto.ArticleModelToDTO(r.Article),
+			Rank:           r.Rank,
+			TitleHighlight: r.TitleHighlight,
+			Snippet:        r.Snippet,
+		})
+	}
+	return out, total, nil
+}
+
+func (s *articleService) RenderArticleContent(article *dto.ArticleDTO, format string) error {
+	switch format {
+	case "", "json":
+	case "html":
+		article.ContentHTML = content.RenderHTML(article.Content)
+	case "text":
+		article.ContentText = content.RenderText(article.Content)
+	default:
+		return ErrInvalidContentFormat
+	}
+	return nil
+}
+
 func (s *articleService) GetPublishedArticleByID(id uint) (dto.ArticleDTO, error) {
 	article, err := s.repo.GetByID(id)
 	if err != nil {
@@ -100,13 +191,45 @@ func (s *articleService) GetPublishedArticleByID(id uint) (dto.ArticleDTO, error
 		return dto.ArticleDTO{}, err
 	}
 
-	if article.Status != "published" {
+	if !isPubliclyVisible(article, time.Now().Unix()) {
 		return dto.ArticleDTO{}, ErrNotFoundArticle
 	}
 	return dto.ArticleModelToDTO(article), nil
 }
 
-func (s *articleService) UpdateArticle(id uint, articleDTO dto.ArticleDTO) error {
+// GetPublishedArticleBySlug resolves the current slug first, then old slugs.
+// For an old slug it returns ErrArticleSlugMoved with only Slug filled (the current one),
+// so the handler can redirect.
+func (s *articleService) GetPublishedArticleBySlug(slug string) (dto.ArticleDTO, error) {
+	article, err := s.repo.GetBySlug(slug)
+	if err == nil {
+		if !isPubliclyVisible(article, time.Now().Unix()) {
+			return dto.ArticleDTO{}, ErrNotFoundArticle
+		}
+		return dto.ArticleModelToDTO(article), nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logrus.WithError(err).WithField("slug", slug).Error("failed get article by slug")
+		return dto.ArticleDTO{}, err
+	}
+
+	redirect, err := s.repo.GetSlugRedirect(slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.ArticleDTO{}, ErrNotFoundArticle
+		}
+		logrus.WithError(err).WithField("slug", slug).Error("failed get article slug redirect")
+		return dto.ArticleDTO{}, err
+	}
+
+	target, err := s.GetPublishedArticleByID(redirect.ArticleID)
+	if err != nil {
+		return dto.ArticleDTO{}, err
+	}
+	return dto.ArticleDTO{Slug: target.Slug}, ErrArticleSlugMoved
+}
+
+func (s *articleService) UpdateArticle(adminID uint, id uint, articleDTO dto.ArticleDTO) error {
 	article, err := s.repo.GetByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -115,20 +238,61 @@ func (s *articleService) UpdateArticle(id uint, articleDTO dto.ArticleDTO) error
 		return err
 	}
 
+	oldSlug := article.Slug
+
+	// explicit slug wins; otherwise a rename regenerates it from the new title
+	switch {
+	case articleDTO.Slug != "":
+		if utils.Slugify(articleDTO.Slug) != oldSlug {
+			if article.Slug, err = s.uniqueSlug(articleDTO.Slug, article.ID); err != nil {
+				return err
+			}
+		}
+	case articleDTO.Title != article.Title || oldSlug == "":
+		if article.Slug, err = s.uniqueSlug(articleDTO.Title, article.ID); err != nil {
+			return err
+		}
+	}
+
 	article.Title = articleDTO.Title
 	article.PhotoHeader = articleDTO.PhotoHeader
 	article.Content = articleDTO.Content
 	article.Author = articleDTO.Author
 	if articleDTO.Status != "" {
 		article.Status = articleDTO.Status
+	} else if articleDTO.PublishAt != nil {
+		article.Status = models.ArticleStatusScheduled
+	}
+	// omitted times keep the stored schedule; publish_at goes away with the
+	// scheduled status, unpublish_at with clear_unpublish_at
+	if articleDTO.PublishAt != nil {
+		article.PublishAt = articleDTO.PublishAt
 	}
+	unpublishChanged := articleDTO.UnpublishAt != nil || articleDTO.ClearUnpublishAt
+	if unpublishChanged {
+		article.UnpublishAt = articleDTO.UnpublishAt
+	}
+	if err := normalizeSchedule(&article, time.Now().Unix(), unpublishChanged); err != nil {
+		return err
+	}
+	if err := s.resolveTaxonomy(&article, articleDTO); err != nil {
+		return err
+	}
+	if err := s.resolveMedia(&article); err != nil {
+		return err
+	}
+	article.Excerpt = content.Excerpt(article.Content, content.ExcerptLength)
 
-	if err := s.repo.Update(article); err != nil {
+	if err := s.repo.UpdateWithRevision(article, oldSlug, repository.RevisionMeta{AdminID: adminID}); err != nil {
 		logrus.WithError(err).WithField("id", id).Error("failed update article")
 		return ErrUpdateArticle
 	}
 
-	logrus.WithField("id", id).Info("article updated")
+	logrus.WithFields(logrus.Fields{
+		"id":       id,
+		"slug":     article.Slug,
+		"admin_id": adminID,
+	}).Info("article updated")
 	return nil
 }
 
@@ -141,24 +305,408 @@ func (s *articleService) DeleteArticle(id uint) error {
 	return nil
 }
 
-// ======================
-//  METHODS FOR GCS
-// ======================
+func (s *articleService) GetArticleRevisions(articleID uint, page, limit int) ([]dto.ArticleRevisionDTO, int64, error) {
+	if _, err := s.getArticle(articleID); err != nil {
+		return nil, 0, err
+	}
 
-func (s *articleService) UploadArticleMedia(ctx context.Context, file io.Reader, fileName string) (string, error) {
-	objectName, err := s.privateStore.UploadFile(ctx, file, fileName)
+	revisions, total, err := s.repo.GetRevisions(articleID, page, limit)
 	if err != nil {
-		logrus.WithError(err).WithField("fileName", fileName).Error("failed upload article media")
-		return "", err
+		logrus.WithError(err).WithField("article_id", articleID).Error("failed get article revisions")
+		return nil, 0, err
+	}
+
+	out := make([]dto.ArticleRevisionDTO, 0, len(revisions))
+	for _, r := range revisions {
+		out = append(out, dto.ArticleRevisionModelToDTO(r))
+	}
+	return out, total, nil
+}
+
+func (s *articleService) GetArticleRevision(articleID uint, revision int) (dto.ArticleRevisionDTO, error) {
+	rev, err := s.getRevision(articleID, revision)
+	if err != nil {
+		return dto.ArticleRevisionDTO{}, err
+	}
+	return dto.ArticleRevisionModelToDTO(rev), nil
+}
+
+func (s *articleService) DiffArticleRevisions(articleID uint, from, to int) (dto.ArticleRevisionDiffDTO, error) {
+	fromRev, err := s.getRevision(articleID, from)
+	if err != nil {
+		return dto.ArticleRevisionDiffDTO{}, err
+	}
+	toRev, err := s.getRevision(articleID, to)
+	if err != nil {
+		return dto.ArticleRevisionDiffDTO{}, err
+	}
+	return diffRevisions(fromRev, toRev), nil
+}
+
+// RestoreArticleRevision makes the revision's title, photo header, content and author
+// current again, recorded as a new revision. Slug, status, schedule and taxonomy
+// are left as they are, so restoring never publishes or moves an article by itself.
+func (s *articleService) RestoreArticleRevision(adminID uint, articleID uint, revision int) error {
+	article, err := s.getArticle(articleID)
+	if err != nil {
+		return err
+	}
+	rev, err := s.getRevision(articleID, revision)
+	if err != nil {
+		return err
+	}
+
+	article.Title = rev.Title
+	article.PhotoHeader = rev.PhotoHeader
+	article.Content = rev.Content
+	article.Author = rev.Author
+	if err := s.resolveMedia(&article); err != nil {
+		return err
+	}
+	article.Excerpt = content.Excerpt(article.Content, content.ExcerptLength)
+
+	meta := repository.RevisionMeta{AdminID: adminID, RestoredFrom: &rev.Revision}
+	if err := s.repo.UpdateWithRevision(article, article.Slug, meta); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"id":       articleID,
+			"revision": revision,
+		}).Error("failed restore article revision")
+		return ErrUpdateArticle
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"id":       articleID,
+		"revision": revision,
+		"admin_id": adminID,
+	}).Info("article revision restored")
+	return nil
+}
+
+func (s *articleService) CreatePreviewLink(adminID uint, articleID uint, req dto.PreviewLinkCreateRequest) (dto.PreviewLinkDTO, error) {
+	if _, err := s.getArticle(articleID); err != nil {
+		return dto.PreviewLinkDTO{}, err
+	}
+
+	ttl := defaultPreviewLinkTTL
+	if req.ExpiresInHours > 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+
+	token, err := newSecretToken()
+	if err != nil {
+		logrus.WithError(err).Error("failed generate preview token")
+		return dto.PreviewLinkDTO{}, err
+	}
+
+	now := time.Now()
+	link := models.ArticlePreviewLink{
+		ArticleID: articleID,
+		TokenHash: hashSecretToken(token),
+		Note:      strings.TrimSpace(req.Note),
+		CreatedBy: adminID,
+		ExpiresAt: now.Add(ttl).Unix(),
+	}
+	if err := s.repo.CreatePreviewLink(&link); err != nil {
+		logrus.WithError(err).WithField("article_id", articleID).Error("failed create preview link")
+		return dto.PreviewLinkDTO{}, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"article_id": articleID,
+		"link_id":    link.ID,
+		"admin_id":   adminID,
+		"expires_at": link.ExpiresAt,
+	}).Info("article preview link created")
+
+	out := dto.PreviewLinkModelToDTO(link, now.Unix())
+	out.Token = token
+	if s.site.Configured() {
+		out.URL = s.site.URL("/articles/preview/" + token)
 	}
-	return objectName, nil
+	return out, nil
 }
 
-func (s *articleService) GetArticleMediaURL(ctx context.Context, objectName string) (string, error) {
-	url, err := s.privateStore.GenerateSignedURL(ctx, objectName, 10*time.Minute)
+func (s *articleService) GetPreviewLinks(articleID uint) ([]dto.PreviewLinkDTO, error) {
+	if _, err := s.getArticle(articleID); err != nil {
+		return nil, err
+	}
+
+	links, err := s.repo.GetPreviewLinks(articleID)
 	if err != nil {
-		logrus.WithError(err).WithField("objectName", objectName).Error("failed generate article signed url")
+		logrus.WithError(err).WithField("article_id", articleID).Error("failed get preview links")
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	out := make([]dto.PreviewLinkDTO, 0, len(links))
+	for _, l := range links {
+		out = append(out, dto.PreviewLinkModelToDTO(l, now))
+	}
+	return out, nil
+}
+
+func (s *articleService) RevokePreviewLink(articleID, linkID uint) error {
+	if err := s.repo.RevokePreviewLink(articleID, linkID, time.Now().Unix()); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFoundPreviewLink
+		}
+		logrus.WithError(err).WithField("link_id", linkID).Error("failed revoke preview link")
+		return err
+	}
+	logrus.WithFields(logrus.Fields{"article_id": articleID, "link_id": linkID}).Info("article preview link revoked")
+	return nil
+}
+
+// GetArticleByPreviewToken returns the article as the public endpoints would, whatever its status.
+func (s *articleService) GetArticleByPreviewToken(token string) (dto.ArticleDTO, error) {
+	link, err := s.repo.GetPreviewLinkByTokenHash(hashSecretToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.ArticleDTO{}, ErrNotFoundPreviewLink
+		}
+		logrus.WithError(err).Error("failed get preview link")
+		return dto.ArticleDTO{}, err
+	}
+	if link.RevokedAt != nil || link.ExpiresAt <= time.Now().Unix() {
+		return dto.ArticleDTO{}, ErrPreviewLinkInactive
+	}
+
+	article, err := s.getArticle(link.ArticleID)
+	if err != nil {
+		return dto.ArticleDTO{}, err
+	}
+	return dto.ArticleModelToDTO(article), nil
+}
+
+// newSecretToken returns 32 random bytes, base64url-encoded (43 chars). Used for
+// preview links and registration upload tokens; only hashSecretToken is stored.
+func newSecretToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
 		return "", err
 	}
-	return url, nil
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *articleService) getArticle(id uint) (models.Article, error) {
+	article, err := s.repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Article{}, ErrNotFoundArticle
+		}
+		logrus.WithError(err).WithField("id", id).Error("failed get article by id")
+		return models.Article{}, err
+	}
+	return article, nil
+}
+
+func (s *articleService) getRevision(articleID uint, revision int) (models.ArticleRevision, error) {
+	rev, err := s.repo.GetRevision(articleID, revision)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ArticleRevision{}, ErrNotFoundArticleRevision
+		}
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"article_id": articleID,
+			"revision":   revision,
+		}).Error("failed get article revision")
+		return models.ArticleRevision{}, err
+	}
+	return rev, nil
+}
+
+func (s *articleService) ProcessSchedule(now time.Time) (int, int, error) {
+	published, err := s.repo.PublishDue(now.Unix())
+	if err != nil {
+		logrus.WithError(err).Error("failed publish due articles")
+		return 0, 0, err
+	}
+	for _, a := range published {
+		logrus.WithFields(logrus.Fields{
+			"id":    a.ID,
+			"slug":  a.Slug,
+			"title": a.Title,
+		}).Info("scheduled article published")
+	}
+
+	unpublished, err := s.repo.UnpublishExpired(now.Unix())
+	if err != nil {
+		logrus.WithError(err).Error("failed unpublish expired articles")
+		return len(published), 0, err
+	}
+	for _, a := range unpublished {
+		logrus.WithFields(logrus.Fields{
+			"id":    a.ID,
+			"slug":  a.Slug,
+			"title": a.Title,
+		}).Info("expired article unpublished")
+	}
+
+	return len(published), len(unpublished), nil
+}
+
+// normalizeSchedule validates publish_at / unpublish_at against the status.
+// A scheduled article whose publish_at already passed is published right away;
+// publish_at is only kept for scheduled articles. An unpublish_at that wasn't
+// changed may have passed already (the scheduler hasn't unpublished yet).
+func normalizeSchedule(article *models.Article, now int64, unpublishChanged bool) error {
+	switch article.Status {
+	case models.ArticleStatusScheduled:
+		if article.PublishAt == nil {
+			return ErrInvalidArticleSchedule
+		}
+		if *article.PublishAt <= now {
+			article.Status = models.ArticleStatusPublished
+		}
+	default:
+		article.PublishAt = nil
+	}
+
+	if article.UnpublishAt != nil {
+		if unpublishChanged && *article.UnpublishAt <= now {
+			return ErrInvalidArticleSchedule
+		}
+		if article.PublishAt != nil && *article.UnpublishAt <= *article.PublishAt {
+			return ErrInvalidArticleSchedule
+		}
+	}
+	return nil
+}
+
+// isPubliclyVisible mirrors the repository's publishedCondition for a single article.
+func isPubliclyVisible(article models.Article, now int64) bool {
+	if article.UnpublishAt != nil && *article.UnpublishAt <= now {
+		return false
+	}
+	switch article.Status {
+	case models.ArticleStatusPublished:
+		return true
+	case models.ArticleStatusScheduled:
+		return article.PublishAt != nil && *article.PublishAt <= now
+	default:
+		return false
+	}
+}
+
+// resolveTaxonomy loads the categories/tags referenced by articleDTO into article.
+// nil ID lists leave the article's current assignment untouched.
+func (s *articleService) resolveTaxonomy(article *models.Article, articleDTO dto.ArticleDTO) error {
+	if articleDTO.CategoryIDs != nil {
+		ids := uniqueIDs(articleDTO.CategoryIDs)
+		categories, err := s.taxonomyRepo.GetCategoriesByIDs(ids)
+		if err != nil {
+			logrus.WithError(err).Error("failed get categories by ids")
+			return err
+		}
+		if len(categories) != len(ids) {
+			return ErrInvalidCategory
+		}
+		article.Categories = categories
+	}
+
+	if articleDTO.TagIDs != nil {
+		ids := uniqueIDs(articleDTO.TagIDs)
+		tags, err := s.taxonomyRepo.GetTagsByIDs(ids)
+		if err != nil {
+			logrus.WithError(err).Error("failed get tags by ids")
+			return err
+		}
+		if len(tags) != len(ids) {
+			return ErrInvalidTag
+		}
+		article.Tags = tags
+	}
+	return nil
+}
+
+// resolveMedia links the library items the article uses, referenced by media_id in
+// content or by URL (content or header), refreshes the url/srcset of blocks that
+// reference an item by ID and sets the header's srcset. Unknown media IDs are rejected.
+func (s *articleService) resolveMedia(article *models.Article) error {
+	ids, urls := content.MediaRefs(article.Content)
+	header := strings.TrimSpace(article.PhotoHeader)
+	if header != "" {
+		urls = append(urls, header)
+	}
+
+	items, err := s.mediaRepo.GetByRefs(ids, urls)
+	if err != nil {
+		logrus.WithError(err).Error("failed get media by refs")
+		return err
+	}
+
+	refs := make(map[uint]content.MediaRef, len(items))
+	article.PhotoHeaderSrcset = datatypes.JSONSlice[models.ImageSource]{}
+	for _, m := range items {
+		ref := content.MediaRef{URL: m.URL, Srcset: m.Srcset()}
+		if m.Width != nil && m.Height != nil {
+			ref.Width, ref.Height = *m.Width, *m.Height
+		}
+		refs[m.ID] = ref
+
+		if header != "" && m.URL == header {
+			article.PhotoHeaderSrcset = m.Srcset()
+		}
+	}
+	for _, id := range ids {
+		if _, ok := refs[id]; !ok {
+			return ErrInvalidMedia
+		}
+	}
+
+	if len(ids) > 0 {
+		resolved, err := content.ResolveMedia(article.Content, refs)
+		if err != nil {
+			return err
+		}
+		article.Content = resolved
+	}
+	article.Media = items
+	return nil
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	out := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out
+}
+
+// uniqueSlug slugifies source and appends -2, -3, ... until it doesn't collide
+// with another article (excludeID is the article being edited, 0 on create).
+func (s *articleService) uniqueSlug(source string, excludeID uint) (string, error) {
+	base := utils.Slugify(source)
+	if base == "" {
+		return "", ErrInvalidArticleSlug
+	}
+
+	candidate := base
+	for n := 2; ; n++ {
+		exists, err := s.repo.SlugExists(candidate, excludeID)
+		if err != nil {
+			logrus.WithError(err).WithField("slug", candidate).Error("failed check article slug")
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+
+		suffix := "-" + strconv.Itoa(n)
+		trimmed := base
+		if len(trimmed)+len(suffix) > utils.MaxSlugLength {
+			trimmed = trimmed[:utils.MaxSlugLength-len(suffix)]
+		}
+		candidate = trimmed + suffix
+	}
 }
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"darulabror/internal/dto"
+	"darulabror/internal/models"
+	"darulabror/internal/repository"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// fakeArticleRepo keeps articles in memory; methods a test doesn't need
+// panic through the embedded nil interface.
+type fakeArticleRepo struct {
+	repository.ArticleRepo
+	articles  map[uint]models.Article
+	redirects map[string]uint  // old slug -> article id
+	published []models.Article // GetPublished result, in order
+}
+
+func (f *fakeArticleRepo) GetPublished(page, limit int, filter repository.ArticleFilter) ([]models.Article, int64, error) {
+	return f.published, int64(len(f.published)), nil
+}
+
+func (f *fakeArticleRepo) GetByID(id uint) (models.Article, error) {
+	a, ok := f.articles[id]
+	if !ok {
+		return models.Article{}, gorm.ErrRecordNotFound
+	}
+	return a, nil
+}
+
+func (f *fakeArticleRepo) GetBySlug(slug string) (models.Article, error) {
+	for _, a := range f.articles {
+		if a.Slug == slug {
+			return a, nil
+		}
+	}
+	return models.Article{}, gorm.ErrRecordNotFound
+}
+
+func (f *fakeArticleRepo) SlugExists(slug string, excludeID uint) (bool, error) {
+	for _, a := range f.articles {
+		if a.Slug == slug && a.ID != excludeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeArticleRepo) UpdateWithRevision(article models.Article, oldSlug string, meta repository.RevisionMeta) error {
+	f.articles[article.ID] = article
+	return nil
+}
+
+func (f *fakeArticleRepo) GetSlugRedirect(oldSlug string) (models.ArticleSlugRedirect, error) {
+	id, ok := f.redirects[oldSlug]
+	if !ok {
+		return models.ArticleSlugRedirect{}, gorm.ErrRecordNotFound
+	}
+	return models.ArticleSlugRedirect{OldSlug: oldSlug, ArticleID: id}, nil
+}
+
+func TestGetPublishedArticleBySlug(t *testing.T) {
+	repo := &fakeArticleRepo{
+		articles: map[uint]models.Article{
+			1: {ID: 1, Slug: "jadwal-ujian-2025", Status: models.ArticleStatusPublished},
+			2: {ID: 2, Slug: "draf-pengumuman", Status: models.ArticleStatusDraft},
+		},
+		redirects: map[string]uint{
+			"jadwal-ujian":     1,
+			"jadwal-ujian-old": 1,
+			"pengumuman":       2,
+			"dihapus":          3,
+		},
+	}
+	s := &articleService{repo: repo}
+
+	tests := []struct {
+		slug     string
+		wantSlug string
+		wantErr  error
+	}{
+		{"jadwal-ujian-2025", "jadwal-ujian-2025", nil},
+		{"jadwal-ujian", "jadwal-ujian-2025", ErrArticleSlugMoved},
+		{"jadwal-ujian-old", "jadwal-ujian-2025", ErrArticleSlugMoved},
+		{"draf-pengumuman", "", ErrNotFoundArticle}, // not published
+		{"pengumuman", "", ErrNotFoundArticle},      // old slug of a draft: no redirect
+		{"dihapus", "", ErrNotFoundArticle},         // article gone
+		{"tidak-ada", "", ErrNotFoundArticle},
+	}
+	for _, tt := range tests {
+		got, err := s.GetPublishedArticleBySlug(tt.slug)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tt.slug, err, tt.wantErr)
+			continue
+		}
+		if got.Slug != tt.wantSlug {
+			t.Errorf("%s: slug = %q, want %q", tt.slug, got.Slug, tt.wantSlug)
+		}
+	}
+}
+
+func TestUniqueSlug(t *testing.T) {
+	repo := &fakeArticleRepo{articles: map[uint]models.Article{
+		1: {ID: 1, Slug: "libur-semester"},
+		2: {ID: 2, Slug: "libur-semester-2"},
+	}}
+	s := &articleService{repo: repo}
+
+	tests := []struct {
+		source    string
+		excludeID uint
+		want      string
+		wantErr   error
+	}{
+		{"Libur Semester", 0, "libur-semester-3", nil},
+		{"Libur Semester", 1, "libur-semester", nil}, // its own slug
+		{"Libur Semester!", 2, "libur-semester-2", nil},
+		{"Wisuda", 0, "wisuda", nil},
+		{"???", 0, "", ErrInvalidArticleSlug},
+	}
+	for _, tt := range tests {
+		got, err := s.uniqueSlug(tt.source, tt.excludeID)
+		if !errors.Is(err, tt.wantErr) || got != tt.want {
+			t.Errorf("uniqueSlug(%q, %d) = %q, %v; want %q, %v", tt.source, tt.excludeID, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+type fakeMediaRepo struct {
+	repository.MediaRepo
+	items []models.Media
+}
+
+func (f *fakeMediaRepo) GetByRefs(ids []uint, urls []string) ([]models.Media, error) {
+	var out []models.Media
+	for _, m := range f.items {
+		for _, id := range ids {
+			if m.ID == id {
+				out = append(out, m)
+			}
+		}
+	}
+	return out, nil
+}
+
+func at(v int64) *int64 { return &v }
+
+func TestNormalizeSchedule(t *testing.T) {
+	const now = 1000
+
+	tests := []struct {
+		name             string
+		article          models.Article
+		unpublishChanged bool
+		wantStatus       string
+		wantPublishAt    *int64
+		wantErr          error
+	}{
+		{"draft drops publish_at",
+			models.Article{Status: models.ArticleStatusDraft, PublishAt: at(2000)}, true,
+			models.ArticleStatusDraft, nil, nil},
+		{"scheduled without publish_at",
+			models.Article{Status: models.ArticleStatusScheduled}, true,
+			"", nil, ErrInvalidArticleSchedule},
+		{"scheduled in the future",
+			models.Article{Status: models.ArticleStatusScheduled, PublishAt: at(2000)}, true,
+			models.ArticleStatusScheduled, at(2000), nil},
+		{"scheduled in the past goes live",
+			models.Article{Status: models.ArticleStatusScheduled, PublishAt: at(500)}, true,
+			models.ArticleStatusPublished, at(500), nil},
+		{"published keeps no publish_at",
+			models.Article{Status: models.ArticleStatusPublished, PublishAt: at(500)}, true,
+			models.ArticleStatusPublished, nil, nil},
+		{"new unpublish_at in the past",
+			models.Article{Status: models.ArticleStatusPublished, UnpublishAt: at(900)}, true,
+			"", nil, ErrInvalidArticleSchedule},
+		{"stored unpublish_at in the past is kept",
+			models.Article{Status: models.ArticleStatusPublished, UnpublishAt: at(900)}, false,
+			models.ArticleStatusPublished, nil, nil},
+		{"unpublish before publish",
+			models.Article{Status: models.ArticleStatusScheduled, PublishAt: at(3000), UnpublishAt: at(2000)}, true,
+			"", nil, ErrInvalidArticleSchedule},
+		{"unpublish at publish",
+			models.Article{Status: models.ArticleStatusScheduled, PublishAt: at(3000), UnpublishAt: at(3000)}, false,
+			"", nil, ErrInvalidArticleSchedule},
+		{"unpublish after publish",
+			models.Article{Status: models.ArticleStatusScheduled, PublishAt: at(2000), UnpublishAt: at(3000)}, true,
+			models.ArticleStatusScheduled, at(2000), nil},
+	}
+	for _, tt := range tests {
+		article := tt.article
+		err := normalizeSchedule(&article, now, tt.unpublishChanged)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if article.Status != tt.wantStatus {
+			t.Errorf("%s: status = %q, want %q", tt.name, article.Status, tt.wantStatus)
+		}
+		if !sameTime(article.PublishAt, tt.wantPublishAt) {
+			t.Errorf("%s: publish_at = %v, want %v", tt.name, article.PublishAt, tt.wantPublishAt)
+		}
+	}
+}
+
+func TestIsPubliclyVisible(t *testing.T) {
+	const now = 1000
+
+	tests := []struct {
+		name    string
+		article models.Article
+		want    bool
+	}{
+		{"published", models.Article{Status: models.ArticleStatusPublished}, true},
+		{"draft", models.Article{Status: models.ArticleStatusDraft}, false},
+		{"scheduled, due", models.Article{Status: models.ArticleStatusScheduled, PublishAt: at(1000)}, true},
+		{"scheduled, not yet", models.Article{Status: models.ArticleStatusScheduled, PublishAt: at(1001)}, false},
+		{"scheduled without time", models.Article{Status: models.ArticleStatusScheduled}, false},
+		{"expired", models.Article{Status: models.ArticleStatusPublished, UnpublishAt: at(1000)}, false},
+		{"expires later", models.Article{Status: models.ArticleStatusPublished, UnpublishAt: at(1001)}, true},
+	}
+	for _, tt := range tests {
+		if got := isPubliclyVisible(tt.article, now); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestUpdateArticleSchedule(t *testing.T) {
+	now := time.Now().Unix()
+	publishAt, unpublishAt := now+3600, now+7200
+
+	tests := []struct {
+		name            string
+		update          dto.ArticleDTO
+		wantStatus      string
+		wantPublishAt   *int64
+		wantUnpublishAt *int64
+		wantErr         error
+	}{
+		{"omitted times are kept", dto.ArticleDTO{},
+			models.ArticleStatusScheduled, &publishAt, &unpublishAt, nil},
+		{"new publish_at", dto.ArticleDTO{PublishAt: at(now + 60)},
+			models.ArticleStatusScheduled, at(now + 60), &unpublishAt, nil},
+		{"clear_unpublish_at", dto.ArticleDTO{ClearUnpublishAt: true},
+			models.ArticleStatusScheduled, &publishAt, nil, nil},
+		{"back to draft", dto.ArticleDTO{Status: models.ArticleStatusDraft},
+			models.ArticleStatusDraft, nil, &unpublishAt, nil},
+		{"unpublish before publish", dto.ArticleDTO{UnpublishAt: at(now + 60)},
+			"", nil, nil, ErrInvalidArticleSchedule},
+	}
+	for _, tt := range tests {
+		repo := &fakeArticleRepo{articles: map[uint]models.Article{1: {
+			ID: 1, Title: "Pengumuman", Slug: "pengumuman", Content: []byte(`{"blocks":[]}`),
+			Status: models.ArticleStatusScheduled, PublishAt: at(publishAt), UnpublishAt: at(unpublishAt),
+		}}}
+		s := &articleService{repo: repo, mediaRepo: &fakeMediaRepo{}}
+
+		update := tt.update
+		update.Title = "Pengumuman"
+		update.Content = []byte(`{"blocks":[]}`)
+		err := s.UpdateArticle(1, 1, update)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		got := repo.articles[1]
+		if got.Status != tt.wantStatus {
+			t.Errorf("%s: status = %q, want %q", tt.name, got.Status, tt.wantStatus)
+		}
+		if !sameTime(got.PublishAt, tt.wantPublishAt) {
+			t.Errorf("%s: publish_at = %v, want %v", tt.name, got.PublishAt, tt.wantPublishAt)
+		}
+		if !sameTime(got.UnpublishAt, tt.wantUnpublishAt) {
+			t.Errorf("%s: unpublish_at = %v, want %v", tt.name, got.UnpublishAt, tt.wantUnpublishAt)
+		}
+	}
+}
+
+func sameTime(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
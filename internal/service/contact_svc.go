@@ -22,11 +22,12 @@ type ContactService interface {
 }
 
 type contactService struct {
-	repo repository.ContactRepository
+	repo   repository.ContactRepository
+	notify NotificationService
 }
 
-func NewContactService(repo repository.ContactRepository) ContactService {
-	return &contactService{repo: repo}
+func NewContactService(repo repository.ContactRepository, notify NotificationService) ContactService {
+	return &contactService{repo: repo, notify: notify}
 }
 
 func (s *contactService) CreateContact(email, subject, message string) error {
@@ -42,6 +43,8 @@ func (s *contactService) CreateContact(email, subject, message string) error {
 		"email":   email,
 		"subject": subject,
 	}).Info("contact created")
+
+	s.notify.ContactReceived(email)
 	return nil
 }
 
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"bytes"
+	"darulabror/internal/dto"
+	"darulabror/internal/models"
+	"encoding/json"
+	"sort"
+)
+
+// diffRevisions compares two revisions: scalar fields plus a block-level diff of content.
+func diffRevisions(from, to models.ArticleRevision) dto.ArticleRevisionDiffDTO {
+	fields := make([]dto.FieldChangeDTO, 0)
+	for _, f := range []struct{ name, a, b string }{
+		{"title", from.Title, to.Title},
+		{"slug", from.Slug, to.Slug},
+		{"photo_header", from.PhotoHeader, to.PhotoHeader},
+		{"author", from.Author, to.Author},
+		{"status", from.Status, to.Status},
+	} {
+		if f.a != f.b {
+			fields = append(fields, dto.FieldChangeDTO{Field: f.name, From: f.a, To: f.b})
+		}
+	}
+
+	return dto.ArticleRevisionDiffDTO{
+		ArticleID: to.ArticleID,
+		From:      from.Revision,
+		To:        to.Revision,
+		Fields:    fields,
+		Blocks:    diffBlocks(contentBlocks(from.Content), contentBlocks(to.Content)),
+	}
+}
+
+// contentBlock is one top-level content block, kept in canonical JSON so equal
+// blocks compare equal regardless of key order/whitespace.
+type contentBlock struct {
+	raw  json.RawMessage
+	typ  string
+	keys map[string]json.RawMessage // nil when the block isn't an object
+}
+
+// contentBlocks splits content into blocks: {"blocks":[...]}, a bare array, or
+// (for anything else) the whole document as a single block.
+func contentBlocks(content []byte) []contentBlock {
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil
+	}
+
+	var items []json.RawMessage
+	var doc struct {
+		Blocks []json.RawMessage `json:"blocks"`
+	}
+	switch {
+	case json.Unmarshal(content, &items) == nil:
+	case json.Unmarshal(content, &doc) == nil && doc.Blocks != nil:
+		items = doc.Blocks
+	default:
+		items = []json.RawMessage{content}
+	}
+
+	blocks := make([]contentBlock, 0, len(items))
+	for _, item := range items {
+		blocks = append(blocks, newContentBlock(item))
+	}
+	return blocks
+}
+
+func newContentBlock(item json.RawMessage) contentBlock {
+	var v any
+	if err := json.Unmarshal(item, &v); err != nil {
+		return contentBlock{raw: item}
+	}
+	canonical, _ := json.Marshal(v) // map keys are sorted by encoding/json
+	b := contentBlock{raw: canonical}
+
+	if obj, ok := v.(map[string]any); ok {
+		b.keys = make(map[string]json.RawMessage, len(obj))
+		for k, val := range obj {
+			b.keys[k], _ = json.Marshal(val)
+		}
+		b.typ, _ = obj["type"].(string)
+	}
+	return b
+}
+
+// diffBlocks runs an LCS over the two block lists. Blocks of the same type in the
+// same slot that don't match are reported as a single "changed" step.
+func diffBlocks(a, b []contentBlock) []dto.BlockChangeDTO {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if bytes.Equal(a[i].raw, b[j].raw) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	out := make([]dto.BlockChangeDTO, 0, max(n, m))
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && bytes.Equal(a[i].raw, b[j].raw):
+			out = append(out, dto.BlockChangeDTO{
+				Op: dto.BlockOpEqual, FromIndex: intPtr(i), ToIndex: intPtr(j), Type: b[j].typ,
+			})
+			i++
+			j++
+		case i < n && j < m && a[i].typ == b[j].typ && lcs[i+1][j+1] == lcs[i][j]:
+			// same slot, same type, edited in place
+			out = append(out, dto.BlockChangeDTO{
+				Op: dto.BlockOpChanged, FromIndex: intPtr(i), ToIndex: intPtr(j), Type: b[j].typ,
+				Before: a[i].raw, After: b[j].raw, ChangedKeys: changedKeys(a[i], b[j]),
+			})
+			i++
+			j++
+		case i < n && (j == m || lcs[i+1][j] >= lcs[i][j+1]):
+			out = append(out, dto.BlockChangeDTO{
+				Op: dto.BlockOpRemoved, FromIndex: intPtr(i), Type: a[i].typ, Before: a[i].raw,
+			})
+			i++
+		default:
+			out = append(out, dto.BlockChangeDTO{
+				Op: dto.BlockOpAdded, ToIndex: intPtr(j), Type: b[j].typ, After: b[j].raw,
+			})
+			j++
+		}
+	}
+	return out
+}
+
+func changedKeys(a, b contentBlock) []string {
+	if a.keys == nil || b.keys == nil {
+		return nil
+	}
+	keys := make([]string, 0)
+	for k, va := range a.keys {
+		if vb, ok := b.keys[k]; !ok || !bytes.Equal(va, vb) {
+			keys = append(keys, k)
+		}
+	}
+	for k := range b.keys {
+		if _, ok := a.keys[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func intPtr(v int) *int { return &v }
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"darulabror/internal/dto"
+	"darulabror/internal/models"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// steps renders a block diff compactly: "=from,to", "~from,to[keys]", "-from", "+to".
+func steps(changes []dto.BlockChangeDTO) string {
+	out := make([]string, 0, len(changes))
+	for _, c := range changes {
+		switch c.Op {
+		case dto.BlockOpEqual:
+			out = append(out, fmt.Sprintf("=%d,%d", *c.FromIndex, *c.ToIndex))
+		case dto.BlockOpChanged:
+			out = append(out, fmt.Sprintf("~%d,%d%v", *c.FromIndex, *c.ToIndex, c.ChangedKeys))
+		case dto.BlockOpRemoved:
+			out = append(out, fmt.Sprintf("-%d", *c.FromIndex))
+		case dto.BlockOpAdded:
+			out = append(out, fmt.Sprintf("+%d", *c.ToIndex))
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+func TestDiffBlocks(t *testing.T) {
+	const (
+		a   = `{"type":"paragraph","text":"a"}`
+		b   = `{"type":"paragraph","text":"b"}`
+		c   = `{"type":"paragraph","text":"c"}`
+		h   = `{"type":"heading","level":2,"text":"Jadwal"}`
+		img = `{"type":"image","url":"/uploads/a.jpg"}`
+	)
+	doc := func(blocks ...string) string { return `{"blocks":[` + strings.Join(blocks, ",") + `]}` }
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     string
+	}{
+		{"unchanged", doc(h, a), doc(h, a), "=0,0 =1,1"},
+		{"key order and spacing ignored", doc(a), `{"blocks":[ {"text": "a", "type": "paragraph"} ]}`, "=0,0"},
+		{"legacy bare array", `[` + a + `,` + b + `]`, doc(a, b), "=0,0 =1,1"},
+		{"inserted", doc(a, c), doc(a, b, c), "=0,0 +1 =1,2"},
+		{"removed", doc(a, b, c), doc(a, c), "=0,0 -1 =2,1"},
+		{"edited in place", doc(h, a, c), doc(h, b, c), "=0,0 ~1,1[text] =2,2"},
+		{"changed keys", doc(h), doc(`{"type":"heading","level":3,"text":"Jadwal","anchor":"x"}`), "~0,0[anchor level]"},
+		{"type replaced", doc(a, b), doc(a, img), "=0,0 -1 +1"},
+		{"swapped", doc(a, b), doc(b, a), "-0 =1,0 +1"},
+		{"from empty", "", doc(a, b), "+0 +1"},
+		{"to empty", doc(a), doc(), "-0"},
+		{"not a block list", `"teks"`, `"teks baru"`, "~0,0[]"},
+	}
+	for _, tt := range tests {
+		got := steps(diffBlocks(contentBlocks([]byte(tt.from)), contentBlocks([]byte(tt.to))))
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDiffRevisions(t *testing.T) {
+	from := models.ArticleRevision{ArticleID: 7, Revision: 1, Title: "Lama", Slug: "lama", Author: "Admin",
+		Status: models.ArticleStatusDraft, Content: []byte(`{"blocks":[]}`)}
+	to := from
+	to.Revision, to.Title, to.Slug, to.Status = 3, "Baru", "baru", models.ArticleStatusPublished
+	to.Content = []byte(`{"blocks":[{"type":"paragraph","text":"x"}]}`)
+
+	diff := diffRevisions(from, to)
+	if diff.ArticleID != 7 || diff.From != 1 || diff.To != 3 {
+		t.Errorf("header = %d %d->%d", diff.ArticleID, diff.From, diff.To)
+	}
+	var fields []string
+	for _, f := range diff.Fields {
+		fields = append(fields, f.Field+":"+f.From+">"+f.To)
+	}
+	if got, want := strings.Join(fields, " "), "title:Lama>Baru slug:lama>baru status:draft>published"; got != want {
+		t.Errorf("fields = %q, want %q", got, want)
+	}
+	if got := steps(diff.Blocks); got != "+0" {
+		t.Errorf("blocks = %q", got)
+	}
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"darulabror/internal/dto"
+	"darulabror/internal/messaging"
+	"darulabror/internal/models"
+	"darulabror/internal/repository"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// DeliveryService sends WhatsApp/SMS messages through the configured channel.
+// Messages are logged as deliveries first and sent by ProcessDue, which retries
+// failed attempts with a growing delay; admins see the log and can retry
+// deliveries that gave up.
+type DeliveryService interface {
+	// Queue logs the message of event (a messaging template) for the phone
+	// numbers of the registration. Nothing is logged without a channel.
+	Queue(event string, reg models.Registration, data any)
+	// ProcessDue sends the deliveries due at now and returns how many were sent.
+	ProcessDue(ctx context.Context, now time.Time) (int, error)
+
+	// Admin
+	ListDeliveries(page, limit int, filter repository.DeliveryFilter) ([]dto.NotificationDeliveryDTO, int64, error)
+	RetryDelivery(id uint) (dto.NotificationDeliveryDTO, error)
+}
+
+// deliveryBackoff is the wait before each retry; a delivery gives up after
+// len(deliveryBackoff)+1 attempts.
+var deliveryBackoff = []time.Duration{time.Minute, 5 * time.Minute, 30 * time.Minute, 2 * time.Hour}
+
+const (
+	deliveryBatch = 50
+	// claimed deliveries are skipped by other instances this long
+	deliveryLease = 5 * time.Minute
+)
+
+// RegistrationPhoneFields are the registration phone numbers messages can go
+// to (MESSAGE_RECIPIENTS).
+var RegistrationPhoneFields = []string{"phone", "phone_father", "phone_mother"}
+
+type deliveryService struct {
+	repo       repository.NotificationRepo
+	channel    messaging.Channel // nil: messaging disabled
+	recipients []string          // RegistrationPhoneFields to send to
+}
+
+func NewDeliveryService(repo repository.NotificationRepo, channel messaging.Channel, recipients []string) DeliveryService {
+	return &deliveryService{repo: repo, channel: channel, recipients: recipients}
+}
+
+func (s *deliveryService) Queue(event string, reg models.Registration, data any) {
+	if s.channel == nil {
+		return
+	}
+	text, err := messaging.Render(event, data)
+	if err != nil {
+		logrus.WithError(err).WithField("event", event).Error("failed render message")
+		return
+	}
+
+	now := time.Now()
+	seen := make(map[string]bool)
+	deliveries := make([]models.NotificationDelivery, 0, len(s.recipients))
+	for _, raw := range registrationPhones(reg, s.recipients) {
+		d := models.NotificationDelivery{
+			Channel:        s.channel.Name(),
+			Event:          event,
+			RegistrationID: &reg.ID,
+			Message:        text,
+			Status:         models.DeliveryPending,
+			NextAttemptAt:  &now,
+		}
+		phone, err := messaging.NormalizePhone(raw)
+		if err != nil {
+			// logged as failed so admins see the number needs fixing
+			d.Recipient, d.Status, d.LastError, d.NextAttemptAt = raw, models.DeliveryFailed, err.Error(), nil
+		} else {
+			d.Recipient = phone
+		}
+		if seen[d.Recipient] {
+			continue // parents sharing a number get one message
+		}
+		seen[d.Recipient] = true
+		deliveries = append(deliveries, d)
+	}
+
+	if err := s.repo.CreateDeliveries(deliveries); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"event":           event,
+			"registration_id": reg.ID,
+		}).Error("failed queue messages")
+	}
+}
+
+func (s *deliveryService) ProcessDue(ctx context.Context, now time.Time) (int, error) {
+	if s.channel == nil {
+		return 0, nil
+	}
+	deliveries, err := s.repo.ClaimDueDeliveries(s.channel.Name(), now, deliveryLease, deliveryBatch)
+	if err != nil {
+		logrus.WithError(err).Error("failed claim due deliveries")
+		return 0, err
+	}
+
+	sent := 0
+	for _, d := range deliveries {
+		if err := ctx.Err(); err != nil {
+			return sent, err // the rest is retried once the lease expires
+		}
+		if s.attempt(ctx, d) {
+			sent++
+		}
+	}
+	if sent > 0 {
+		logrus.WithField("sent", sent).Info("messages sent")
+	}
+	return sent, nil
+}
+
+// attempt sends d once and records the outcome; true when it was sent.
+func (s *deliveryService) attempt(ctx context.Context, d models.NotificationDelivery) bool {
+	log := logrus.WithFields(logrus.Fields{
+		"id":      d.ID,
+		"channel": d.Channel,
+		"event":   d.Event,
+	})
+
+	phone, err := messaging.NormalizePhone(d.Recipient)
+	if err == nil {
+		err = s.channel.Send(ctx, messaging.Message{
+			ID:   fmt.Sprintf("delivery-%d", d.ID),
+			To:   phone,
+			Text: d.Message,
+		})
+	} else {
+		err = fmt.Errorf("%w: %v", messaging.ErrPermanent, err)
+	}
+
+	d.Attempts++
+	now := time.Now()
+	switch {
+	case err == nil:
+		d.Status, d.LastError, d.NextAttemptAt, d.SentAt = models.DeliverySent, "", nil, &now
+	case errors.Is(err, messaging.ErrPermanent) || d.Attempts > len(deliveryBackoff):
+		d.Status, d.LastError, d.NextAttemptAt = models.DeliveryFailed, err.Error(), nil
+		log.WithError(err).WithField("attempts", d.Attempts).Error("message delivery failed, giving up")
+	default:
+		next := now.Add(deliveryBackoff[d.Attempts-1])
+		d.LastError, d.NextAttemptAt = err.Error(), &next
+		log.WithError(err).WithField("attempts", d.Attempts).Warn("message delivery failed, will retry")
+	}
+
+	if err := s.repo.UpdateDelivery(d); err != nil {
+		log.WithError(err).Error("failed update delivery")
+	}
+	return d.Status == models.DeliverySent
+}
+
+func (s *deliveryService) ListDeliveries(page, limit int, filter repository.DeliveryFilter) ([]dto.NotificationDeliveryDTO, int64, error) {
+	deliveries, total, err := s.repo.GetDeliveries(page, limit, filter)
+	if err != nil {
+		logrus.WithError(err).Error("failed list deliveries")
+		return nil, 0, err
+	}
+
+	out := make([]dto.NotificationDeliveryDTO, 0, len(deliveries))
+	for _, d := range deliveries {
+		out = append(out, dto.NotificationDeliveryModelToDTO(d))
+	}
+	return out, total, nil
+}
+
+func (s *deliveryService) RetryDelivery(id uint) (dto.NotificationDeliveryDTO, error) {
+	d, err := s.repo.GetDelivery(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.NotificationDeliveryDTO{}, ErrNotFoundDelivery
+		}
+		logrus.WithError(err).WithField("id", id).Error("failed get delivery")
+		return dto.NotificationDeliveryDTO{}, err
+	}
+	if d.Status == models.DeliverySent {
+		return dto.NotificationDeliveryDTO{}, ErrDeliveryAlreadySent
+	}
+
+	// a fresh round of attempts, starting with the next run
+	now := time.Now()
+	d.Status, d.Attempts, d.NextAttemptAt = models.DeliveryPending, 0, &now
+	if err := s.repo.UpdateDelivery(d); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.NotificationDeliveryDTO{}, ErrNotFoundDelivery
+		}
+		logrus.WithError(err).WithField("id", id).Error("failed update delivery")
+		return dto.NotificationDeliveryDTO{}, err
+	}
+
+	logrus.WithField("id", id).Info("delivery queued for retry")
+	return dto.NotificationDeliveryModelToDTO(d), nil
+}
+
+// registrationPhones returns the non-empty phone numbers of reg named by fields
+// (RegistrationPhoneFields).
+func registrationPhones(reg models.Registration, fields []string) []string {
+	phones := make([]string, 0, len(fields))
+	for _, field := range fields {
+		var phone string
+		switch field {
+		case "phone":
+			phone = reg.Phone
+		case "phone_father":
+			phone = reg.PhoneFather
+		case "phone_mother":
+			phone = reg.PhoneMother
+		}
+		if phone != "" {
+			phones = append(phones, phone)
+		}
+	}
+	return phones
+}
@@ -8,9 +8,42 @@ var (
 	ErrInvalidAdmin  = errors.New("invalid admin")
 	ErrCreateAdmin   = errors.New("failed to create admin")
 	// Article service errors
-	ErrNotFoundArticle = errors.New("article not found")
-	ErrCreateArticle   = errors.New("failed to create article")
-	ErrUpdateArticle   = errors.New("failed to update article")
+	ErrNotFoundArticle         = errors.New("article not found")
+	ErrCreateArticle           = errors.New("failed to create article")
+	ErrUpdateArticle           = errors.New("failed to update article")
+	ErrArticleSlugMoved        = errors.New("article slug moved")
+	ErrInvalidArticleSlug      = errors.New("invalid article slug")
+	ErrInvalidSearchQuery      = errors.New("search query is required")
+	ErrInvalidArticleSchedule  = errors.New("invalid article schedule")
+	ErrNotFoundArticleRevision = errors.New("article revision not found")
+	ErrInvalidContentFormat    = errors.New("invalid content format")
+	ErrNotFoundPreviewLink     = errors.New("preview link not found")
+	ErrPreviewLinkInactive     = errors.New("preview link expired or revoked")
+	ErrInvalidMedia            = errors.New("content references unknown media")
+	// Media library errors
+	ErrNotFoundMedia       = errors.New("media not found")
+	ErrMediaInUse          = errors.New("media is used by articles")
+	ErrMediaGCBaseMismatch = errors.New("no article references the storage base url")
+	// Upload policy errors
+	ErrUnsupportedMediaType = errors.New("unsupported file type")
+	ErrMediaTooLarge        = errors.New("file too large")
+	// Private file errors
+	ErrInvalidFileName      = errors.New("invalid file name")
+	ErrInvalidSignedURL     = errors.New("invalid or expired link")
+	ErrPrivateFilesDisabled = errors.New("private file storage is not configured")
+	// Category / tag service errors
+	ErrNotFoundCategory = errors.New("category not found")
+	ErrInvalidCategory  = errors.New("invalid category")
+	ErrCategoryExists   = errors.New("category slug already used")
+	ErrNotFoundTag      = errors.New("tag not found")
+	ErrInvalidTag       = errors.New("invalid tag")
+	ErrTagExists        = errors.New("tag slug already used")
+	// Site (feeds / sitemap) errors
+	ErrSiteNotConfigured   = errors.New("site not configured: set SITE_URL")
+	ErrNotFoundSitemapPage = errors.New("sitemap page not found")
+	// Trash service errors
+	ErrInvalidTrashType  = errors.New("unknown trash type")
+	ErrNotFoundTrashItem = errors.New("item not found in trash")
 	// Registration service errors public
 	ErrCreateRegistration = errors.New("failed to create registration")
 	// Registration service errors admin
@@ -19,4 +52,32 @@ var (
 	// Registration service errors additional
 	ErrRegistrationEmailExists = errors.New("registration email already used")
 	ErrRegistrationNISNExists  = errors.New("registration nisn already used")
+	ErrNotFoundRegistration    = errors.New("registration not found")
+	ErrInvalidStatusTransition = errors.New("status transition not allowed")
+	ErrInvalidExportFormat     = errors.New("format must be csv or xlsx")
+	ErrInvalidImportFormat     = errors.New("file must be a .csv or .xlsx spreadsheet")
+	ErrInvalidImportFile       = errors.New("invalid import file")
+	ErrImportTooLarge          = errors.New("the import file has too many rows")
+	// one error for unknown identifiers and wrong dates of birth: lookups can't
+	// tell whether a NISN is registered
+	ErrRegistrationLookupFailed = errors.New("no registration matches this reference number / NISN and date of birth")
+	// Admission period errors
+	ErrAdmissionClosed         = errors.New("registration is closed: no admission period is open")
+	ErrAdmissionFull           = errors.New("the admission period quota is full")
+	ErrNotFoundAdmissionPeriod = errors.New("admission period not found")
+	ErrInvalidAcademicYear     = errors.New("academic_year must look like 2025/2026")
+	ErrInvalidAdmissionDates   = errors.New("closes_at must be after opens_at")
+	ErrAdmissionPeriodOverlaps = errors.New("admission period overlaps another period")
+	ErrAdmissionPeriodInUse    = errors.New("admission period has registrations")
+	// Registration document errors
+	ErrInvalidUploadToken     = errors.New("invalid or expired upload token")
+	ErrRegistrationClosed     = errors.New("registration no longer accepts documents")
+	ErrInvalidDocumentType    = errors.New("invalid document type")
+	ErrNotFoundDocument       = errors.New("document not found")
+	ErrDocumentVerified       = errors.New("document already verified")
+	ErrDocumentReasonRequired = errors.New("reason is required when rejecting a document")
+	ErrInvalidDocumentStatus  = errors.New("status must be verified or rejected")
+	// Notification delivery errors
+	ErrNotFoundDelivery    = errors.New("delivery not found")
+	ErrDeliveryAlreadySent = errors.New("delivery already sent")
 )
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"crypto/sha1"
+	"darulabror/internal/content"
+	"darulabror/internal/models"
+	"darulabror/internal/repository"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Feed formats
+const (
+	FeedFormatRSS  = "rss"
+	FeedFormatAtom = "atom"
+)
+
+// feedSize is how many of the latest published articles a feed lists.
+const feedSize = 50
+
+// Feed is a rendered feed document plus its cache validators.
+type Feed struct {
+	Body         []byte
+	ContentType  string
+	ETag         string    // quoted, changes whenever an article in the feed is added, removed or updated
+	LastModified time.Time // newest change (update or going live) in the feed; zero for an empty feed
+}
+
+type FeedService interface {
+	// ArticleFeed renders the latest published articles. path is the feed's own
+	// path, linked as self on the API's public URL (SiteConfig.APIURL), never on
+	// the request's Host: feeds are cached publicly.
+	ArticleFeed(format, path string) (Feed, error)
+}
+
+type feedService struct {
+	articleRepo repository.ArticleRepo
+	site        SiteConfig
+}
+
+func NewFeedService(articleRepo repository.ArticleRepo, site SiteConfig) FeedService {
+	return &feedService{articleRepo: articleRepo, site: site}
+}
+
+func (s *feedService) ArticleFeed(format, path string) (Feed, error) {
+	if !s.site.Configured() {
+		return Feed{}, ErrSiteNotConfigured
+	}
+	selfURL := s.site.APIURL(path)
+
+	articles, _, err := s.articleRepo.GetPublished(1, feedSize, repository.ArticleFilter{})
+	if err != nil {
+		logrus.WithError(err).Error("failed get articles for feed")
+		return Feed{}, err
+	}
+
+	var lastModified time.Time
+	h := sha1.New()
+	fmt.Fprintf(h, "%s|%s|%s|", format, s.site.BaseURL, selfURL)
+	for _, a := range articles {
+		t := modifiedAt(a)
+		if t.After(lastModified) {
+			lastModified = t
+		}
+		fmt.Fprintf(h, "%d:%d,", a.ID, t.Unix())
+	}
+	feed := Feed{
+		ETag:         `"` + hex.EncodeToString(h.Sum(nil)[:12]) + `"`,
+		LastModified: lastModified,
+	}
+
+	var doc any
+	switch format {
+	case FeedFormatRSS:
+		doc = s.rss(articles, selfURL, lastModified)
+		feed.ContentType = "application/rss+xml; charset=utf-8"
+	case FeedFormatAtom:
+		doc = s.atom(articles, selfURL, lastModified)
+		feed.ContentType = "application/atom+xml; charset=utf-8"
+	default:
+		return Feed{}, fmt.Errorf("unknown feed format %q", format)
+	}
+
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		logrus.WithError(err).WithField("format", format).Error("failed encode feed")
+		return Feed{}, err
+	}
+	feed.Body = append([]byte(xml.Header), body...)
+	return feed, nil
+}
+
+// ======================
+//  RSS 2.0
+// ======================
+
+type rssDoc struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	DCNS    string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	SelfLink      atomLink  `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate"`
+	Creator     string        `xml:"dc:creator,omitempty"`
+	Description string        `xml:"description"`
+	Categories  []string      `xml:"category"`
+	Enclosure   *rssEnclosure `xml:"enclosure"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"` // unknown: 0
+	Type   string `xml:"type,attr"`
+}
+
+func (s *feedService) rss(articles []models.Article, selfURL string, lastModified time.Time) rssDoc {
+	channel := rssChannel{
+		Title:       s.site.Name,
+		Link:        s.site.URL("/"),
+		Description: s.site.Description,
+		Language:    "id",
+		SelfLink:    atomLink{Href: selfURL, Rel: "self", Type: "application/rss+xml"},
+		Items:       make([]rssItem, 0, len(articles)),
+	}
+	if !lastModified.IsZero() {
+		channel.LastBuildDate = lastModified.Format(time.RFC1123Z)
+	}
+
+	for _, a := range articles {
+		link := s.site.ArticleURL(a.Slug)
+		item := rssItem{
+			Title:       a.Title,
+			Link:        link,
+			GUID:        rssGUID{IsPermaLink: true, Value: link},
+			PubDate:     publishedAt(a).Format(time.RFC1123Z),
+			Creator:     a.Author,
+			Description: articleExcerpt(a),
+			Categories:  categoryNames(a),
+		}
+		if a.PhotoHeader != "" {
+			item.Enclosure = &rssEnclosure{URL: a.PhotoHeader, Type: imageType(a.PhotoHeader)}
+		}
+		channel.Items = append(channel.Items, item)
+	}
+
+	return rssDoc{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		DCNS:    "http://purl.org/dc/elements/1.1/",
+		Channel: channel,
+	}
+}
+
+// ======================
+//  Atom
+// ======================
+
+type atomDoc struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	ID       string      `xml:"id"`
+	Links    []atomLink  `xml:"link"`
+	Updated  string      `xml:"updated"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     atomAuthor     `xml:"author"`
+	Summary    string         `xml:"summary"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr,omitempty"`
+}
+
+func (s *feedService) atom(articles []models.Article, selfURL string, lastModified time.Time) atomDoc {
+	if lastModified.IsZero() {
+		lastModified = time.Unix(0, 0).UTC()
+	}
+	doc := atomDoc{
+		Title:    s.site.Name,
+		Subtitle: s.site.Description,
+		ID:       s.site.URL("/"),
+		Links: []atomLink{
+			{Href: s.site.URL("/"), Rel: "alternate", Type: "text/html"},
+			{Href: selfURL, Rel: "self", Type: "application/atom+xml"},
+		},
+		Updated: lastModified.Format(time.RFC3339),
+		Entries: make([]atomEntry, 0, len(articles)),
+	}
+
+	for _, a := range articles {
+		link := s.site.ArticleURL(a.Slug)
+		entry := atomEntry{
+			Title:     a.Title,
+			ID:        link,
+			Links:     []atomLink{{Href: link, Rel: "alternate", Type: "text/html"}},
+			Published: publishedAt(a).Format(time.RFC3339),
+			Updated:   modifiedAt(a).Format(time.RFC3339),
+			Author:    atomAuthor{Name: a.Author},
+			Summary:   articleExcerpt(a),
+		}
+		if a.PhotoHeader != "" {
+			entry.Links = append(entry.Links, atomLink{Href: a.PhotoHeader, Rel: "enclosure", Type: imageType(a.PhotoHeader)})
+		}
+		for _, c := range a.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: c.Slug, Label: c.Name})
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+	return doc
+}
+
+// publishedAt is when the article went public: its publish_at if it was scheduled, else creation.
+func publishedAt(a models.Article) time.Time {
+	if a.PublishAt != nil {
+		return time.Unix(*a.PublishAt, 0).UTC()
+	}
+	return time.Unix(a.CreatedAt, 0).UTC()
+}
+
+// modifiedAt is the article's last change as a feed sees it: a scheduled article
+// going live doesn't touch updated_at.
+func modifiedAt(a models.Article) time.Time {
+	updated := time.Unix(a.UpdatedAt, 0).UTC()
+	if published := publishedAt(a); published.After(updated) {
+		return published
+	}
+	return updated
+}
+
+func articleExcerpt(a models.Article) string {
+	if a.Excerpt != "" {
+		return a.Excerpt
+	}
+	return content.Excerpt(a.Content, content.ExcerptLength)
+}
+
+func categoryNames(a models.Article) []string {
+	names := make([]string, 0, len(a.Categories))
+	for _, c := range a.Categories {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// imageType guesses the header image MIME type from its URL, defaulting to JPEG.
+func imageType(rawURL string) string {
+	ext := strings.ToLower(path.Ext(strings.SplitN(rawURL, "?", 2)[0]))
+	if t := mime.TypeByExtension(ext); strings.HasPrefix(t, "image/") {
+		return strings.SplitN(t, ";", 2)[0]
+	}
+	return "image/jpeg"
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"darulabror/internal/models"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArticleFeedLastModified(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	edited := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC).Unix()
+	wentLive := time.Date(2025, 3, 5, 7, 0, 0, 0, time.UTC).Unix()
+
+	// written in January, scheduled in March; going live doesn't touch updated_at
+	scheduled := models.Article{ID: 1, Slug: "jadwal", Title: "Jadwal", Status: models.ArticleStatusPublished,
+		CreatedAt: created, UpdatedAt: edited, PublishAt: at(wentLive)}
+	older := models.Article{ID: 2, Slug: "berita", Title: "Berita", Status: models.ArticleStatusPublished,
+		CreatedAt: created, UpdatedAt: edited}
+
+	repo := &fakeArticleRepo{published: []models.Article{scheduled, older}}
+	s := &feedService{articleRepo: repo, site: SiteConfig{BaseURL: "https://www.darulabror.com", Name: "Darul Abror"}}
+
+	feed, err := s.ArticleFeed(FeedFormatAtom, "/feeds/articles.atom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Unix(wentLive, 0).UTC(); !feed.LastModified.Equal(want) {
+		t.Errorf("LastModified = %v, want %v", feed.LastModified, want)
+	}
+	body := string(feed.Body)
+	for _, want := range []string{
+		"<updated>2025-03-05T07:00:00Z</updated>",     // feed
+		"<published>2025-03-05T07:00:00Z</published>", // entry
+		"<updated>2025-03-01T00:00:00Z</updated>",     // the other entry, only edited
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %s in\n%s", want, body)
+		}
+	}
+
+	// the same articles before the scheduled one went live have another ETag
+	repo.published = []models.Article{older}
+	before, err := s.ArticleFeed(FeedFormatAtom, "/feeds/articles.atom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before.ETag == feed.ETag || !before.LastModified.Before(feed.LastModified) {
+		t.Errorf("going live changed neither ETag (%s) nor Last-Modified (%v)", before.ETag, before.LastModified)
+	}
+}
+
+func TestArticleFeedRSS(t *testing.T) {
+	published := time.Date(2025, 2, 10, 3, 0, 0, 0, time.UTC).Unix()
+	repo := &fakeArticleRepo{published: []models.Article{{
+		ID: 1, Slug: "wisuda-2025", Title: "Wisuda <2025>", Author: "Admin", Excerpt: "Alhamdulillah & syukur",
+		PhotoHeader: "https://cdn.example.com/wisuda.webp", CreatedAt: published, UpdatedAt: published,
+		Categories: []models.Category{{Name: "Kegiatan", Slug: "kegiatan"}},
+	}}}
+	s := &feedService{articleRepo: repo, site: SiteConfig{BaseURL: "https://www.darulabror.com/", Name: "Darul Abror"}}
+
+	feed, err := s.ArticleFeed(FeedFormatRSS, "/feeds/articles.rss")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if feed.ContentType != "application/rss+xml; charset=utf-8" {
+		t.Errorf("content type %q", feed.ContentType)
+	}
+	body := string(feed.Body)
+	for _, want := range []string{
+		`<atom:link href="https://www.darulabror.com/feeds/articles.rss" rel="self" type="application/rss+xml">`,
+		`<link>https://www.darulabror.com/articles/wisuda-2025</link>`,
+		`<title>Wisuda &lt;2025&gt;</title>`,
+		`<description>Alhamdulillah &amp; syukur</description>`,
+		`<pubDate>Mon, 10 Feb 2025 03:00:00 +0000</pubDate>`,
+		`<category>Kegiatan</category>`,
+		`<enclosure url="https://cdn.example.com/wisuda.webp" length="0" type="image/webp">`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %s in\n%s", want, body)
+		}
+	}
+}
+
+func TestArticleFeedSelfLink(t *testing.T) {
+	s := &feedService{articleRepo: &fakeArticleRepo{}, site: SiteConfig{
+		BaseURL: "https://www.darulabror.com", APIBaseURL: "https://api.darulabror.com",
+	}}
+	feed, err := s.ArticleFeed(FeedFormatAtom, "/feeds/articles.atom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := string(feed.Body)
+	for _, want := range []string{
+		`<link href="https://api.darulabror.com/feeds/articles.atom" rel="self" type="application/atom+xml">`,
+		`<link href="https://www.darulabror.com/" rel="alternate" type="text/html">`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %s in\n%s", want, body)
+		}
+	}
+}
+
+func TestArticleFeedNotConfigured(t *testing.T) {
+	s := &feedService{articleRepo: &fakeArticleRepo{}}
+	if _, err := s.ArticleFeed(FeedFormatRSS, "/feeds/articles.rss"); !errors.Is(err, ErrSiteNotConfigured) {
+		t.Errorf("got %v, want ErrSiteNotConfigured", err)
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"darulabror/internal/dto"
+	"darulabror/internal/repository"
+	"errors"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FileService gives access to files in the private store (PRIVATE_BUCKET), which
+// are never public: they're read through short-lived signed URLs only.
+type FileService interface {
+	// SignedURL returns a link to a private file, valid for the configured TTL.
+	SignedURL(ctx context.Context, adminID uint, objectName string) (dto.SignedURLDTO, error)
+	// ResolveSignedFile checks a signed link served by the API itself (local disk
+	// backend) and returns the file to send.
+	ResolveSignedFile(objectName, expires, signature string) (string, error)
+}
+
+type fileService struct {
+	store  repository.StorageRepo
+	urlTTL time.Duration
+}
+
+func NewFileService(store repository.StorageRepo, urlTTL time.Duration) FileService {
+	return &fileService{store: store, urlTTL: urlTTL}
+}
+
+func (s *fileService) SignedURL(ctx context.Context, adminID uint, objectName string) (dto.SignedURLDTO, error) {
+	if !validObjectName(objectName) {
+		return dto.SignedURLDTO{}, ErrInvalidFileName
+	}
+
+	expiresAt := time.Now().Add(s.urlTTL)
+	url, err := s.store.GenerateSignedURL(ctx, objectName, s.urlTTL)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrStorageNotConfigured), errors.Is(err, repository.ErrSignedURLNotSupported):
+			return dto.SignedURLDTO{}, ErrPrivateFilesDisabled
+		case errors.Is(err, repository.ErrInvalidObjectName):
+			return dto.SignedURLDTO{}, ErrInvalidFileName
+		}
+		logrus.WithError(err).WithField("object", objectName).Error("failed sign private file url")
+		return dto.SignedURLDTO{}, err
+	}
+
+	// access to private files is audited
+	logrus.WithFields(logrus.Fields{
+		"object":     objectName,
+		"admin_id":   adminID,
+		"expires_at": expiresAt.Unix(),
+	}).Info("private file url issued")
+	return dto.SignedURLDTO{URL: url, ExpiresAt: expiresAt.Unix()}, nil
+}
+
+func (s *fileService) ResolveSignedFile(objectName, expires, signature string) (string, error) {
+	resolver, ok := s.store.(repository.SignedFileResolver)
+	if !ok {
+		return "", ErrInvalidSignedURL
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || signature == "" || !validObjectName(objectName) {
+		return "", ErrInvalidSignedURL
+	}
+
+	file, err := resolver.ResolveSignedURL(objectName, expiresAt, signature, time.Now())
+	if err != nil {
+		return "", ErrInvalidSignedURL
+	}
+	return file, nil
+}
+
+// validObjectName accepts clean relative paths like "registrations/12/kk.pdf".
+func validObjectName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.HasPrefix(name, "/") && path.Clean(name) == name && !strings.HasPrefix(name, "../")
+}
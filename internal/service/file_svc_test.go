@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"darulabror/internal/repository"
+	"errors"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidObjectName(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"registrations/12/kk.pdf", true},
+		{"kk.pdf", true},
+		{"registrations/12/..kk.pdf", true},
+		{"", false},
+		{"/registrations/12/kk.pdf", false},
+		{"../secrets.txt", false},
+		{"..", false},
+		{".", false},
+		{"registrations/../../secrets.txt", false},
+		{"registrations//kk.pdf", false},
+		{"registrations/12/", false},
+	}
+	for _, tt := range tests {
+		if got := validObjectName(tt.name); got != tt.want {
+			t.Errorf("%q: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFileServiceSignedURL(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	svc := NewFileService(repository.NewLocalStorageRepo(root, "/files", false, []byte("key")), 10*time.Minute)
+
+	const object = "registrations/12/kk.pdf"
+	signed, err := svc.SignedURL(ctx, 1, object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if until := time.Until(time.Unix(signed.ExpiresAt, 0)); until < 9*time.Minute || until > 10*time.Minute {
+		t.Errorf("expires in %v, want the TTL", until)
+	}
+	link, err := url.Parse(signed.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expires, signature := link.Query().Get("expires"), link.Query().Get("signature")
+
+	tests := []struct {
+		name      string
+		object    string
+		expires   string
+		signature string
+		want      error
+	}{
+		{"valid", object, expires, signature, nil},
+		{"other object", "registrations/12/akta.pdf", expires, signature, ErrInvalidSignedURL},
+		{"expires not a number", object, expires + "x", signature, ErrInvalidSignedURL},
+		{"missing signature", object, expires, "", ErrInvalidSignedURL},
+		{"traversal", "../" + object, expires, signature, ErrInvalidSignedURL},
+	}
+	for _, tt := range tests {
+		file, err := svc.ResolveSignedFile(tt.object, tt.expires, tt.signature)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+			continue
+		}
+		if err == nil && file != filepath.Join(root, object) {
+			t.Errorf("%s: file %q", tt.name, file)
+		}
+	}
+
+	if _, err := svc.SignedURL(ctx, 1, "../"+object); !errors.Is(err, ErrInvalidFileName) {
+		t.Errorf("invalid name: got %v", err)
+	}
+	disabled := NewFileService(repository.NewLocalStorageRepo("", "/files", false, []byte("key")), time.Minute)
+	if _, err := disabled.SignedURL(ctx, 1, object); !errors.Is(err, ErrPrivateFilesDisabled) {
+		t.Errorf("no private store: got %v", err)
+	}
+	noKey := NewFileService(repository.NewLocalStorageRepo(root, "/files", false, nil), time.Minute)
+	if _, err := noKey.SignedURL(ctx, 1, object); !errors.Is(err, ErrPrivateFilesDisabled) {
+		t.Errorf("no signing key: got %v", err)
+	}
+	if _, err := noKey.ResolveSignedFile(object, expires, signature); !errors.Is(err, ErrInvalidSignedURL) {
+		t.Errorf("resolving without a signing key: got %v", err)
+	}
+	if !strings.HasPrefix(signed.URL, "/files/"+object+"?") {
+		t.Errorf("url %q", signed.URL)
+	}
+}
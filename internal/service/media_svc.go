@@ -0,0 +1,522 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"darulabror/internal/content"
+	"darulabror/internal/dto"
+	"darulabror/internal/imaging"
+	"darulabror/internal/models"
+	"darulabror/internal/repository"
+	"errors"
+	"image"
+	_ "image/gif" // register decoders for image.DecodeConfig
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"mime"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/sirupsen/logrus"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Storage folders for uploads.
+const (
+	MediaFolderLibrary        = "media"
+	MediaFolderArticleHeader  = "articles/header"
+	MediaFolderArticleContent = "articles/content"
+)
+
+// mediaGCPrefix is where article uploads live (header and inline content, including
+// the older "articles/header_<ts>_<name>" objects). Library uploads under "media/"
+// are only removed by hand.
+const mediaGCPrefix = "articles/"
+
+// mediaGCBatch is how many articles are read at a time when collecting references.
+const mediaGCBatch = 200
+
+type MediaService interface {
+	// CheckUpload sniffs the file type and checks it against the upload policy of slot
+	// (ErrUnsupportedMediaType / ErrMediaTooLarge). Returns the type; leaves file rewound.
+	CheckUpload(file io.ReadSeeker, slot UploadSlot) (string, error)
+	// Upload checks the file like CheckUpload, stores it in the slot's folder and
+	// records it in the library.
+	Upload(ctx context.Context, adminID uint, file io.ReadSeeker, fileName string, slot UploadSlot) (dto.MediaDTO, error)
+	GetAllMedia(page, limit int, filter repository.MediaFilter) ([]dto.MediaDTO, int64, error)
+	GetMedia(id uint) (dto.MediaDTO, error)
+	GetMediaUsage(id uint) ([]dto.MediaUsageDTO, error)
+	// DeleteMedia removes an unused item and its file.
+	DeleteMedia(ctx context.Context, id uint) error
+
+	// CollectOrphans finds article uploads no article references anymore and, unless
+	// dryRun, deletes those older than the grace period (with their library rows).
+	CollectOrphans(ctx context.Context, now time.Time, dryRun bool) (dto.MediaGCReportDTO, error)
+}
+
+type mediaService struct {
+	repo        repository.MediaRepo
+	articleRepo repository.ArticleRepo
+	store       repository.StorageRepo
+	policy      UploadPolicy
+	gcGrace     time.Duration // unreferenced uploads younger than this are kept
+}
+
+func NewMediaService(repo repository.MediaRepo, articleRepo repository.ArticleRepo, store repository.StorageRepo, policy UploadPolicy, gcGrace time.Duration) MediaService {
+	return &mediaService{
+		repo:        repo,
+		articleRepo: articleRepo,
+		store:       store,
+		policy:      policy,
+		gcGrace:     gcGrace,
+	}
+}
+
+func (s *mediaService) CheckUpload(file io.ReadSeeker, slot UploadSlot) (string, error) {
+	mimeType, _, err := s.policy.inspect(file, slot)
+	return mimeType, err
+}
+
+// Upload stores the file and records it. JPEG, PNG and WebP images are processed
+// into resized variants (see package imaging) and only those are stored; other
+// files are stored as-is.
+func (s *mediaService) Upload(ctx context.Context, adminID uint, file io.ReadSeeker, fileName string, slot UploadSlot) (dto.MediaDTO, error) {
+	safeName := filepath.Base(fileName)
+	if safeName == "." || safeName == string(filepath.Separator) {
+		safeName = "file"
+	}
+
+	mimeType, size, err := s.policy.inspect(file, slot)
+	if err != nil {
+		return dto.MediaDTO{}, err
+	}
+
+	folder := slot.folder()
+	stamp := strconv.FormatInt(time.Now().UnixNano(), 10)
+	media := models.Media{
+		FileName:   safeName,
+		MimeType:   mimeType,
+		Size:       size,
+		UploadedBy: adminID,
+		Variants:   datatypes.JSONSlice[models.MediaVariant]{},
+	}
+
+	if imaging.Processable(media.MimeType) {
+		base := strings.TrimSuffix(safeName, filepath.Ext(safeName))
+		if err := s.uploadVariants(ctx, &media, file, folder+"/"+stamp+"_"+base); err != nil {
+			return dto.MediaDTO{}, err
+		}
+	} else {
+		media.ObjectName = folder + "/" + stamp + "_" + safeName
+		if strings.HasPrefix(media.MimeType, "image/") {
+			if cfg, _, err := image.DecodeConfig(file); err == nil {
+				media.Width, media.Height = &cfg.Width, &cfg.Height
+			}
+			if _, err := file.Seek(0, io.SeekStart); err != nil {
+				return dto.MediaDTO{}, err
+			}
+		}
+
+		media.URL, err = s.store.UploadFile(ctx, file, media.ObjectName)
+		if err != nil {
+			logrus.WithError(err).WithField("object", media.ObjectName).Error("failed upload media")
+			return dto.MediaDTO{}, err
+		}
+	}
+
+	if err := s.repo.Create(&media); err != nil {
+		logrus.WithError(err).WithField("object", media.ObjectName).Error("failed create media record")
+		// don't leave untracked files behind
+		s.deleteObjects(context.WithoutCancel(ctx), mediaObjects(media))
+		return dto.MediaDTO{}, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"id":        media.ID,
+		"object":    media.ObjectName,
+		"mime_type": media.MimeType,
+		"size":      media.Size,
+		"variants":  len(media.Variants),
+		"admin_id":  adminID,
+	}).Info("media uploaded")
+	return dto.MediaModelToDTO(media), nil
+}
+
+// uploadVariants stores the resized variants as <prefix>_<width>w.<ext> and makes
+// the widest one the item's own file.
+func (s *mediaService) uploadVariants(ctx context.Context, media *models.Media, file io.Reader, prefix string) error {
+	variants, err := imaging.Process(file, imaging.DefaultWidths)
+	if err != nil {
+		logrus.WithError(err).WithField("file", media.FileName).Warn("failed process image")
+		return err
+	}
+
+	for _, v := range variants {
+		name := prefix + "_" + strconv.Itoa(v.Width) + "w" + v.Ext
+		url, err := s.store.UploadFile(ctx, bytes.NewReader(v.Data), name)
+		if err != nil {
+			logrus.WithError(err).WithField("object", name).Error("failed upload media variant")
+			s.deleteObjects(context.WithoutCancel(ctx), mediaObjects(*media))
+			return err
+		}
+		media.Variants = append(media.Variants, models.MediaVariant{
+			ImageSource: models.ImageSource{URL: url, Width: v.Width, Height: v.Height},
+			ObjectName:  name,
+			MimeType:    v.MimeType,
+			Size:        int64(len(v.Data)),
+		})
+	}
+
+	widest := media.Variants[len(media.Variants)-1]
+	media.ObjectName = widest.ObjectName
+	media.URL = widest.URL
+	media.MimeType = widest.MimeType
+	media.Size = widest.Size
+	media.Width, media.Height = &widest.Width, &widest.Height
+	return nil
+}
+
+// mediaObjects lists every stored object of an item (its file and all variants).
+func mediaObjects(media models.Media) []string {
+	names := make([]string, 0, len(media.Variants)+1)
+	if media.ObjectName != "" {
+		names = append(names, media.ObjectName)
+	}
+	for _, v := range media.Variants {
+		if v.ObjectName != media.ObjectName {
+			names = append(names, v.ObjectName)
+		}
+	}
+	return names
+}
+
+// deleteObjects removes files best-effort; leftovers are only wasted space.
+func (s *mediaService) deleteObjects(ctx context.Context, names []string) {
+	for _, name := range names {
+		if err := s.store.DeleteFile(ctx, name); err != nil {
+			logrus.WithError(err).WithField("object", name).Warn("failed delete media file")
+		}
+	}
+}
+
+func (s *mediaService) GetAllMedia(page, limit int, filter repository.MediaFilter) ([]dto.MediaDTO, int64, error) {
+	rows, total, err := s.repo.List(page, limit, filter)
+	if err != nil {
+		logrus.WithError(err).Error("failed list media")
+		return nil, 0, err
+	}
+
+	out := make([]dto.MediaDTO, 0, len(rows))
+	for _, r := range rows {
+		d := dto.MediaModelToDTO(r.Media)
+		count := r.UsageCount
+		d.UsageCount = &count
+		out = append(out, d)
+	}
+	return out, total, nil
+}
+
+func (s *mediaService) GetMedia(id uint) (dto.MediaDTO, error) {
+	media, err := s.getMedia(id)
+	if err != nil {
+		return dto.MediaDTO{}, err
+	}
+	count, err := s.repo.CountUsage(id)
+	if err != nil {
+		logrus.WithError(err).WithField("id", id).Error("failed count media usage")
+		return dto.MediaDTO{}, err
+	}
+
+	out := dto.MediaModelToDTO(media)
+	out.UsageCount = &count
+	return out, nil
+}
+
+func (s *mediaService) GetMediaUsage(id uint) ([]dto.MediaUsageDTO, error) {
+	if _, err := s.getMedia(id); err != nil {
+		return nil, err
+	}
+	articles, err := s.repo.GetUsage(id)
+	if err != nil {
+		logrus.WithError(err).WithField("id", id).Error("failed get media usage")
+		return nil, err
+	}
+
+	out := make([]dto.MediaUsageDTO, 0, len(articles))
+	for _, a := range articles {
+		out = append(out, dto.MediaUsageFromArticle(a))
+	}
+	return out, nil
+}
+
+func (s *mediaService) DeleteMedia(ctx context.Context, id uint) error {
+	media, err := s.getMedia(id)
+	if err != nil {
+		return err
+	}
+	count, err := s.repo.CountUsage(id)
+	if err != nil {
+		logrus.WithError(err).WithField("id", id).Error("failed count media usage")
+		return err
+	}
+	if count > 0 {
+		return ErrMediaInUse
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFoundMedia
+		}
+		logrus.WithError(err).WithField("id", id).Error("failed delete media")
+		return err
+	}
+	s.deleteObjects(ctx, mediaObjects(media))
+
+	logrus.WithFields(logrus.Fields{"id": id, "object": media.ObjectName}).Info("media deleted")
+	return nil
+}
+
+func (s *mediaService) CollectOrphans(ctx context.Context, now time.Time, dryRun bool) (dto.MediaGCReportDTO, error) {
+	cutoff := now.Add(-s.gcGrace)
+	report := dto.MediaGCReportDTO{
+		DryRun:      dryRun,
+		Prefix:      mediaGCPrefix,
+		GracePeriod: s.gcGrace.String(),
+		Cutoff:      cutoff.Unix(),
+		Items:       make([]dto.MediaGCObjectDTO, 0),
+	}
+
+	// list first: an object uploaded after the reference scan started is never
+	// mistaken for an orphan, and the grace period covers saves still in flight
+	objects, err := s.store.ListFiles(ctx, mediaGCPrefix)
+	if err != nil {
+		logrus.WithError(err).Error("failed list media objects")
+		return report, err
+	}
+	report.Scanned = len(objects)
+
+	refURLs, refIDs, err := s.references()
+	if err != nil {
+		return report, err
+	}
+
+	// references are matched by object name, not by URL: content saved before
+	// the bucket or its public URL changed still points at the same objects
+	report.BaseURL = storageBaseURL(objects)
+	refNames := map[string]bool{}
+	onPrefix, onBase := 0, 0
+	for ref := range refURLs {
+		names := referencedObjectNames(ref)
+		if len(names) == 0 {
+			continue
+		}
+		for _, name := range names {
+			refNames[name] = true
+		}
+		onPrefix++
+		if strings.HasPrefix(ref, report.BaseURL) {
+			onBase++
+		}
+	}
+
+	// a processed image is one item: when any of its renditions is used (say a
+	// variant URL without media_id, which gets no srcset), all of them are kept
+	unreferenced := make([]string, 0)
+	for _, obj := range objects {
+		if !refNames[obj.Name] {
+			unreferenced = append(unreferenced, obj.Name)
+		}
+	}
+	items, err := s.repo.GetByObjectNames(unreferenced)
+	if err != nil {
+		logrus.WithError(err).Error("failed get media by object names")
+		return report, err
+	}
+	itemByObject := make(map[string]models.Media, len(items))
+	for _, m := range items {
+		for _, name := range mediaObjects(m) {
+			itemByObject[name] = m
+		}
+	}
+	itemUsed := func(m models.Media) bool {
+		if refIDs[m.ID] {
+			return true
+		}
+		for _, name := range mediaObjects(m) {
+			if refNames[name] {
+				return true
+			}
+		}
+		return false
+	}
+
+	orphans := make([]string, 0)
+	for _, obj := range objects {
+		item, hasItem := itemByObject[obj.Name]
+		switch {
+		case refNames[obj.Name] || (hasItem && itemUsed(item)):
+			report.Referenced++
+		case !obj.Updated.Before(cutoff):
+			report.WithinGrace++
+		default:
+			orphans = append(orphans, obj.Name)
+			report.OrphanBytes += obj.Size
+			gcObj := dto.MediaGCObjectDTO{
+				ObjectName: obj.Name,
+				URL:        obj.URL,
+				Size:       obj.Size,
+				UpdatedAt:  obj.Updated.Unix(),
+			}
+			if hasItem {
+				id := item.ID
+				gcObj.MediaID = &id
+			}
+			report.Items = append(report.Items, gcObj)
+		}
+	}
+	report.Orphans = len(orphans)
+
+	// articles do use uploads, but none of them through the store's current URL:
+	// the store likely points at another bucket than the one articles were
+	// written against, so nothing it lists is trusted to be unused
+	if len(orphans) > 0 && onPrefix > 0 && onBase == 0 {
+		logrus.WithFields(logrus.Fields{"base_url": report.BaseURL, "references": onPrefix}).
+			Error("no article references the storage base url; refusing to collect media")
+		return report, ErrMediaGCBaseMismatch
+	}
+
+	if dryRun || len(orphans) == 0 {
+		return report, nil
+	}
+
+	// drop the library rows first so nothing keeps pointing at a missing file
+	if report.MediaDeleted, err = s.repo.DeleteByObjectNames(orphans); err != nil {
+		logrus.WithError(err).Error("failed delete orphaned media records")
+		return report, err
+	}
+	for _, name := range orphans {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if err := s.store.DeleteFile(ctx, name); err != nil {
+			logrus.WithError(err).WithField("object", name).Warn("failed delete orphaned media file")
+			continue
+		}
+		report.Deleted++
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"scanned":       report.Scanned,
+		"orphans":       report.Orphans,
+		"deleted":       report.Deleted,
+		"media_deleted": report.MediaDeleted,
+		"bytes":         report.OrphanBytes,
+	}).Info("orphaned media collected")
+	return report, nil
+}
+
+// references collects every URL used by an article (trashed included) as
+// photo_header (and its srcset) or anywhere in content (srcset entries
+// included), and the media IDs content refers to.
+func (s *mediaService) references() (map[string]bool, map[uint]bool, error) {
+	urls := map[string]bool{}
+	ids := map[uint]bool{}
+	var afterID uint
+	for {
+		articles, err := s.articleRepo.GetContentBatch(afterID, mediaGCBatch)
+		if err != nil {
+			logrus.WithError(err).Error("failed scan article media references")
+			return nil, nil, err
+		}
+		for _, a := range articles {
+			if header := strings.TrimSpace(a.PhotoHeader); header != "" {
+				urls[header] = true
+			}
+			for _, src := range a.PhotoHeaderSrcset {
+				urls[src.URL] = true
+			}
+			contentIDs, contentURLs := content.MediaRefs(a.Content)
+			for _, u := range contentURLs {
+				urls[u] = true
+			}
+			for _, id := range contentIDs {
+				ids[id] = true
+			}
+			afterID = a.ID
+		}
+		if len(articles) < mediaGCBatch {
+			return urls, ids, nil
+		}
+	}
+}
+
+// storageBaseURL is what the store puts before an object name in its URLs
+// ("" for private stores, whose URLs are the names themselves).
+func storageBaseURL(objects []repository.StorageObject) string {
+	for _, obj := range objects {
+		if strings.HasSuffix(obj.URL, obj.Name) {
+			return strings.TrimSuffix(obj.URL, obj.Name)
+		}
+	}
+	return ""
+}
+
+// referencedObjectNames lists the article upload object names ref may point at:
+// its path and every tail of it starting after a slash, under mediaGCPrefix. So
+// "https://cdn.example/bucket/articles/header/a.jpg?v=2" gives
+// "articles/header/a.jpg", whatever host and bucket path are in front.
+func referencedObjectNames(ref string) []string {
+	path := ref
+	if u, err := url.Parse(ref); err == nil {
+		path = u.Path
+	}
+	path = strings.TrimLeft(path, "/")
+
+	var names []string
+	for {
+		if strings.HasPrefix(path, mediaGCPrefix) {
+			names = append(names, path)
+		}
+		i := strings.Index(path, "/")
+		if i < 0 {
+			return names
+		}
+		path = path[i+1:]
+	}
+}
+
+func (s *mediaService) getMedia(id uint) (models.Media, error) {
+	media, err := s.repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Media{}, ErrNotFoundMedia
+		}
+		logrus.WithError(err).WithField("id", id).Error("failed get media")
+		return models.Media{}, err
+	}
+	return media, nil
+}
+
+// detectMimeType sniffs the type from the file content (never from its name) and
+// leaves file rewound. Unrecognized content is application/octet-stream.
+func detectMimeType(file io.ReadSeeker) (string, error) {
+	detected, err := mimetype.DetectReader(file)
+	if err != nil {
+		return "", err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	mimeType, _, err := mime.ParseMediaType(detected.String())
+	if err != nil {
+		return "application/octet-stream", nil
+	}
+	return mimeType, nil
+}
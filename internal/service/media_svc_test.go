@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"darulabror/internal/models"
+	"darulabror/internal/repository"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type gcStore struct {
+	repository.StorageRepo
+	objects []repository.StorageObject
+	deleted []string
+}
+
+func (s *gcStore) ListFiles(ctx context.Context, prefix string) ([]repository.StorageObject, error) {
+	return s.objects, nil
+}
+
+func (s *gcStore) DeleteFile(ctx context.Context, objectName string) error {
+	s.deleted = append(s.deleted, objectName)
+	return nil
+}
+
+type gcMediaRepo struct {
+	repository.MediaRepo
+	items   []models.Media
+	deleted []string
+}
+
+func (r *gcMediaRepo) GetByObjectNames(names []string) ([]models.Media, error) {
+	var out []models.Media
+	for _, m := range r.items {
+		for _, name := range mediaObjects(m) {
+			if slices.Contains(names, name) {
+				out = append(out, m)
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func (r *gcMediaRepo) DeleteByObjectNames(names []string) (int64, error) {
+	r.deleted = append(r.deleted, names...)
+	var n int64
+	for _, m := range r.items {
+		if slices.Contains(names, m.ObjectName) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+type gcArticleRepo struct {
+	repository.ArticleRepo
+	articles []models.Article
+}
+
+func (r *gcArticleRepo) GetContentBatch(afterID uint, limit int) ([]models.Article, error) {
+	var out []models.Article
+	for _, a := range r.articles {
+		if a.ID > afterID && len(out) < limit {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func TestCollectOrphans(t *testing.T) {
+	now := time.Unix(1_750_000_000, 0)
+	old, recent := now.Add(-30*24*time.Hour), now.Add(-time.Hour)
+	object := func(base, name string, updated time.Time) repository.StorageObject {
+		return repository.StorageObject{Name: name, URL: base + name, Size: 100, Updated: updated}
+	}
+	article := func(id uint, header, content string) models.Article {
+		if content == "" {
+			content = `{"blocks":[]}`
+		}
+		return models.Article{ID: id, PhotoHeader: header, Content: datatypes.JSON(content)}
+	}
+
+	const cdn = "https://cdn.example/media/"
+	const oldCDN = "https://storage.googleapis.com/old-bucket/"
+	processed := models.Media{
+		ID:         9,
+		ObjectName: "articles/header/2_foto_1280.webp",
+		URL:        cdn + "articles/header/2_foto_1280.webp",
+		Variants: datatypes.NewJSONSlice([]models.MediaVariant{
+			{ImageSource: models.ImageSource{URL: cdn + "articles/header/2_foto_480.webp"}, ObjectName: "articles/header/2_foto_480.webp"},
+			{ImageSource: models.ImageSource{URL: cdn + "articles/header/2_foto_1280.webp"}, ObjectName: "articles/header/2_foto_1280.webp"},
+		}),
+	}
+
+	tests := []struct {
+		name        string
+		objects     []repository.StorageObject
+		items       []models.Media
+		articles    []models.Article
+		wantErr     error
+		wantBase    string
+		wantRef     int
+		wantGrace   int
+		wantOrphans []string
+	}{
+		{
+			name: "unreferenced and old",
+			objects: []repository.StorageObject{
+				object(cdn, "articles/header/1_used.jpg", old),
+				object(cdn, "articles/header/1_unused.jpg", old),
+				object(cdn, "articles/content/1_new.jpg", recent),
+			},
+			articles:    []models.Article{article(1, cdn+"articles/header/1_used.jpg", "")},
+			wantBase:    cdn,
+			wantRef:     1,
+			wantGrace:   1,
+			wantOrphans: []string{"articles/header/1_unused.jpg"},
+		},
+		{
+			name: "matched by object name after the base url changed",
+			objects: []repository.StorageObject{
+				object(cdn, "articles/header/1_used.jpg", old),
+				object(cdn, "articles/content/1_inline.jpg", old),
+				object(cdn, "articles/content/1_unused.jpg", old),
+			},
+			articles: []models.Article{
+				article(1, oldCDN+"articles/header/1_used.jpg", `{"blocks":[{"type":"image","url":"`+cdn+`articles/content/1_inline.jpg"}]}`),
+				article(2, oldCDN+"articles/content/1_unused.jpg?ignore=1", `{"blocks":[]}`),
+			},
+			wantBase: cdn,
+			wantRef:  3,
+		},
+		{
+			name: "a variant keeps the whole item",
+			objects: []repository.StorageObject{
+				object(cdn, "articles/header/2_foto_480.webp", old),
+				object(cdn, "articles/header/2_foto_1280.webp", old),
+			},
+			items:    []models.Media{processed},
+			articles: []models.Article{article(1, cdn+"articles/header/2_foto_480.webp?v=2", "")},
+			wantBase: cdn,
+			wantRef:  2,
+		},
+		{
+			name: "media_id keeps the item",
+			objects: []repository.StorageObject{
+				object(cdn, "articles/header/2_foto_480.webp", old),
+				object(cdn, "articles/header/2_foto_1280.webp", old),
+				object(cdn, "articles/header/1_used.jpg", old),
+			},
+			items:    []models.Media{processed},
+			articles: []models.Article{article(1, cdn+"articles/header/1_used.jpg", `{"blocks":[{"type":"image","media_id":9}]}`)},
+			wantBase: cdn,
+			wantRef:  3,
+		},
+		{
+			name: "private store",
+			objects: []repository.StorageObject{
+				object("", "articles/header/1_used.jpg", old),
+				object("", "articles/header/1_unused.jpg", old),
+			},
+			articles:    []models.Article{article(1, "articles/header/1_used.jpg", "")},
+			wantRef:     1,
+			wantOrphans: []string{"articles/header/1_unused.jpg"},
+		},
+		{
+			name: "no references at all",
+			objects: []repository.StorageObject{
+				object(cdn, "articles/header/1_unused.jpg", old),
+			},
+			articles:    []models.Article{article(1, "https://example.com/stock.jpg", "")},
+			wantBase:    cdn,
+			wantOrphans: []string{"articles/header/1_unused.jpg"},
+		},
+		{
+			name: "refused when no reference uses the current base url",
+			objects: []repository.StorageObject{
+				object(cdn, "articles/header/1_used.jpg", old),
+				object(cdn, "articles/header/1_unused.jpg", old),
+			},
+			articles:    []models.Article{article(1, oldCDN+"articles/header/1_used.jpg", "")},
+			wantErr:     ErrMediaGCBaseMismatch,
+			wantBase:    cdn,
+			wantRef:     1,
+			wantOrphans: []string{"articles/header/1_unused.jpg"}, // reported, not deleted
+		},
+	}
+	for _, tt := range tests {
+		for _, dryRun := range []bool{true, false} {
+			store := &gcStore{objects: tt.objects}
+			repo := &gcMediaRepo{items: tt.items}
+			svc := NewMediaService(repo, &gcArticleRepo{articles: tt.articles}, store, UploadPolicy{}, 7*24*time.Hour)
+
+			report, err := svc.CollectOrphans(context.Background(), now, dryRun)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("%s (dry run %v): err %v, want %v", tt.name, dryRun, err, tt.wantErr)
+			}
+			if report.BaseURL != tt.wantBase || report.Referenced != tt.wantRef || report.WithinGrace != tt.wantGrace ||
+				report.Orphans != len(tt.wantOrphans) || len(report.Items) != len(tt.wantOrphans) {
+				t.Errorf("%s (dry run %v): report %+v", tt.name, dryRun, report)
+			}
+
+			wantDeleted := tt.wantOrphans
+			if dryRun || tt.wantErr != nil {
+				wantDeleted = nil
+			}
+			if !reflect.DeepEqual(store.deleted, wantDeleted) || !reflect.DeepEqual(repo.deleted, wantDeleted) {
+				t.Errorf("%s (dry run %v): deleted files %v, rows %v; want %v", tt.name, dryRun, store.deleted, repo.deleted, wantDeleted)
+			}
+		}
+	}
+}
+
+func TestReferencedObjectNames(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want []string
+	}{
+		{"https://storage.googleapis.com/bucket/articles/header/1_a.jpg", []string{"articles/header/1_a.jpg"}},
+		{"https://cdn.example/articles/content/1_a%20b.jpg?v=2#top", []string{"articles/content/1_a b.jpg"}},
+		{"/uploads/articles/header_1_a.jpg", []string{"articles/header_1_a.jpg"}},
+		{"articles/header/1_a.jpg", []string{"articles/header/1_a.jpg"}},
+		{"https://s3.example/articles/articles/header/1_a.jpg", []string{"articles/articles/header/1_a.jpg", "articles/header/1_a.jpg"}},
+		{"https://cdn.example/media/1_a.jpg", nil},
+		{"https://example.com/", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := referencedObjectNames(tt.ref); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: got %q, want %q", tt.ref, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"darulabror/internal/mailer"
+	"darulabror/internal/messaging"
+	"darulabror/internal/models"
+	"darulabror/internal/repository"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NotificationService mails applicants, contact senders and admins, and sends
+// registration events to the families' phones as well (DeliveryService). Both
+// are sent in the background; failures are logged, never returned to the
+// request that caused them.
+type NotificationService interface {
+	RegistrationReceived(reg models.Registration, period models.AdmissionPeriod)
+	RegistrationStatusChanged(reg models.Registration, status models.RegistrationStatus)
+	ContactReceived(email string)
+
+	// SendAdminDigest mails the admins the registrations and contact messages of
+	// the last completed digest window, once per window. Windows end at multiples
+	// of the digest interval in WIB (daily: 00:00 WIB).
+	SendAdminDigest(ctx context.Context, now time.Time) error
+}
+
+// digestListLimit caps the registrations / contacts listed in a digest.
+const digestListLimit = 50
+
+// statusMail describes each status for applicants: its label and what happens next.
+var statusMail = map[models.RegistrationStatus]struct{ Label, Info string }{
+	models.RegistrationStatusNew:       {"Diterima", "Pendaftaran akan segera kami periksa."},
+	models.RegistrationStatusValidate:  {"Sedang divalidasi", "Panitia sedang memeriksa data dan dokumen pendaftaran. Pastikan semua dokumen persyaratan sudah diunggah."},
+	models.RegistrationStatusProcess:   {"Sedang diproses", "Data dan dokumen pendaftaran sudah valid. Panitia akan menghubungi Anda untuk tahap selanjutnya."},
+	models.RegistrationStatusDone:      {"Selesai", "Alhamdulillah, proses pendaftaran telah selesai. Informasi daftar ulang akan disampaikan oleh panitia."},
+	models.RegistrationStatusRejected:  {"Ditolak", "Mohon maaf, pendaftaran tidak dapat kami lanjutkan. Silakan hubungi panitia untuk informasi lebih lanjut."},
+	models.RegistrationStatusWithdrawn: {"Dibatalkan", "Pendaftaran telah dibatalkan. Silakan hubungi panitia bila ini tidak sesuai."},
+}
+
+type registrationNotice struct {
+	SiteName        string
+	SiteURL         string
+	FullName        string
+	ReferenceNumber string
+	AcademicYear    string
+	WaveName        string
+	StatusLabel     string
+	StatusInfo      string
+}
+
+type contactMail struct {
+	SiteName string
+}
+
+type digestMail struct {
+	SiteName          string
+	From, To          time.Time
+	RegistrationTotal int64
+	Registrations     []models.Registration
+	MoreRegistrations int64
+	ContactTotal      int64
+	Contacts          []models.Contact
+	MoreContacts      int64
+}
+
+type notificationService struct {
+	queue          *mailer.Queue
+	messages       DeliveryService
+	regRepo        repository.RegistrationRepo
+	contactRepo    repository.ContactRepository
+	repo           repository.NotificationRepo
+	site           SiteConfig
+	adminEmails    []string
+	digestInterval time.Duration
+}
+
+func NewNotificationService(queue *mailer.Queue, messages DeliveryService, regRepo repository.RegistrationRepo, contactRepo repository.ContactRepository, repo repository.NotificationRepo, site SiteConfig, adminEmails []string, digestInterval time.Duration) NotificationService {
+	return &notificationService{
+		queue:          queue,
+		messages:       messages,
+		regRepo:        regRepo,
+		contactRepo:    contactRepo,
+		repo:           repo,
+		site:           site,
+		adminEmails:    adminEmails,
+		digestInterval: digestInterval,
+	}
+}
+
+func (s *notificationService) RegistrationReceived(reg models.Registration, period models.AdmissionPeriod) {
+	data := s.notice(reg)
+	data.AcademicYear, data.WaveName = period.AcademicYear, period.WaveName
+	s.send(mailer.TemplateRegistrationReceived, data, reg.Email)
+	s.messages.Queue(messaging.TemplateRegistrationReceived, reg, data)
+}
+
+func (s *notificationService) RegistrationStatusChanged(reg models.Registration, status models.RegistrationStatus) {
+	data := s.notice(reg)
+	data.StatusLabel, data.StatusInfo = statusMail[status].Label, statusMail[status].Info
+	s.send(mailer.TemplateRegistrationStatusChanged, data, reg.Email)
+	s.messages.Queue(messaging.TemplateRegistrationStatusChanged, reg, data)
+}
+
+func (s *notificationService) ContactReceived(email string) {
+	s.send(mailer.TemplateContactAcknowledged, contactMail{SiteName: s.site.Name}, email)
+}
+
+func (s *notificationService) SendAdminDigest(ctx context.Context, now time.Time) error {
+	if len(s.adminEmails) == 0 || s.digestInterval <= 0 {
+		return nil
+	}
+
+	// windows are aligned on WIB midnight (for intervals dividing a day)
+	_, offset := now.In(mailer.WIB).Zone()
+	shift := time.Duration(offset) * time.Second
+	to := now.Add(shift).Truncate(s.digestInterval).Add(-shift)
+	from := to.Add(-s.digestInterval)
+
+	regs, regTotal, err := s.regRepo.GetCreatedBetween(from, to, digestListLimit)
+	if err != nil {
+		logrus.WithError(err).Error("failed get registrations for admin digest")
+		return err
+	}
+	contacts, contactTotal, err := s.contactRepo.GetCreatedBetween(from, to, digestListLimit)
+	if err != nil {
+		logrus.WithError(err).Error("failed get contacts for admin digest")
+		return err
+	}
+	if regTotal == 0 && contactTotal == 0 {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	claimed, err := s.repo.ClaimDigest(to, now)
+	if err != nil {
+		logrus.WithError(err).Error("failed claim admin digest")
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	s.send(mailer.TemplateAdminDigest, digestMail{
+		SiteName:          s.site.Name,
+		From:              from,
+		To:                to,
+		RegistrationTotal: regTotal,
+		Registrations:     regs,
+		MoreRegistrations: regTotal - int64(len(regs)),
+		ContactTotal:      contactTotal,
+		Contacts:          contacts,
+		MoreContacts:      contactTotal - int64(len(contacts)),
+	}, s.adminEmails...)
+
+	logrus.WithFields(logrus.Fields{
+		"from":          from.Format(time.RFC3339),
+		"to":            to.Format(time.RFC3339),
+		"registrations": regTotal,
+		"contacts":      contactTotal,
+	}).Info("admin digest queued")
+	return nil
+}
+
+func (s *notificationService) notice(reg models.Registration) registrationNotice {
+	data := registrationNotice{
+		SiteName:        s.site.Name,
+		FullName:        reg.FullName,
+		ReferenceNumber: reg.ReferenceNumber,
+	}
+	if s.site.Configured() {
+		data.SiteURL = s.site.BaseURL
+	}
+	return data
+}
+
+func (s *notificationService) send(template string, data any, to ...string) {
+	msg, err := mailer.Render(template, data, to...)
+	if err != nil {
+		logrus.WithError(err).WithField("template", template).Error("failed render mail")
+		return
+	}
+	s.queue.Enqueue(msg)
+}
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"darulabror/internal/dto"
+	"darulabror/internal/models"
+	"darulabror/internal/repository"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// RegistrationDocumentService handles the documents applicants attach to their
+// registration. Files go to the private store; admins read them through signed
+// URLs only.
+type RegistrationDocumentService interface {
+	// Public (authorized by the upload token handed out on registration)
+	GetChecklist(token string) (dto.RegistrationDocumentChecklistDTO, error)
+	UploadDocument(ctx context.Context, token string, docType models.RegistrationDocumentType, file io.ReadSeeker, fileName string) (dto.RegistrationDocumentDTO, error)
+
+	// Admin
+	ListDocuments(registrationID uint) (dto.RegistrationDocumentChecklistDTO, error)
+	DocumentURL(ctx context.Context, adminID, registrationID, id uint) (dto.SignedURLDTO, error)
+	ReviewDocument(adminID, registrationID, id uint, req dto.RegistrationDocumentReviewRequest) (dto.RegistrationDocumentDTO, error)
+
+	// CollectOrphans deletes stored documents no registration owns anymore (their
+	// registration was purged from the trash, or the upload failed half-way).
+	CollectOrphans(ctx context.Context, now time.Time) (int, error)
+}
+
+const (
+	documentPrefix = "registrations/"
+	// uploads younger than this may still be waiting for their database row
+	documentGCGrace = time.Hour
+)
+
+type registrationDocumentService struct {
+	regRepo repository.RegistrationRepo
+	repo    repository.RegistrationDocumentRepo
+	store   repository.StorageRepo // private store
+	files   FileService
+	policy  UploadPolicy
+}
+
+func NewRegistrationDocumentService(regRepo repository.RegistrationRepo, repo repository.RegistrationDocumentRepo, store repository.StorageRepo, files FileService, policy UploadPolicy) RegistrationDocumentService {
+	return &registrationDocumentService{
+		regRepo: regRepo,
+		repo:    repo,
+		store:   store,
+		files:   files,
+		policy:  policy,
+	}
+}
+
+func (s *registrationDocumentService) GetChecklist(token string) (dto.RegistrationDocumentChecklistDTO, error) {
+	reg, err := s.registrationByToken(token)
+	if err != nil {
+		return dto.RegistrationDocumentChecklistDTO{}, err
+	}
+	return s.ListDocuments(reg.ID)
+}
+
+func (s *registrationDocumentService) UploadDocument(ctx context.Context, token string, docType models.RegistrationDocumentType, file io.ReadSeeker, fileName string) (dto.RegistrationDocumentDTO, error) {
+	if !validDocumentType(docType) {
+		return dto.RegistrationDocumentDTO{}, ErrInvalidDocumentType
+	}
+	reg, err := s.registrationByToken(token)
+	if err != nil {
+		return dto.RegistrationDocumentDTO{}, err
+	}
+	if reg.Status == models.RegistrationStatusDone || reg.Status.IsTerminal() {
+		return dto.RegistrationDocumentDTO{}, ErrRegistrationClosed
+	}
+
+	previous, err := s.repo.GetByType(reg.ID, docType)
+	hasPrevious := err == nil
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logrus.WithError(err).WithField("registration_id", reg.ID).Error("failed get registration document")
+		return dto.RegistrationDocumentDTO{}, err
+	}
+	if hasPrevious && previous.Status == models.DocumentVerified {
+		return dto.RegistrationDocumentDTO{}, ErrDocumentVerified
+	}
+
+	mimeType, size, err := s.policy.inspect(file, UploadSlotRegistration)
+	if err != nil {
+		return dto.RegistrationDocumentDTO{}, err
+	}
+	if docType == models.DocumentPhoto && !strings.HasPrefix(mimeType, "image/") {
+		return dto.RegistrationDocumentDTO{}, fmt.Errorf("%w: %s must be an image", ErrUnsupportedMediaType, docType)
+	}
+
+	objectName := documentObjectName(reg.ID, docType, mimeType, time.Now())
+	if _, err := s.store.UploadFile(ctx, file, objectName); err != nil {
+		if errors.Is(err, repository.ErrStorageNotConfigured) {
+			return dto.RegistrationDocumentDTO{}, ErrPrivateFilesDisabled
+		}
+		logrus.WithError(err).WithField("registration_id", reg.ID).Error("failed upload registration document")
+		return dto.RegistrationDocumentDTO{}, err
+	}
+
+	doc := models.RegistrationDocument{
+		RegistrationID: reg.ID,
+		Type:           docType,
+		ObjectName:     objectName,
+		FileName:       filepath.Base(fileName),
+		MimeType:       mimeType,
+		Size:           size,
+		Status:         models.DocumentPending,
+	}
+	if err := s.repo.Save(&doc); err != nil {
+		logrus.WithError(err).WithField("registration_id", reg.ID).Error("failed save registration document")
+		s.deleteObject(ctx, objectName)
+		return dto.RegistrationDocumentDTO{}, err
+	}
+	if hasPrevious && previous.ObjectName != objectName {
+		s.deleteObject(ctx, previous.ObjectName)
+	}
+
+	// the upsert doesn't return the row as stored (created_at of a replaced document)
+	saved, err := s.repo.GetByType(reg.ID, docType)
+	if err != nil {
+		logrus.WithError(err).WithField("registration_id", reg.ID).Error("failed get registration document")
+		return dto.RegistrationDocumentDTO{}, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"registration_id": reg.ID,
+		"type":            docType,
+		"object":          objectName,
+		"replaced":        hasPrevious,
+	}).Info("registration document uploaded")
+	return dto.RegistrationDocumentModelToDTO(saved), nil
+}
+
+func (s *registrationDocumentService) ListDocuments(registrationID uint) (dto.RegistrationDocumentChecklistDTO, error) {
+	if _, err := s.regRepo.GetByID(registrationID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.RegistrationDocumentChecklistDTO{}, ErrNotFoundRegistration
+		}
+		logrus.WithError(err).WithField("id", registrationID).Error("failed get registration by id")
+		return dto.RegistrationDocumentChecklistDTO{}, err
+	}
+
+	docs, err := s.repo.ListByRegistration(registrationID)
+	if err != nil {
+		logrus.WithError(err).WithField("registration_id", registrationID).Error("failed list registration documents")
+		return dto.RegistrationDocumentChecklistDTO{}, err
+	}
+
+	out := dto.RegistrationDocumentChecklistDTO{
+		Documents: make([]dto.RegistrationDocumentDTO, 0, len(docs)),
+		Missing:   dto.MissingRegistrationDocuments(docs),
+	}
+	for _, d := range docs {
+		out.Documents = append(out.Documents, dto.RegistrationDocumentModelToDTO(d))
+	}
+	return out, nil
+}
+
+func (s *registrationDocumentService) DocumentURL(ctx context.Context, adminID, registrationID, id uint) (dto.SignedURLDTO, error) {
+	doc, err := s.getDocument(registrationID, id)
+	if err != nil {
+		return dto.SignedURLDTO{}, err
+	}
+	return s.files.SignedURL(ctx, adminID, doc.ObjectName)
+}
+
+func (s *registrationDocumentService) ReviewDocument(adminID, registrationID, id uint, req dto.RegistrationDocumentReviewRequest) (dto.RegistrationDocumentDTO, error) {
+	status := models.DocumentStatus(req.Status)
+	reason := strings.TrimSpace(req.Reason)
+	switch status {
+	case models.DocumentRejected:
+		if reason == "" {
+			return dto.RegistrationDocumentDTO{}, ErrDocumentReasonRequired
+		}
+	case models.DocumentVerified:
+		reason = ""
+	default:
+		return dto.RegistrationDocumentDTO{}, ErrInvalidDocumentStatus
+	}
+
+	if err := s.repo.Review(registrationID, id, status, reason, adminID, time.Now()); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.RegistrationDocumentDTO{}, ErrNotFoundDocument
+		}
+		logrus.WithError(err).WithField("id", id).Error("failed review registration document")
+		return dto.RegistrationDocumentDTO{}, err
+	}
+
+	doc, err := s.getDocument(registrationID, id)
+	if err != nil {
+		return dto.RegistrationDocumentDTO{}, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"registration_id": registrationID,
+		"id":              id,
+		"status":          status,
+		"admin_id":        adminID,
+	}).Info("registration document reviewed")
+	return dto.RegistrationDocumentModelToDTO(doc), nil
+}
+
+func (s *registrationDocumentService) CollectOrphans(ctx context.Context, now time.Time) (int, error) {
+	objects, err := s.store.ListFiles(ctx, documentPrefix)
+	if err != nil {
+		logrus.WithError(err).Error("failed list registration document objects")
+		return 0, err
+	}
+
+	cutoff := now.Add(-documentGCGrace)
+	names := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		if obj.Updated.Before(cutoff) {
+			names = append(names, obj.Name)
+		}
+	}
+	existing, err := s.repo.ExistingObjectNames(names)
+	if err != nil {
+		logrus.WithError(err).Error("failed get registration document object names")
+		return 0, err
+	}
+	owned := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		owned[name] = true
+	}
+
+	deleted := 0
+	for _, name := range names {
+		if owned[name] {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+		if err := s.store.DeleteFile(ctx, name); err != nil {
+			logrus.WithError(err).WithField("object", name).Warn("failed delete orphaned registration document")
+			continue
+		}
+		deleted++
+	}
+
+	if deleted > 0 {
+		logrus.WithField("deleted", deleted).Info("orphaned registration documents collected")
+	}
+	return deleted, nil
+}
+
+func (s *registrationDocumentService) registrationByToken(token string) (models.Registration, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return models.Registration{}, ErrInvalidUploadToken
+	}
+	reg, err := s.regRepo.GetByUploadTokenHash(hashSecretToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Registration{}, ErrInvalidUploadToken
+		}
+		logrus.WithError(err).Error("failed get registration by upload token")
+		return models.Registration{}, err
+	}
+	if reg.UploadTokenExpiresAt != nil && !time.Now().Before(*reg.UploadTokenExpiresAt) {
+		return models.Registration{}, ErrInvalidUploadToken
+	}
+	return reg, nil
+}
+
+func (s *registrationDocumentService) getDocument(registrationID, id uint) (models.RegistrationDocument, error) {
+	doc, err := s.repo.GetByID(registrationID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.RegistrationDocument{}, ErrNotFoundDocument
+		}
+		logrus.WithError(err).WithField("id", id).Error("failed get registration document")
+		return models.RegistrationDocument{}, err
+	}
+	return doc, nil
+}
+
+func (s *registrationDocumentService) deleteObject(ctx context.Context, name string) {
+	if err := s.store.DeleteFile(ctx, name); err != nil {
+		logrus.WithError(err).WithField("object", name).Warn("failed delete registration document file")
+	}
+}
+
+func validDocumentType(t models.RegistrationDocumentType) bool {
+	for _, known := range models.RegistrationDocumentTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// documentObjectName is where a document is stored, e.g.
+// "registrations/12/kartu_keluarga_1700000000000.pdf". Each upload gets a new
+// name, so a replaced file is never served from a stale signed link.
+func documentObjectName(registrationID uint, docType models.RegistrationDocumentType, mimeType string, now time.Time) string {
+	ext := ""
+	if m := mimetype.Lookup(mimeType); m != nil {
+		ext = m.Extension()
+	}
+	return fmt.Sprintf("%s%d/%s_%d%s", documentPrefix, registrationID, docType, now.UnixMilli(), ext)
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"darulabror/internal/dto"
+	"darulabror/internal/models"
+	"darulabror/internal/repository"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type fakeDocumentRepo struct {
+	repository.RegistrationDocumentRepo
+	docs map[uint]models.RegistrationDocument
+}
+
+func (r *fakeDocumentRepo) GetByID(registrationID, id uint) (models.RegistrationDocument, error) {
+	doc, ok := r.docs[id]
+	if !ok || doc.RegistrationID != registrationID {
+		return models.RegistrationDocument{}, gorm.ErrRecordNotFound
+	}
+	return doc, nil
+}
+
+func (r *fakeDocumentRepo) Review(registrationID, id uint, status models.DocumentStatus, reason string, adminID uint, at time.Time) error {
+	doc, err := r.GetByID(registrationID, id)
+	if err != nil {
+		return err
+	}
+	doc.Status, doc.RejectReason, doc.ReviewedBy, doc.ReviewedAt = status, reason, &adminID, &at
+	r.docs[id] = doc
+	return nil
+}
+
+func TestReviewDocument(t *testing.T) {
+	tests := []struct {
+		name           string
+		registrationID uint
+		req            dto.RegistrationDocumentReviewRequest
+		want           error
+		wantStatus     models.DocumentStatus
+		wantReason     string
+	}{
+		{"verified", 1, dto.RegistrationDocumentReviewRequest{Status: "verified", Reason: "ignored"}, nil, models.DocumentVerified, ""},
+		{"rejected", 1, dto.RegistrationDocumentReviewRequest{Status: "rejected", Reason: " blurry scan "}, nil, models.DocumentRejected, "blurry scan"},
+		{"rejected without reason", 1, dto.RegistrationDocumentReviewRequest{Status: "rejected", Reason: "  "}, ErrDocumentReasonRequired, models.DocumentPending, ""},
+		{"back to pending", 1, dto.RegistrationDocumentReviewRequest{Status: "pending"}, ErrInvalidDocumentStatus, models.DocumentPending, ""},
+		{"unknown status", 1, dto.RegistrationDocumentReviewRequest{Status: "approved"}, ErrInvalidDocumentStatus, models.DocumentPending, ""},
+		{"empty status", 1, dto.RegistrationDocumentReviewRequest{}, ErrInvalidDocumentStatus, models.DocumentPending, ""},
+		{"other registration", 2, dto.RegistrationDocumentReviewRequest{Status: "verified"}, ErrNotFoundDocument, models.DocumentPending, ""},
+	}
+	for _, tt := range tests {
+		repo := &fakeDocumentRepo{docs: map[uint]models.RegistrationDocument{
+			5: {ID: 5, RegistrationID: 1, Type: models.DocumentPhoto, Status: models.DocumentPending},
+		}}
+		svc := NewRegistrationDocumentService(nil, repo, nil, nil, DefaultUploadPolicy())
+
+		got, err := svc.ReviewDocument(7, tt.registrationID, 5, tt.req)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+			continue
+		}
+		stored := repo.docs[5]
+		if stored.Status != tt.wantStatus || stored.RejectReason != tt.wantReason {
+			t.Errorf("%s: stored %s %q, want %s %q", tt.name, stored.Status, stored.RejectReason, tt.wantStatus, tt.wantReason)
+		}
+		if err == nil && (got.Status != tt.wantStatus || got.ReviewedBy == nil || *got.ReviewedBy != 7) {
+			t.Errorf("%s: returned %+v", tt.name, got)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"darulabror/internal/dto"
+	"darulabror/internal/models"
+	"darulabror/internal/repository"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xuri/excelize/v2"
+)
+
+// Registration export formats (GET /admin/registrations/export?format=).
+const (
+	ExportCSV  = "csv"
+	ExportXLSX = "xlsx"
+)
+
+// exportBatch is how many registrations are read from the database at a time.
+const exportBatch = 500
+
+// exportColumns are the spreadsheet headers, in the order of exportRow.
+var exportColumns = []string{
+	"No. Pendaftaran", "Tanggal Daftar", "Status", "Tahun Ajaran", "Gelombang",
+	"Jenis Santri", "Nama Lengkap", "Jenis Kelamin", "Tempat Lahir", "Tanggal Lahir",
+	"NISN", "Asal Sekolah", "Alamat", "Email", "No. HP",
+	"Nama Ayah", "Pekerjaan Ayah", "No. HP Ayah", "Tanggal Lahir Ayah",
+	"Nama Ibu", "Pekerjaan Ibu", "No. HP Ibu", "Tanggal Lahir Ibu",
+}
+
+var exportStatusLabels = map[models.RegistrationStatus]string{
+	models.RegistrationStatusNew:       "Baru",
+	models.RegistrationStatusValidate:  "Validasi",
+	models.RegistrationStatusProcess:   "Diproses",
+	models.RegistrationStatusDone:      "Selesai",
+	models.RegistrationStatusRejected:  "Ditolak",
+	models.RegistrationStatusWithdrawn: "Dibatalkan",
+}
+
+var exportStudentTypeLabels = map[models.StudentType]string{
+	models.StudentNew:      "Baru",
+	models.StudentTransfer: "Pindahan",
+}
+
+var exportGenderLabels = map[models.Gender]string{
+	models.Male:   "Laki-laki",
+	models.Female: "Perempuan",
+}
+
+// ExportRegistrations writes the registrations matching filter to w as a CSV
+// or XLSX spreadsheet, reading them in batches. Check the format with
+// ValidExportFormat first: once rows are written, errors can only cut the file short.
+func (s *registrationService) ExportRegistrations(ctx context.Context, filter repository.RegistrationFilter, format string, w io.Writer) error {
+	periods, err := s.periodRepo.GetAll("")
+	if err != nil {
+		logrus.WithError(err).Error("failed get admission periods for export")
+		return err
+	}
+	periodByID := make(map[uint]models.AdmissionPeriod, len(periods))
+	for _, p := range periods {
+		periodByID[p.ID] = p
+	}
+
+	var out rowWriter
+	switch format {
+	case ExportCSV:
+		out, err = newCSVRowWriter(w)
+	case ExportXLSX:
+		out, err = newXLSXRowWriter(w)
+	default:
+		return ErrInvalidExportFormat
+	}
+	if err != nil {
+		return err
+	}
+
+	rows := 0
+	err = s.repo.Each(filter, exportBatch, func(batch []models.Registration) error {
+		if err := ctx.Err(); err != nil {
+			return err // client gone
+		}
+		for _, reg := range batch {
+			var period models.AdmissionPeriod
+			if reg.AdmissionPeriodID != nil {
+				period = periodByID[*reg.AdmissionPeriodID]
+			}
+			if err := out.Write(exportRow(dto.RegistrationModelToDTO(reg), period)); err != nil {
+				return err
+			}
+		}
+		rows += len(batch)
+		return out.Flush()
+	})
+	if err != nil {
+		out.Discard()
+		logrus.WithError(err).WithField("rows", rows).Error("failed export registrations")
+		return err
+	}
+	if err := out.Close(); err != nil {
+		logrus.WithError(err).Error("failed finish registration export")
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"format": format,
+		"rows":   rows,
+	}).Info("registrations exported")
+	return nil
+}
+
+// ValidExportFormat reports whether format is one of ExportCSV, ExportXLSX.
+func ValidExportFormat(format string) bool {
+	return format == ExportCSV || format == ExportXLSX
+}
+
+func exportRow(d dto.RegistrationDTO, period models.AdmissionPeriod) []string {
+	return []string{
+		d.ReferenceNumber, d.CreatedAt, exportStatusLabels[d.Status], period.AcademicYear, period.WaveName,
+		exportStudentTypeLabels[d.StudentType], d.FullName, exportGenderLabels[d.Gender], d.PlaceOfBirth, d.DateOfBirth,
+		d.NISN, d.OriginSchool, d.Address, d.Email, d.Phone,
+		d.FatherName, d.FatherOccupation, d.PhoneFather, d.DateOfBirthFather,
+		d.MotherName, d.MotherOccupation, d.PhoneMother, d.DateOfBirthMother,
+	}
+}
+
+type rowWriter interface {
+	Write(row []string) error
+	Flush() error // after each batch
+	Close() error // finishes the file
+	Discard()     // drops an unfinished file
+}
+
+type csvRowWriter struct {
+	w *csv.Writer
+}
+
+func newCSVRowWriter(w io.Writer) (rowWriter, error) {
+	// the BOM makes Excel read the file as UTF-8
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+	cw := &csvRowWriter{w: csv.NewWriter(w)}
+	return cw, cw.w.Write(exportColumns)
+}
+
+func (c *csvRowWriter) Write(row []string) error {
+	for i, v := range row {
+		row[i] = csvSafe(v)
+	}
+	return c.w.Write(row)
+}
+
+func (c *csvRowWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvRowWriter) Close() error {
+	return c.Flush()
+}
+
+func (c *csvRowWriter) Discard() {}
+
+// csvSafe keeps spreadsheets from running applicant input as a formula
+// (CSV injection): such values get a leading apostrophe. Phone numbers like
+// "+6281..." are left alone.
+func csvSafe(v string) string {
+	if v == "" {
+		return v
+	}
+	switch v[0] {
+	case '=', '@', '\t', '\r':
+		return "'" + v
+	case '+', '-':
+		if strings.Trim(v[1:], "0123456789 -") != "" {
+			return "'" + v
+		}
+	}
+	return v
+}
+
+// xlsxRowWriter streams rows into a single sheet; excelize keeps large sheets
+// in a temporary file rather than in memory. The workbook is written to w on Close.
+type xlsxRowWriter struct {
+	w    io.Writer
+	file *excelize.File
+	sw   *excelize.StreamWriter
+	row  int
+}
+
+const exportSheet = "Pendaftaran"
+
+func newXLSXRowWriter(w io.Writer) (rowWriter, error) {
+	f := excelize.NewFile()
+	if err := f.SetSheetName("Sheet1", exportSheet); err != nil {
+		f.Close()
+		return nil, err
+	}
+	sw, err := f.NewStreamWriter(exportSheet)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := sw.SetColWidth(1, len(exportColumns), 18); err != nil {
+		f.Close()
+		return nil, err
+	}
+	// keep the header row visible while scrolling
+	if err := sw.SetPanes(&excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	header := make([]interface{}, len(exportColumns))
+	for i, col := range exportColumns {
+		header[i] = excelize.Cell{StyleID: bold, Value: col}
+	}
+	if err := sw.SetRow("A1", header); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &xlsxRowWriter{w: w, file: f, sw: sw, row: 1}, nil
+}
+
+func (x *xlsxRowWriter) Write(row []string) error {
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	// strings stay strings: NISN and phone numbers keep their leading zeros
+	values := make([]interface{}, len(row))
+	for i, v := range row {
+		values[i] = v
+	}
+	return x.sw.SetRow(cell, values)
+}
+
+func (x *xlsxRowWriter) Flush() error {
+	return nil // the stream writer spills to its temporary file by itself
+}
+
+func (x *xlsxRowWriter) Discard() {
+	x.file.Close()
+}
+
+func (x *xlsxRowWriter) Close() error {
+	defer x.file.Close()
+	if err := x.sw.Flush(); err != nil {
+		return err
+	}
+	if _, err := x.file.WriteTo(x.w); err != nil {
+		return fmt.Errorf("write xlsx: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package service
+
+import "testing"
+
+func TestCSVSafe(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"Ahmad Fauzi", "Ahmad Fauzi"},
+		{"Jl. Mawar No. 3", "Jl. Mawar No. 3"},
+		// formulas
+		{"=SUM(A1:A9)", "'=SUM(A1:A9)"},
+		{"=HYPERLINK(\"http://x\")", "'=HYPERLINK(\"http://x\")"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1+1", "'\t=1+1"},
+		{"\r=1+1", "'\r=1+1"},
+		{"+cmd|' /C calc'!A0", "'+cmd|' /C calc'!A0"},
+		{"-2+3", "'-2+3"},
+		{"+", "+"},
+		// numbers that only look like formulas
+		{"+6281234567890", "+6281234567890"},
+		{"+62 812-3456-7890", "+62 812-3456-7890"},
+		{"-5", "-5"},
+		{"2010-05-17", "2010-05-17"},
+	}
+	for _, tt := range tests {
+		if got := csvSafe(tt.in); got != tt.want {
+			t.Errorf("csvSafe(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,546 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"darulabror/internal/dto"
+	"darulabror/internal/models"
+	"darulabror/internal/repository"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+// RegistrationImportOptions control POST /admin/registrations/import.
+type RegistrationImportOptions struct {
+	// PeriodID is the admission period the registrations join; 0 = the period
+	// open now (none while no period has been set up).
+	PeriodID uint
+	// DryRun only checks the rows.
+	DryRun bool
+	// Atomic stores all rows or none: a single invalid row cancels the import.
+	// Otherwise the valid rows are stored and the invalid ones reported.
+	Atomic bool
+}
+
+// importMaxRows caps the data rows of an import file.
+const importMaxRows = 2000
+
+// importColumn maps a spreadsheet column to a RegistrationDTO field. Columns
+// are found by their export header (exportColumns) or the JSON field name, so
+// an export can be edited and imported again; other columns are ignored.
+type importColumn struct {
+	field string
+	label string
+	set   func(d *dto.RegistrationDTO, v string)
+}
+
+var importColumns = []importColumn{
+	{"student_type", "Jenis Santri", func(d *dto.RegistrationDTO, v string) {
+		d.StudentType = importLabel(v, exportStudentTypeLabels)
+	}},
+	{"full_name", "Nama Lengkap", func(d *dto.RegistrationDTO, v string) { d.FullName = v }},
+	{"gender", "Jenis Kelamin", func(d *dto.RegistrationDTO, v string) {
+		switch strings.ToUpper(v) {
+		case "L":
+			d.Gender = models.Male
+		case "P":
+			d.Gender = models.Female
+		default:
+			d.Gender = importLabel(v, exportGenderLabels)
+		}
+	}},
+	{"place_of_birth", "Tempat Lahir", func(d *dto.RegistrationDTO, v string) { d.PlaceOfBirth = v }},
+	{"date_of_birth", "Tanggal Lahir", func(d *dto.RegistrationDTO, v string) { d.DateOfBirth = importDate(v) }},
+	{"nisn", "NISN", func(d *dto.RegistrationDTO, v string) { d.NISN = v }},
+	{"origin_school", "Asal Sekolah", func(d *dto.RegistrationDTO, v string) { d.OriginSchool = v }},
+	{"address", "Alamat", func(d *dto.RegistrationDTO, v string) { d.Address = v }},
+	{"email", "Email", func(d *dto.RegistrationDTO, v string) { d.Email = v }},
+	{"phone", "No. HP", func(d *dto.RegistrationDTO, v string) { d.Phone = importPhone(v) }},
+	{"father_name", "Nama Ayah", func(d *dto.RegistrationDTO, v string) { d.FatherName = v }},
+	{"father_occupation", "Pekerjaan Ayah", func(d *dto.RegistrationDTO, v string) { d.FatherOccupation = v }},
+	{"phone_father", "No. HP Ayah", func(d *dto.RegistrationDTO, v string) { d.PhoneFather = importPhone(v) }},
+	{"date_of_birth_father", "Tanggal Lahir Ayah", func(d *dto.RegistrationDTO, v string) { d.DateOfBirthFather = importDate(v) }},
+	{"mother_name", "Nama Ibu", func(d *dto.RegistrationDTO, v string) { d.MotherName = v }},
+	{"mother_occupation", "Pekerjaan Ibu", func(d *dto.RegistrationDTO, v string) { d.MotherOccupation = v }},
+	{"phone_mother", "No. HP Ibu", func(d *dto.RegistrationDTO, v string) { d.PhoneMother = importPhone(v) }},
+	{"date_of_birth_mother", "Tanggal Lahir Ibu", func(d *dto.RegistrationDTO, v string) { d.DateOfBirthMother = importDate(v) }},
+}
+
+// importRow is a checked data row; reg is set when the row is valid.
+type importRow struct {
+	report dto.RegistrationImportRowDTO
+	reg    *models.Registration
+}
+
+// ImportRegistrations checks every data row of a CSV / XLSX spreadsheet like
+// CreateRegistration does (validate runs the RegistrationDTO rules, then the
+// email / NISN must be unused, in the database and in the file) and stores the
+// valid ones according to opts. Imported registrations get no upload token and
+// no notifications. Problems with the file itself are returned as errors,
+// problems with rows are in the report.
+func (s *registrationService) ImportRegistrations(ctx context.Context, r io.Reader, format string, opts RegistrationImportOptions, validate func(any) error) (dto.RegistrationImportReportDTO, error) {
+	report := dto.RegistrationImportReportDTO{DryRun: opts.DryRun, Atomic: opts.Atomic, Rows: []dto.RegistrationImportRowDTO{}}
+
+	period, err := s.importPeriod(opts.PeriodID)
+	if err != nil {
+		return report, err
+	}
+	report.PeriodID = period.ID
+
+	sheet, err := readImportSheet(r, format)
+	if err != nil {
+		return report, err
+	}
+	if len(sheet.rows) == 0 {
+		return report, fmt.Errorf("%w: the file is empty", ErrInvalidImportFile)
+	}
+	columns, err := importHeader(sheet.rows[0])
+	if err != nil {
+		return report, err
+	}
+
+	// the file is checked completely before anything is stored
+	var (
+		rows   []importRow
+		emails = make(map[string]int) // -> row
+		nisns  = make(map[string]int)
+	)
+	for i, cells := range sheet.rows[1:] {
+		if blankRow(cells) {
+			continue
+		}
+		if len(rows) == importMaxRows {
+			return report, fmt.Errorf("%w (at most %d)", ErrImportTooLarge, importMaxRows)
+		}
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		line := i + 2 // 1-based, after the header
+		var d dto.RegistrationDTO
+		for c, col := range importColumns {
+			v := cell(cells, columns[c])
+			if col.field == "nisn" && sheet.numeric[[2]int{line - 1, columns[c]}] {
+				v = fmt.Sprintf("%010s", v) // Excel drops the leading zeros of numbers
+			}
+			col.set(&d, v)
+		}
+
+		row, err := s.checkImportRow(d, validate, emails, nisns)
+		if err != nil {
+			return report, err
+		}
+		row.report.Row = line
+		if row.reg != nil {
+			if period.ID != 0 {
+				row.reg.AdmissionPeriodID = &period.ID
+			}
+			if key := strings.ToLower(d.Email); emails[key] == 0 {
+				emails[key] = line
+			}
+			if nisns[d.NISN] == 0 {
+				nisns[d.NISN] = line
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	for _, row := range rows {
+		if row.reg != nil {
+			report.Valid++
+		} else {
+			report.Invalid++
+		}
+	}
+	report.Total = len(rows)
+
+	switch {
+	case opts.DryRun:
+	case opts.Atomic:
+		if report.Invalid == 0 {
+			if err := s.importAll(rows, &report); err != nil {
+				return report, err
+			}
+		}
+	default:
+		s.importEach(rows, &report)
+	}
+
+	for _, row := range rows {
+		report.Rows = append(report.Rows, row.report)
+	}
+	report.Committed = report.Created > 0
+
+	logrus.WithFields(logrus.Fields{
+		"format":    format,
+		"period_id": period.ID,
+		"dry_run":   opts.DryRun,
+		"atomic":    opts.Atomic,
+		"total":     report.Total,
+		"invalid":   report.Invalid,
+		"created":   report.Created,
+	}).Info("registrations imported")
+	return report, nil
+}
+
+func (s *registrationService) importPeriod(id uint) (models.AdmissionPeriod, error) {
+	if id == 0 {
+		return s.openAdmissionPeriod(time.Now())
+	}
+
+	period, err := s.periodRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return period, ErrNotFoundAdmissionPeriod
+		}
+		logrus.WithError(err).WithField("id", id).Error("failed get admission period")
+	}
+	return period, err
+}
+
+// checkImportRow applies the checks of CreateRegistration to d; emails / nisns
+// are those of the valid rows before it. Only database failures are returned.
+func (s *registrationService) checkImportRow(d dto.RegistrationDTO, validate func(any) error, emails, nisns map[string]int) (importRow, error) {
+	row := importRow{report: dto.RegistrationImportRowDTO{FullName: d.FullName, NISN: d.NISN}}
+	fail := func(msg string) {
+		row.report.Errors = append(row.report.Errors, msg)
+	}
+
+	if err := validate(&d); err != nil {
+		var fieldErrs validator.ValidationErrors
+		if !errors.As(err, &fieldErrs) {
+			return row, err
+		}
+		for _, fe := range fieldErrs {
+			fail(importFieldError(fe))
+		}
+	}
+
+	if d.Email != "" {
+		if line := emails[strings.ToLower(d.Email)]; line != 0 {
+			fail(fmt.Sprintf("email: already used in row %d", line))
+		} else if exists, err := s.repo.ExistsByEmail(d.Email); err != nil {
+			logrus.WithError(err).WithField("email", d.Email).Error("failed check registration email")
+			return row, err
+		} else if exists {
+			fail("email: " + ErrRegistrationEmailExists.Error())
+		}
+	}
+	if d.NISN != "" {
+		if line := nisns[d.NISN]; line != 0 {
+			fail(fmt.Sprintf("nisn: already used in row %d", line))
+		} else if exists, err := s.repo.ExistsByNISN(d.NISN); err != nil {
+			logrus.WithError(err).WithField("nisn", d.NISN).Error("failed check registration nisn")
+			return row, err
+		} else if exists {
+			fail("nisn: " + ErrRegistrationNISNExists.Error())
+		}
+	}
+	if len(row.report.Errors) > 0 {
+		row.report.Status = dto.ImportRowInvalid
+		return row, nil
+	}
+
+	reg, err := dto.RegistrationDTOToModel(d)
+	if err != nil {
+		fail(err.Error())
+		row.report.Status = dto.ImportRowInvalid
+		return row, nil
+	}
+	row.reg = &reg
+	row.report.Status = dto.ImportRowValid
+	return row, nil
+}
+
+// importAll stores the rows (all valid) in one transaction.
+func (s *registrationService) importAll(rows []importRow, report *dto.RegistrationImportReportDTO) error {
+	regs := make([]*models.Registration, 0, len(rows))
+	for _, row := range rows {
+		var err error
+		if row.reg.ReferenceNumber, err = newReferenceNumber(time.Now()); err != nil {
+			logrus.WithError(err).Error("failed generate reference number")
+			return err
+		}
+		regs = append(regs, row.reg)
+	}
+
+	// a taken reference number rolls everything back: draw another and retry
+	failed, err := s.repo.CreateAll(regs)
+	for attempt := 1; attempt < referenceAttempts && errors.Is(err, repository.ErrDuplicateReference); attempt++ {
+		if regs[failed].ReferenceNumber, err = newReferenceNumber(time.Now()); err != nil {
+			logrus.WithError(err).Error("failed generate reference number")
+			return err
+		}
+		failed, err = s.repo.CreateAll(regs)
+	}
+	switch {
+	case err == nil:
+		for i := range rows {
+			rows[i].report.Status = dto.ImportRowCreated
+			rows[i].report.ID = rows[i].reg.ID
+			rows[i].report.ReferenceNumber = rows[i].reg.ReferenceNumber
+		}
+		report.Created = len(rows)
+		return nil
+	case errors.Is(err, repository.ErrAdmissionPeriodFull):
+		// the rest stays valid, just not stored
+		rows[failed].report.Status = dto.ImportRowInvalid
+		rows[failed].report.Errors = []string{ErrAdmissionFull.Error()}
+		report.Valid--
+		report.Invalid++
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound): // period deleted meanwhile
+		return ErrNotFoundAdmissionPeriod
+	}
+	logrus.WithError(err).WithField("row", rows[failed].report.Row).Error("failed import registrations")
+	return ErrCreateRegistration
+}
+
+// importEach stores the valid rows one by one; rows that fail are reported.
+func (s *registrationService) importEach(rows []importRow, report *dto.RegistrationImportReportDTO) {
+	for i := range rows {
+		row := &rows[i]
+		if row.reg == nil {
+			continue
+		}
+		if err := s.createWithReference(row.reg); err != nil {
+			msg := ErrCreateRegistration.Error()
+			if errors.Is(err, repository.ErrAdmissionPeriodFull) {
+				msg = ErrAdmissionFull.Error()
+			} else {
+				logrus.WithError(err).WithField("row", row.report.Row).Error("failed import registration")
+			}
+			row.report.Status = dto.ImportRowInvalid
+			row.report.Errors = []string{msg}
+			report.Valid--
+			report.Invalid++
+			continue
+		}
+		row.report.Status = dto.ImportRowCreated
+		row.report.ID = row.reg.ID
+		row.report.ReferenceNumber = row.reg.ReferenceNumber
+		report.Created++
+	}
+}
+
+// importHeader returns the position of each of importColumns in header;
+// all of them are required.
+func importHeader(header []string) ([]int, error) {
+	pos := make(map[string]int, len(header))
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		if _, ok := pos[h]; !ok && h != "" {
+			pos[h] = i
+		}
+	}
+
+	columns := make([]int, len(importColumns))
+	var missing []string
+	for c, col := range importColumns {
+		i, ok := pos[strings.ToLower(col.label)]
+		if !ok {
+			i, ok = pos[col.field]
+		}
+		if !ok {
+			missing = append(missing, fmt.Sprintf("%s (%s)", col.label, col.field))
+			continue
+		}
+		columns[c] = i
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: missing columns %s", ErrInvalidImportFile, strings.Join(missing, ", "))
+	}
+	return columns, nil
+}
+
+// importFieldError describes a failed RegistrationDTO rule for the report.
+func importFieldError(fe validator.FieldError) string {
+	var msg string
+	switch fe.Tag() {
+	case "required":
+		msg = "is required"
+	case "email":
+		msg = "must be an email address"
+	case "min":
+		msg = "must be at least " + fe.Param() + " characters"
+	case "max":
+		msg = "must be at most " + fe.Param() + " characters"
+	case "len":
+		msg = "must be " + fe.Param() + " characters"
+	case "oneof":
+		msg = "must be one of " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "datetime":
+		msg = "must be a date (YYYY-MM-DD or DD/MM/YYYY)"
+	default:
+		msg = "is invalid (" + fe.Tag() + ")"
+	}
+	return fe.Field() + ": " + msg
+}
+
+// importLabel maps an export label back to its value ("Laki-laki" -> male);
+// anything else is taken as the value itself.
+func importLabel[T ~string](v string, labels map[T]string) T {
+	for value, label := range labels {
+		if strings.EqualFold(v, label) {
+			return value
+		}
+	}
+	return T(strings.ToLower(v))
+}
+
+const importDateLayout = "2006-01-02" // what RegistrationDTO takes
+
+// importDateLayouts are the date formats accepted besides YYYY-MM-DD
+// (Indonesian spreadsheets write day first).
+var importDateLayouts = []string{"2/1/2006", "2-1-2006", "2.1.2006"}
+
+// importDate normalizes a date to YYYY-MM-DD; XLSX dates arrive as serial
+// numbers. Anything unrecognized is left for the validator to reject.
+func importDate(v string) string {
+	if _, err := time.Parse(importDateLayout, v); err == nil || v == "" {
+		return v
+	}
+	for _, layout := range importDateLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t.Format(importDateLayout)
+		}
+	}
+	if serial, err := strconv.ParseFloat(v, 64); err == nil && serial > 0 && serial < 100000 {
+		if t, err := excelize.ExcelDateToTime(serial, false); err == nil {
+			return t.Format(importDateLayout)
+		}
+	}
+	return v
+}
+
+// importPhone restores the leading zero spreadsheets drop from mobile numbers
+// stored as numbers ("81234567890").
+func importPhone(v string) string {
+	if strings.HasPrefix(v, "8") && strings.Trim(v, "0123456789") == "" {
+		return "0" + v
+	}
+	return v
+}
+
+func cell(cells []string, i int) string {
+	if i >= len(cells) {
+		return ""
+	}
+	v := strings.TrimSpace(cells[i])
+	// undo csvSafe: "'=..." was exported as text on purpose
+	if len(v) > 1 && v[0] == '\'' && strings.ContainsRune("=@+-", rune(v[1])) {
+		v = v[1:]
+	}
+	return v
+}
+
+func blankRow(cells []string) bool {
+	for _, c := range cells {
+		if strings.TrimSpace(c) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// importSheet is the content of an import file, header row first.
+type importSheet struct {
+	rows [][]string
+	// numeric marks the XLSX cells ({row, column}, 0-based) holding digits
+	// stored as a number rather than text
+	numeric map[[2]int]bool
+}
+
+func readImportSheet(r io.Reader, format string) (importSheet, error) {
+	switch format {
+	case ExportCSV:
+		return readImportCSV(r)
+	case ExportXLSX:
+		return readImportXLSX(r)
+	}
+	return importSheet{}, ErrInvalidImportFormat
+}
+
+func readImportCSV(r io.Reader) (importSheet, error) {
+	// Excel with Indonesian regional settings saves CSV separated by ';'
+	br := bufio.NewReader(r)
+	first, err := br.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return importSheet{}, err
+	}
+	cr := csv.NewReader(io.MultiReader(strings.NewReader(first), br))
+	if strings.Count(first, ";") > strings.Count(first, ",") {
+		cr.Comma = ';'
+	}
+	cr.FieldsPerRecord = -1
+
+	var sheet importSheet
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return sheet, nil
+		}
+		if err != nil {
+			return sheet, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+		}
+		// header plus data rows, blank rows aside, are capped later; this only
+		// bounds the memory
+		if len(sheet.rows) > 2*importMaxRows {
+			return sheet, fmt.Errorf("%w (at most %d)", ErrImportTooLarge, importMaxRows)
+		}
+		sheet.rows = append(sheet.rows, record)
+	}
+}
+
+func readImportXLSX(r io.Reader) (importSheet, error) {
+	f, err := excelize.OpenReader(r, excelize.Options{UnzipSizeLimit: 64 << 20})
+	if err != nil {
+		return importSheet{}, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return importSheet{}, fmt.Errorf("%w: the workbook has no sheets", ErrInvalidImportFile)
+	}
+	// dates as serial numbers rather than in the display format of the cell
+	rows, err := f.GetRows(sheets[0], excelize.Options{RawCellValue: true})
+	if err != nil {
+		return importSheet{}, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+
+	if len(rows) > 2*importMaxRows {
+		return importSheet{}, fmt.Errorf("%w (at most %d)", ErrImportTooLarge, importMaxRows)
+	}
+
+	sheet := importSheet{rows: rows, numeric: make(map[[2]int]bool)}
+	for i, cells := range rows {
+		for j, v := range cells {
+			if v == "" || strings.Trim(v, "0123456789") != "" {
+				continue
+			}
+			name, err := excelize.CoordinatesToCellName(j+1, i+1)
+			if err != nil {
+				return sheet, err
+			}
+			typ, err := f.GetCellType(sheets[0], name)
+			if err != nil {
+				return sheet, err
+			}
+			if typ == excelize.CellTypeNumber || typ == excelize.CellTypeUnset {
+				sheet.numeric[[2]int{i, j}] = true
+			}
+		}
+	}
+	return sheet, nil
+}
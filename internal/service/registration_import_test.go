@@ -0,0 +1,121 @@
+package service
+
+import (
+	"darulabror/internal/models"
+	"errors"
+	"testing"
+)
+
+func TestImportDate(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"2010-05-17", "2010-05-17"},
+		{"17/05/2010", "2010-05-17"},
+		{"1/2/2010", "2010-02-01"}, // day first
+		{"17-05-2010", "2010-05-17"},
+		{"17.05.2010", "2010-05-17"},
+		// XLSX serial numbers
+		{"40315", "2010-05-17"},
+		{"40315.75", "2010-05-17"},
+		{"29221", "1980-01-01"},
+		// left for the validator
+		{"", ""},
+		{"0", "0"},
+		{"-1", "-1"},
+		{"05/17/2010", "05/17/2010"},
+		{"17 Mei 2010", "17 Mei 2010"},
+		{"kemarin", "kemarin"},
+	}
+	for _, tt := range tests {
+		if got := importDate(tt.in); got != tt.want {
+			t.Errorf("importDate(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestImportPhone(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"81234567890", "081234567890"}, // number cell lost its 0
+		{"081234567890", "081234567890"},
+		{"+6281234567890", "+6281234567890"},
+		{"6281234567890", "6281234567890"},
+		{"8123-4567-890", "8123-4567-890"}, // not digits only: left as written
+		{"0215551234", "0215551234"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := importPhone(tt.in); got != tt.want {
+			t.Errorf("importPhone(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestImportCell(t *testing.T) {
+	cells := []string{"  Ahmad  ", "'=SUM(A1)", "'+62812", "'Ali", "'", ""}
+	tests := []struct {
+		i    int
+		want string
+	}{
+		{0, "Ahmad"},
+		{1, "=SUM(A1)"}, // csvSafe undone
+		{2, "+62812"},
+		{3, "'Ali"}, // a real apostrophe stays
+		{4, "'"},
+		{5, ""},
+		{9, ""}, // short row
+	}
+	for _, tt := range tests {
+		if got := cell(cells, tt.i); got != tt.want {
+			t.Errorf("cell(%d) = %q, want %q", tt.i, got, tt.want)
+		}
+	}
+
+	// what the export escapes comes back unchanged
+	for _, v := range []string{"=1+1", "@x", "+cmd", "-2+3"} {
+		if got := cell([]string{csvSafe(v)}, 0); got != v {
+			t.Errorf("round trip of %q = %q", v, got)
+		}
+	}
+}
+
+func TestImportLabel(t *testing.T) {
+	if got := importLabel("pindahan", exportStudentTypeLabels); got != models.StudentTransfer {
+		t.Errorf("student type = %q", got)
+	}
+	if got := importLabel("Laki-Laki", exportGenderLabels); got != models.Male {
+		t.Errorf("gender = %q", got)
+	}
+	if got := importLabel("Female", exportGenderLabels); got != models.Female {
+		t.Errorf("gender value = %q", got)
+	}
+}
+
+func TestImportHeader(t *testing.T) {
+	header := append([]string{"\ufeff" + exportColumns[0]}, exportColumns[1:]...)
+	columns, err := importHeader(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for c, col := range importColumns {
+		if header[columns[c]] != col.label {
+			t.Errorf("%s: column %d is %q", col.field, columns[c], header[columns[c]])
+		}
+	}
+
+	// JSON field names, any case
+	names := make([]string, 0, len(importColumns))
+	for _, col := range importColumns {
+		names = append(names, " "+col.field+" ")
+	}
+	names[0] = "STUDENT_TYPE"
+	if _, err := importHeader(names); err != nil {
+		t.Errorf("field names: %v", err)
+	}
+
+	if _, err := importHeader([]string{"Nama Lengkap", "Email"}); !errors.Is(err, ErrInvalidImportFile) {
+		t.Errorf("missing columns: got %v", err)
+	}
+}
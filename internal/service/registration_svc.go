@@ -1,10 +1,16 @@
 package service
 
 import (
+	"context"
+	"crypto/rand"
 	"darulabror/internal/dto"
 	"darulabror/internal/models"
 	"darulabror/internal/repository"
 	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -12,66 +18,118 @@ import (
 
 type RegistrationService interface {
 	// Public
-	CreateRegistration(regDTO dto.RegistrationDTO) error
+	// CreateRegistration stores the registration and returns the token the
+	// applicant uploads documents with.
+	CreateRegistration(regDTO dto.RegistrationDTO) (dto.RegistrationCreatedDTO, error)
+	// LookupStatus is the applicants' status check: the registration matching the
+	// reference number (or NISN) and date of birth, with its timeline and the
+	// documents still to upload.
+	LookupStatus(req dto.RegistrationStatusLookupRequest) (dto.RegistrationStatusLookupDTO, error)
 
 	// Admin
-	GetAllRegistrations(page, limit int, status string) ([]dto.RegistrationDTO, int64, error)
+	GetAllRegistrations(page, limit int, filter repository.RegistrationFilter) ([]dto.RegistrationDTO, int64, error)
+	// ExportRegistrations streams the registrations matching filter to w as a
+	// spreadsheet (ExportCSV, ExportXLSX).
+	ExportRegistrations(ctx context.Context, filter repository.RegistrationFilter, format string, w io.Writer) error
+	// ImportRegistrations checks and stores the registrations of a CSV / XLSX
+	// spreadsheet (ExportCSV, ExportXLSX), reporting on every row; validate
+	// applies the RegistrationDTO rules.
+	ImportRegistrations(ctx context.Context, r io.Reader, format string, opts RegistrationImportOptions, validate func(any) error) (dto.RegistrationImportReportDTO, error)
 	GetRegistrationByID(id uint) (dto.RegistrationDTO, error)
-	UpdateRegistrationStatus(id uint, status models.RegistrationStatus) error
+	// UpdateRegistrationStatus applies an allowed transition (see
+	// models.RegistrationStatus.NextStatuses) and records it in the timeline.
+	UpdateRegistrationStatus(adminID, id uint, status models.RegistrationStatus, note string) error
+	GetStatusTimeline(id uint) ([]dto.RegistrationStatusHistoryDTO, error)
 	DeleteRegistration(id uint) error
 }
 
 type registrationService struct {
-	repo repository.RegistrationRepo
+	repo           repository.RegistrationRepo
+	periodRepo     repository.AdmissionPeriodRepo
+	docRepo        repository.RegistrationDocumentRepo
+	notify         NotificationService
+	uploadTokenTTL time.Duration
 }
 
-func NewRegistrationService(repo repository.RegistrationRepo) RegistrationService {
-	return &registrationService{repo: repo}
+func NewRegistrationService(repo repository.RegistrationRepo, periodRepo repository.AdmissionPeriodRepo, docRepo repository.RegistrationDocumentRepo, notify NotificationService, uploadTokenTTL time.Duration) RegistrationService {
+	return &registrationService{repo: repo, periodRepo: periodRepo, docRepo: docRepo, notify: notify, uploadTokenTTL: uploadTokenTTL}
 }
 
-func (s *registrationService) CreateRegistration(regDTO dto.RegistrationDTO) error {
+func (s *registrationService) CreateRegistration(regDTO dto.RegistrationDTO) (dto.RegistrationCreatedDTO, error) {
+	// only accepted while an admission period is open (or before any is set up)
+	period, err := s.openAdmissionPeriod(time.Now())
+	if err != nil {
+		return dto.RegistrationCreatedDTO{}, err
+	}
+
 	// uniqueness checks
 	existsEmail, err := s.repo.ExistsByEmail(regDTO.Email)
 	if err != nil {
 		logrus.WithError(err).WithField("email", regDTO.Email).Error("failed check registration email")
-		return err
+		return dto.RegistrationCreatedDTO{}, err
 	}
 	if existsEmail {
-		return ErrRegistrationEmailExists
+		return dto.RegistrationCreatedDTO{}, ErrRegistrationEmailExists
 	}
 
 	existsNISN, err := s.repo.ExistsByNISN(regDTO.NISN)
 	if err != nil {
 		logrus.WithError(err).WithField("nisn", regDTO.NISN).Error("failed check registration nisn")
-		return err
+		return dto.RegistrationCreatedDTO{}, err
 	}
 	if existsNISN {
-		return ErrRegistrationNISNExists
+		return dto.RegistrationCreatedDTO{}, ErrRegistrationNISNExists
 	}
 
 	reg, err := dto.RegistrationDTOToModel(regDTO)
 	if err != nil {
 		logrus.WithError(err).Error("failed convert RegistrationDTO to model")
-		return err
+		return dto.RegistrationCreatedDTO{}, err
+	}
+
+	token, err := newSecretToken()
+	if err != nil {
+		logrus.WithError(err).Error("failed generate upload token")
+		return dto.RegistrationCreatedDTO{}, err
+	}
+	expiresAt := time.Now().Add(s.uploadTokenTTL)
+	reg.UploadTokenHash = hashSecretToken(token)
+	reg.UploadTokenExpiresAt = &expiresAt
+	if period.ID != 0 {
+		reg.AdmissionPeriodID = &period.ID
 	}
 
-	if err := s.repo.Create(reg); err != nil {
+	if err := s.createWithReference(&reg); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrAdmissionPeriodFull):
+			return dto.RegistrationCreatedDTO{}, ErrAdmissionFull
+		case errors.Is(err, gorm.ErrRecordNotFound): // period deleted meanwhile
+			return dto.RegistrationCreatedDTO{}, ErrAdmissionClosed
+		}
 		logrus.WithError(err).WithFields(logrus.Fields{
 			"email": reg.Email,
 			"nisn":  reg.NISN,
 		}).Error("failed create registration")
-		return ErrCreateRegistration
+		return dto.RegistrationCreatedDTO{}, ErrCreateRegistration
 	}
 
 	logrus.WithFields(logrus.Fields{
-		"email": reg.Email,
-		"nisn":  reg.NISN,
+		"email":     reg.Email,
+		"nisn":      reg.NISN,
+		"period_id": period.ID,
+		"reference": reg.ReferenceNumber,
 	}).Info("registration created")
-	return nil
+	s.notify.RegistrationReceived(reg, period)
+
+	return dto.RegistrationCreatedDTO{
+		ReferenceNumber:      reg.ReferenceNumber,
+		UploadToken:          token,
+		UploadTokenExpiresAt: expiresAt.Format(time.RFC3339),
+	}, nil
 }
 
-func (s *registrationService) GetAllRegistrations(page, limit int, status string) ([]dto.RegistrationDTO, int64, error) {
-	regs, total, err := s.repo.GetAll(page, limit, status)
+func (s *registrationService) GetAllRegistrations(page, limit int, filter repository.RegistrationFilter) ([]dto.RegistrationDTO, int64, error) {
+	regs, total, err := s.repo.GetAll(page, limit, filter)
 	if err != nil {
 		logrus.WithError(err).Error("failed get all registrations")
 		return nil, 0, err
@@ -88,7 +146,7 @@ func (s *registrationService) GetRegistrationByID(id uint) (dto.RegistrationDTO,
 	reg, err := s.repo.GetByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return dto.RegistrationDTO{}, errors.New("registration not found")
+			return dto.RegistrationDTO{}, ErrNotFoundRegistration
 		}
 		logrus.WithError(err).WithField("id", id).Error("failed get registration by id")
 		return dto.RegistrationDTO{}, err
@@ -105,25 +163,208 @@ func (s *registrationService) DeleteRegistration(id uint) error {
 	return nil
 }
 
-func (s *registrationService) UpdateRegistrationStatus(id uint, status models.RegistrationStatus) error {
-	// Validate status value
-	if status != models.RegistrationStatusNew && 
-	   status != models.RegistrationStatusValidate && 
-	   status != models.RegistrationStatusProcess && 
-	   status != models.RegistrationStatusDone {
+func (s *registrationService) UpdateRegistrationStatus(adminID, id uint, status models.RegistrationStatus, note string) error {
+	if !status.Valid() {
 		return errors.New("invalid status value")
 	}
-	
-	if err := s.repo.UpdateStatus(id, status); err != nil {
+
+	reg, err := s.repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFoundRegistration
+		}
+		logrus.WithError(err).WithField("id", id).Error("failed get registration by id")
+		return err
+	}
+	if !reg.Status.CanTransitionTo(status) {
+		return fmt.Errorf("%w: %s -> %s (allowed from %s: %s)", ErrInvalidStatusTransition, reg.Status, status, reg.Status, statusList(reg.Status.NextStatuses()))
+	}
+
+	if err := s.repo.ChangeStatus(id, reg.Status, status, &adminID, strings.TrimSpace(note)); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("registration not found")
+			// deleted, or changed by someone else since we read it
+			return fmt.Errorf("%w: the registration changed meanwhile, reload it", ErrInvalidStatusTransition)
 		}
 		logrus.WithError(err).WithField("id", id).Error("failed update registration status")
 		return err
 	}
 	logrus.WithFields(logrus.Fields{
-		"id":     id,
-		"status": status,
+		"id":       id,
+		"from":     reg.Status,
+		"status":   status,
+		"admin_id": adminID,
 	}).Info("registration status updated")
+
+	s.notify.RegistrationStatusChanged(reg, status)
 	return nil
 }
+
+func (s *registrationService) GetStatusTimeline(id uint) ([]dto.RegistrationStatusHistoryDTO, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFoundRegistration
+		}
+		logrus.WithError(err).WithField("id", id).Error("failed get registration by id")
+		return nil, err
+	}
+
+	history, err := s.repo.GetStatusHistory(id)
+	if err != nil {
+		logrus.WithError(err).WithField("id", id).Error("failed get registration status history")
+		return nil, err
+	}
+
+	out := make([]dto.RegistrationStatusHistoryDTO, 0, len(history))
+	for _, h := range history {
+		out = append(out, dto.RegistrationStatusHistoryModelToDTO(h))
+	}
+	return out, nil
+}
+
+func (s *registrationService) LookupStatus(req dto.RegistrationStatusLookupRequest) (dto.RegistrationStatusLookupDTO, error) {
+	var (
+		reg models.Registration
+		err error
+	)
+	if ref := strings.ToUpper(strings.TrimSpace(req.ReferenceNumber)); ref != "" {
+		reg, err = s.repo.GetByReference(ref)
+	} else {
+		reg, err = s.repo.GetByNISN(strings.TrimSpace(req.NISN))
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.RegistrationStatusLookupDTO{}, ErrRegistrationLookupFailed
+		}
+		logrus.WithError(err).Error("failed get registration for status lookup")
+		return dto.RegistrationStatusLookupDTO{}, err
+	}
+	if reg.DateOfBirth.Format("2006-01-02") != strings.TrimSpace(req.DateOfBirth) {
+		logrus.WithField("id", reg.ID).Info("registration status lookup with wrong date of birth")
+		return dto.RegistrationStatusLookupDTO{}, ErrRegistrationLookupFailed
+	}
+
+	history, err := s.repo.GetStatusHistory(reg.ID)
+	if err != nil {
+		logrus.WithError(err).WithField("id", reg.ID).Error("failed get registration status history")
+		return dto.RegistrationStatusLookupDTO{}, err
+	}
+	docs, err := s.docRepo.ListByRegistration(reg.ID)
+	if err != nil {
+		logrus.WithError(err).WithField("id", reg.ID).Error("failed list registration documents")
+		return dto.RegistrationStatusLookupDTO{}, err
+	}
+
+	out := dto.RegistrationStatusLookupDTO{
+		ReferenceNumber:   reg.ReferenceNumber,
+		FullName:          reg.FullName,
+		Status:            reg.Status,
+		SubmittedAt:       reg.CreatedAt.Format(time.RFC3339),
+		Timeline:          make([]dto.RegistrationStatusStepDTO, 0, len(history)),
+		MissingDocuments:  dto.MissingRegistrationDocuments(docs),
+		RejectedDocuments: make([]dto.RejectedDocumentDTO, 0),
+	}
+	for _, h := range history {
+		out.Timeline = append(out.Timeline, dto.RegistrationStatusStepDTO{
+			Status:    h.ToStatus,
+			ChangedAt: h.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	for _, d := range docs {
+		if d.Status == models.DocumentRejected {
+			out.RejectedDocuments = append(out.RejectedDocuments, dto.RejectedDocumentDTO{Type: d.Type, Reason: d.RejectReason})
+		}
+	}
+	if reg.AdmissionPeriodID != nil {
+		period, err := s.periodRepo.GetByID(*reg.AdmissionPeriodID)
+		if err != nil {
+			logrus.WithError(err).WithField("id", reg.ID).Error("failed get registration admission period")
+			return dto.RegistrationStatusLookupDTO{}, err
+		}
+		out.AcademicYear, out.WaveName = period.AcademicYear, period.WaveName
+	}
+
+	logrus.WithField("id", reg.ID).Info("registration status looked up")
+	return out, nil
+}
+
+// referenceAlphabet leaves out characters easily misread over the phone (0/O, 1/I/L).
+const referenceAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+// referenceAttempts bounds the reference numbers tried for one registration
+// when the generated one is taken already.
+const referenceAttempts = 3
+
+// newReferenceNumber returns a reference like "DA25-7K3M9Q": the year of
+// registration and 6 random characters, so references can't be guessed from
+// one another.
+func newReferenceNumber(now time.Time) (string, error) {
+	// bytes past the last whole multiple of the alphabet are dropped, or the
+	// first characters would come up more often
+	limit := 256 - 256%len(referenceAlphabet)
+	out := make([]byte, 0, 6)
+	buf := make([]byte, 16)
+	for len(out) < cap(out) {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		for _, c := range buf {
+			if int(c) < limit && len(out) < cap(out) {
+				out = append(out, referenceAlphabet[int(c)%len(referenceAlphabet)])
+			}
+		}
+	}
+	return fmt.Sprintf("DA%s-%s", now.Format("06"), out), nil
+}
+
+// openAdmissionPeriod returns the period a registration made at now joins: the
+// one open then, or none (a zero period) as long as no period has been set up at
+// all, so registrations keep coming in until an admin plans the first wave.
+func (s *registrationService) openAdmissionPeriod(now time.Time) (models.AdmissionPeriod, error) {
+	period, err := s.periodRepo.GetOpenAt(now)
+	if err == nil {
+		return period, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logrus.WithError(err).Error("failed get open admission period")
+		return models.AdmissionPeriod{}, err
+	}
+
+	configured, err := s.periodRepo.Any()
+	if err != nil {
+		logrus.WithError(err).Error("failed check admission periods")
+		return models.AdmissionPeriod{}, err
+	}
+	if configured {
+		return models.AdmissionPeriod{}, ErrAdmissionClosed
+	}
+	return models.AdmissionPeriod{}, nil
+}
+
+// createWithReference stores reg under a new reference number, drawing
+// another one when it is taken already.
+func (s *registrationService) createWithReference(reg *models.Registration) error {
+	var err error
+	for attempt := 1; attempt <= referenceAttempts; attempt++ {
+		if reg.ReferenceNumber, err = newReferenceNumber(time.Now()); err != nil {
+			logrus.WithError(err).Error("failed generate reference number")
+			return err
+		}
+		if err = s.repo.Create(reg); !errors.Is(err, repository.ErrDuplicateReference) {
+			return err
+		}
+		logrus.WithField("reference", reg.ReferenceNumber).Warn("reference number taken, drawing another")
+	}
+	return err
+}
+
+// statusList renders statuses for error messages ("none" when empty).
+func statusList(statuses []models.RegistrationStatus) string {
+	if len(statuses) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(statuses))
+	for _, st := range statuses {
+		names = append(names, string(st))
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"darulabror/internal/dto"
+	"darulabror/internal/models"
+	"darulabror/internal/repository"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type statusRegistrationRepo struct {
+	repository.RegistrationRepo
+	reg     models.Registration
+	changes []string
+}
+
+func (r *statusRegistrationRepo) GetByID(id uint) (models.Registration, error) {
+	if id != r.reg.ID {
+		return models.Registration{}, gorm.ErrRecordNotFound
+	}
+	return r.reg, nil
+}
+
+func (r *statusRegistrationRepo) ChangeStatus(id uint, from, to models.RegistrationStatus, adminID *uint, note string) error {
+	if r.reg.Status != from {
+		return gorm.ErrRecordNotFound
+	}
+	r.reg.Status = to
+	r.changes = append(r.changes, string(from)+">"+string(to)+":"+note)
+	return nil
+}
+
+type fakeNotifications struct {
+	NotificationService
+	sent []models.RegistrationStatus
+}
+
+func (n *fakeNotifications) RegistrationStatusChanged(reg models.Registration, status models.RegistrationStatus) {
+	n.sent = append(n.sent, status)
+}
+
+func TestUpdateRegistrationStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    models.RegistrationStatus
+		id      uint
+		to      models.RegistrationStatus
+		want    error
+		wantMsg string // part of the error, for refused transitions
+	}{
+		{"forward", models.RegistrationStatusNew, 1, models.RegistrationStatusValidate, nil, ""},
+		{"withdraw after done", models.RegistrationStatusDone, 1, models.RegistrationStatusWithdrawn, nil, ""},
+		{"skipping a step", models.RegistrationStatusNew, 1, models.RegistrationStatusDone, ErrInvalidStatusTransition, "allowed from new: validate, rejected, withdrawn"},
+		{"backwards", models.RegistrationStatusProcess, 1, models.RegistrationStatusValidate, ErrInvalidStatusTransition, "process -> validate"},
+		{"same status", models.RegistrationStatusValidate, 1, models.RegistrationStatusValidate, ErrInvalidStatusTransition, ""},
+		{"out of a terminal status", models.RegistrationStatusRejected, 1, models.RegistrationStatusNew, ErrInvalidStatusTransition, "allowed from rejected: none"},
+		{"unknown registration", models.RegistrationStatusNew, 2, models.RegistrationStatusValidate, ErrNotFoundRegistration, ""},
+	}
+	for _, tt := range tests {
+		repo := &statusRegistrationRepo{reg: models.Registration{ID: 1, Status: tt.from}}
+		notify := &fakeNotifications{}
+		svc := NewRegistrationService(repo, nil, nil, notify, 0)
+
+		err := svc.UpdateRegistrationStatus(7, tt.id, tt.to, "  dokumen lengkap ")
+		if !errors.Is(err, tt.want) || (err != nil && !strings.Contains(err.Error(), tt.wantMsg)) {
+			t.Errorf("%s: got %v, want %v (%q)", tt.name, err, tt.want, tt.wantMsg)
+			continue
+		}
+		if err != nil {
+			if len(repo.changes) != 0 || len(notify.sent) != 0 {
+				t.Errorf("%s: refused but changed %v, notified %v", tt.name, repo.changes, notify.sent)
+			}
+			continue
+		}
+		want := string(tt.from) + ">" + string(tt.to) + ":dokumen lengkap"
+		if len(repo.changes) != 1 || repo.changes[0] != want || len(notify.sent) != 1 || notify.sent[0] != tt.to {
+			t.Errorf("%s: changed %v, notified %v", tt.name, repo.changes, notify.sent)
+		}
+	}
+}
+
+type fakePeriodRepo struct {
+	repository.AdmissionPeriodRepo
+	open       *models.AdmissionPeriod // nil: none open
+	configured bool
+	err        error
+}
+
+func (r *fakePeriodRepo) GetOpenAt(t time.Time) (models.AdmissionPeriod, error) {
+	if r.err != nil {
+		return models.AdmissionPeriod{}, r.err
+	}
+	if r.open == nil {
+		return models.AdmissionPeriod{}, gorm.ErrRecordNotFound
+	}
+	return *r.open, nil
+}
+
+func (r *fakePeriodRepo) Any() (bool, error) {
+	return r.configured || r.open != nil, nil
+}
+
+type createRegistrationRepo struct {
+	repository.RegistrationRepo
+	full    bool // the period's quota is taken
+	created []models.Registration
+}
+
+func (r *createRegistrationRepo) ExistsByEmail(email string) (bool, error) { return false, nil }
+func (r *createRegistrationRepo) ExistsByNISN(nisn string) (bool, error)   { return false, nil }
+
+func (r *createRegistrationRepo) Create(reg *models.Registration) error {
+	if r.full && reg.AdmissionPeriodID != nil {
+		return repository.ErrAdmissionPeriodFull
+	}
+	reg.ID = uint(len(r.created) + 1)
+	r.created = append(r.created, *reg)
+	return nil
+}
+
+func (n *fakeNotifications) RegistrationReceived(reg models.Registration, period models.AdmissionPeriod) {
+}
+
+func TestCreateRegistrationPeriod(t *testing.T) {
+	now := time.Now()
+	open := &models.AdmissionPeriod{ID: 3, OpensAt: now.AddDate(0, -1, 0), ClosesAt: now.AddDate(0, 1, 0)}
+	dbDown := errors.New("connection refused")
+
+	tests := []struct {
+		name       string
+		periods    *fakePeriodRepo
+		full       bool
+		want       error
+		wantPeriod *uint
+	}{
+		{"open period", &fakePeriodRepo{open: open}, false, nil, &open.ID},
+		{"quota full", &fakePeriodRepo{open: open}, true, ErrAdmissionFull, nil},
+		{"between periods", &fakePeriodRepo{configured: true}, false, ErrAdmissionClosed, nil},
+		{"no period set up yet", &fakePeriodRepo{}, false, nil, nil},
+		{"no period set up yet, quota never applies", &fakePeriodRepo{}, true, nil, nil},
+		{"period lookup failed", &fakePeriodRepo{err: dbDown}, false, dbDown, nil},
+	}
+	for _, tt := range tests {
+		repo := &createRegistrationRepo{full: tt.full}
+		svc := NewRegistrationService(repo, tt.periods, nil, &fakeNotifications{}, time.Hour)
+
+		created, err := svc.CreateRegistration(dto.RegistrationDTO{
+			StudentType:       models.StudentNew,
+			Gender:            models.Male,
+			Email:             "santri@example.com",
+			FullName:          "Ahmad",
+			NISN:              "0012345678",
+			DateOfBirth:       "2012-05-01",
+			DateOfBirthFather: "1980-01-01",
+			DateOfBirthMother: "1982-01-01",
+		})
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+			continue
+		}
+		if err != nil {
+			if len(repo.created) != 0 {
+				t.Errorf("%s: refused but stored %d registrations", tt.name, len(repo.created))
+			}
+			continue
+		}
+		if len(repo.created) != 1 || created.ReferenceNumber == "" || created.UploadToken == "" {
+			t.Fatalf("%s: created %+v, stored %d", tt.name, created, len(repo.created))
+		}
+		got := repo.created[0].AdmissionPeriodID
+		if (got == nil) != (tt.wantPeriod == nil) || (got != nil && *got != *tt.wantPeriod) {
+			t.Errorf("%s: admission period %v, want %v", tt.name, got, tt.wantPeriod)
+		}
+	}
+}
+
+func TestNewReferenceNumber(t *testing.T) {
+	format := regexp.MustCompile(`^DA25-[` + referenceAlphabet + `]{6}$`)
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	counts := map[rune]int{}
+	const draws = 20_000
+	for i := 0; i < draws; i++ {
+		ref, err := newReferenceNumber(now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !format.MatchString(ref) {
+			t.Fatalf("%q doesn't look like DA25-XXXXXX", ref)
+		}
+		for _, c := range ref[5:] {
+			counts[c]++
+		}
+	}
+
+	// chi-square against a uniform draw: about 30 on average for 30 degrees of
+	// freedom; modulo bias (8 characters a ninth more likely) gives hundreds
+	expected := float64(draws*6) / float64(len(referenceAlphabet))
+	chi2 := 0.0
+	for _, c := range referenceAlphabet {
+		d := float64(counts[c]) - expected
+		chi2 += d * d / expected
+	}
+	if chi2 > 80 {
+		t.Errorf("characters aren't uniform (chi-square %.1f): %v", chi2, counts)
+	}
+}
+
+func TestCreateWithReferenceRetries(t *testing.T) {
+	tests := []struct {
+		taken int // attempts answered with ErrDuplicateReference
+		want  error
+	}{
+		{0, nil},
+		{referenceAttempts - 1, nil},
+		{referenceAttempts, repository.ErrDuplicateReference},
+	}
+	for _, tt := range tests {
+		repo := &referenceRepo{taken: tt.taken}
+		svc := &registrationService{repo: repo}
+		reg := models.Registration{}
+		if err := svc.createWithReference(&reg); !errors.Is(err, tt.want) {
+			t.Errorf("%d taken: got %v, want %v", tt.taken, err, tt.want)
+		}
+		if wantTries := min(tt.taken+1, referenceAttempts); len(repo.tried) != wantTries {
+			t.Errorf("%d taken: tried %v", tt.taken, repo.tried)
+		}
+	}
+}
+
+type referenceRepo struct {
+	repository.RegistrationRepo
+	taken int
+	tried []string
+}
+
+func (r *referenceRepo) Create(reg *models.Registration) error {
+	r.tried = append(r.tried, reg.ReferenceNumber)
+	if len(r.tried) <= r.taken {
+		return repository.ErrDuplicateReference
+	}
+	return nil
+}
+
+type lookupRegistrationRepo struct {
+	repository.RegistrationRepo
+	reg models.Registration
+}
+
+func (r *lookupRegistrationRepo) GetByReference(reference string) (models.Registration, error) {
+	if reference != r.reg.ReferenceNumber {
+		return models.Registration{}, gorm.ErrRecordNotFound
+	}
+	return r.reg, nil
+}
+
+func (r *lookupRegistrationRepo) GetByNISN(nisn string) (models.Registration, error) {
+	if nisn != r.reg.NISN {
+		return models.Registration{}, gorm.ErrRecordNotFound
+	}
+	return r.reg, nil
+}
+
+func (r *lookupRegistrationRepo) GetStatusHistory(id uint) ([]models.RegistrationStatusHistory, error) {
+	return []models.RegistrationStatusHistory{
+		{RegistrationID: id, ToStatus: models.RegistrationStatusNew},
+		{RegistrationID: id, FromStatus: models.RegistrationStatusNew, ToStatus: models.RegistrationStatusValidate, Note: "internal note"},
+	}, nil
+}
+
+type lookupDocumentRepo struct {
+	repository.RegistrationDocumentRepo
+}
+
+func (r lookupDocumentRepo) ListByRegistration(registrationID uint) ([]models.RegistrationDocument, error) {
+	return []models.RegistrationDocument{
+		{RegistrationID: registrationID, Type: models.DocumentPhoto, Status: models.DocumentRejected, RejectReason: "buram"},
+	}, nil
+}
+
+func TestLookupStatus(t *testing.T) {
+	reg := models.Registration{
+		ID:              4,
+		ReferenceNumber: "DA25-7K3M9Q",
+		NISN:            "0012345678",
+		FullName:        "Ahmad",
+		Status:          models.RegistrationStatusValidate,
+		DateOfBirth:     time.Date(2012, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+	svc := NewRegistrationService(&lookupRegistrationRepo{reg: reg}, nil, lookupDocumentRepo{}, nil, time.Hour)
+
+	tests := []struct {
+		name string
+		req  dto.RegistrationStatusLookupRequest
+		want error
+	}{
+		{"reference", dto.RegistrationStatusLookupRequest{ReferenceNumber: "DA25-7K3M9Q", DateOfBirth: "2012-05-01"}, nil},
+		{"reference typed loosely", dto.RegistrationStatusLookupRequest{ReferenceNumber: " da25-7k3m9q ", DateOfBirth: " 2012-05-01 "}, nil},
+		{"nisn", dto.RegistrationStatusLookupRequest{NISN: "0012345678", DateOfBirth: "2012-05-01"}, nil},
+		{"reference wins over nisn", dto.RegistrationStatusLookupRequest{ReferenceNumber: "DA25-7K3M9Q", NISN: "9999999999", DateOfBirth: "2012-05-01"}, nil},
+		{"wrong date of birth", dto.RegistrationStatusLookupRequest{ReferenceNumber: "DA25-7K3M9Q", DateOfBirth: "2012-05-02"}, ErrRegistrationLookupFailed},
+		{"date of birth in another format", dto.RegistrationStatusLookupRequest{ReferenceNumber: "DA25-7K3M9Q", DateOfBirth: "01-05-2012"}, ErrRegistrationLookupFailed},
+		{"nisn with wrong date of birth", dto.RegistrationStatusLookupRequest{NISN: "0012345678", DateOfBirth: "2011-05-01"}, ErrRegistrationLookupFailed},
+		{"unknown reference", dto.RegistrationStatusLookupRequest{ReferenceNumber: "DA25-AAAAAA", DateOfBirth: "2012-05-01"}, ErrRegistrationLookupFailed},
+		{"unknown nisn", dto.RegistrationStatusLookupRequest{NISN: "9999999999", DateOfBirth: "2012-05-01"}, ErrRegistrationLookupFailed},
+	}
+	for _, tt := range tests {
+		got, err := svc.LookupStatus(tt.req)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+			continue
+		}
+		if err != nil {
+			if got.ReferenceNumber != "" || got.FullName != "" {
+				t.Errorf("%s: refused but returned %+v", tt.name, got)
+			}
+			continue
+		}
+		if got.ReferenceNumber != reg.ReferenceNumber || got.Status != reg.Status || len(got.Timeline) != 2 ||
+			len(got.RejectedDocuments) != 1 || got.RejectedDocuments[0].Reason != "buram" {
+			t.Errorf("%s: got %+v", tt.name, got)
+		}
+	}
+}
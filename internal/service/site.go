@@ -0,0 +1,41 @@
+package service
+
+import (
+	"net/url"
+	"strings"
+)
+
+// SiteConfig describes the public website (not this API), used for absolute
+// links in feeds and sitemaps.
+type SiteConfig struct {
+	BaseURL     string // e.g. https://www.darulabror.com, no trailing slash
+	Name        string
+	Description string
+	// APIBaseURL is where this API is publicly reachable (e.g.
+	// https://api.darulabror.com), for links to documents it serves itself such
+	// as a feed's self link. Empty when the website proxies those paths.
+	APIBaseURL string
+}
+
+func (s SiteConfig) Configured() bool {
+	return s.BaseURL != ""
+}
+
+// URL joins path onto the site base URL.
+func (s SiteConfig) URL(path string) string {
+	return strings.TrimRight(s.BaseURL, "/") + "/" + strings.TrimLeft(path, "/")
+}
+
+// APIURL joins path onto the API base URL, or the site's when the website
+// proxies the API's public documents.
+func (s SiteConfig) APIURL(path string) string {
+	if s.APIBaseURL == "" {
+		return s.URL(path)
+	}
+	return strings.TrimRight(s.APIBaseURL, "/") + "/" + strings.TrimLeft(path, "/")
+}
+
+// ArticleURL is the public page of an article.
+func (s SiteConfig) ArticleURL(slug string) string {
+	return s.URL("/articles/" + url.PathEscape(slug))
+}
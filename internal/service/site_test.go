@@ -0,0 +1,25 @@
+package service
+
+import "testing"
+
+func TestSiteURLs(t *testing.T) {
+	site := SiteConfig{BaseURL: "https://www.darulabror.com/"}
+	api := site
+	api.APIBaseURL = "https://api.darulabror.com/v1/"
+
+	tests := []struct {
+		got, want string
+	}{
+		{site.URL("/"), "https://www.darulabror.com/"},
+		{site.URL("articles"), "https://www.darulabror.com/articles"},
+		{site.ArticleURL("jum'at berkah/1"), "https://www.darulabror.com/articles/jum%27at%20berkah%2F1"},
+		{site.APIURL("/feeds/articles.rss"), "https://www.darulabror.com/feeds/articles.rss"}, // proxied
+		{api.APIURL("/feeds/articles.rss"), "https://api.darulabror.com/v1/feeds/articles.rss"},
+		{api.ArticleURL("wisuda"), "https://www.darulabror.com/articles/wisuda"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %q, want %q", tt.got, tt.want)
+		}
+	}
+}
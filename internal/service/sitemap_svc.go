@@ -0,0 +1,144 @@
+package service
+
+import (
+	"darulabror/internal/repository"
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MaxSitemapURLs is the sitemap protocol limit per file; beyond it /sitemap.xml
+// becomes an index of /sitemaps/<n>.xml files.
+const MaxSitemapURLs = 50000
+
+const sitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+type SitemapService interface {
+	// Sitemap renders /sitemap.xml: a urlset, or a sitemap index when there are
+	// more than MaxSitemapURLs URLs.
+	Sitemap() ([]byte, error)
+	// SitemapPage renders /sitemaps/<page>.xml (1-based). ErrNotFoundSitemapPage if out of range.
+	SitemapPage(page int) ([]byte, error)
+	// Robots renders /robots.txt.
+	Robots() string
+}
+
+type sitemapService struct {
+	articleRepo repository.ArticleRepo
+	site        SiteConfig
+	staticPaths []string // website pages listed before the articles, e.g. "/", "/pendaftaran"
+}
+
+func NewSitemapService(articleRepo repository.ArticleRepo, site SiteConfig, staticPaths []string) SitemapService {
+	return &sitemapService{articleRepo: articleRepo, site: site, staticPaths: staticPaths}
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	NS      string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	NS       string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapURL `xml:"sitemap"`
+}
+
+func (s *sitemapService) Sitemap() ([]byte, error) {
+	if !s.site.Configured() {
+		return nil, ErrSiteNotConfigured
+	}
+
+	total, err := s.totalURLs()
+	if err != nil {
+		return nil, err
+	}
+	if total <= MaxSitemapURLs {
+		return s.SitemapPage(1)
+	}
+
+	pages := int((total + MaxSitemapURLs - 1) / MaxSitemapURLs)
+	index := sitemapIndex{NS: sitemapNS, Sitemaps: make([]sitemapURL, 0, pages)}
+	for p := 1; p <= pages; p++ {
+		// the files are served by this API, the pages they list are on the website
+		index.Sitemaps = append(index.Sitemaps, sitemapURL{Loc: s.site.APIURL("/sitemaps/" + strconv.Itoa(p) + ".xml")})
+	}
+	return marshalXML(index)
+}
+
+// SitemapPage lists URLs [(page-1)*Max, page*Max) of: static pages, then articles by id.
+func (s *sitemapService) SitemapPage(page int) ([]byte, error) {
+	if !s.site.Configured() {
+		return nil, ErrSiteNotConfigured
+	}
+
+	total, err := s.totalURLs()
+	if err != nil {
+		return nil, err
+	}
+	start := int64(page-1) * MaxSitemapURLs
+	if page < 1 || (page > 1 && start >= total) {
+		return nil, ErrNotFoundSitemapPage
+	}
+	end := min(start+MaxSitemapURLs, total)
+
+	set := sitemapURLSet{NS: sitemapNS, URLs: make([]sitemapURL, 0, end-start)}
+
+	static := int64(len(s.staticPaths))
+	for i := start; i < min(end, static); i++ {
+		set.URLs = append(set.URLs, sitemapURL{Loc: s.site.URL(s.staticPaths[i])})
+	}
+
+	if end > static {
+		offset := max(start, static) - static
+		articles, err := s.articleRepo.GetPublishedSlugs(int(offset), int(end-max(start, static)))
+		if err != nil {
+			logrus.WithError(err).WithField("page", page).Error("failed get articles for sitemap")
+			return nil, err
+		}
+		for _, a := range articles {
+			set.URLs = append(set.URLs, sitemapURL{
+				Loc:     s.site.ArticleURL(a.Slug),
+				LastMod: time.Unix(a.UpdatedAt, 0).UTC().Format(time.RFC3339),
+			})
+		}
+	}
+	return marshalXML(set)
+}
+
+func (s *sitemapService) Robots() string {
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	b.WriteString("Disallow: /admin/\n")
+	b.WriteString("Disallow: /articles/preview/\n")
+	if s.site.Configured() {
+		b.WriteString("\nSitemap: " + s.site.APIURL("/sitemap.xml") + "\n")
+	}
+	return b.String()
+}
+
+func (s *sitemapService) totalURLs() (int64, error) {
+	articles, err := s.articleRepo.CountPublished()
+	if err != nil {
+		logrus.WithError(err).Error("failed count published articles for sitemap")
+		return 0, err
+	}
+	return int64(len(s.staticPaths)) + articles, nil
+}
+
+func marshalXML(v any) ([]byte, error) {
+	body, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
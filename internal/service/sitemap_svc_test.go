@@ -0,0 +1,125 @@
+package service
+
+import (
+	"darulabror/internal/models"
+	"darulabror/internal/repository"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// sitemapArticleRepo has count published articles "artikel-1", "artikel-2", ...
+type sitemapArticleRepo struct {
+	repository.ArticleRepo
+	count int64
+}
+
+func (r sitemapArticleRepo) CountPublished() (int64, error) { return r.count, nil }
+
+func (r sitemapArticleRepo) GetPublishedSlugs(offset, limit int) ([]models.Article, error) {
+	var out []models.Article
+	for i := offset; i < offset+limit && int64(i) < r.count; i++ {
+		out = append(out, models.Article{ID: uint(i + 1), Slug: "artikel-" + strconv.Itoa(i+1), UpdatedAt: 1735689600})
+	}
+	return out, nil
+}
+
+func TestSitemap(t *testing.T) {
+	site := SiteConfig{BaseURL: "https://www.darulabror.com", APIBaseURL: "https://api.darulabror.com"}
+	s := &sitemapService{articleRepo: sitemapArticleRepo{count: 2}, site: site, staticPaths: []string{"/", "/pendaftaran"}}
+
+	body, err := s.Sitemap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(body)
+	for _, want := range []string{
+		`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`,
+		`<url><loc>https://www.darulabror.com/</loc></url>`,
+		`<url><loc>https://www.darulabror.com/pendaftaran</loc></url>`,
+		`<url><loc>https://www.darulabror.com/articles/artikel-2</loc><lastmod>2025-01-01T00:00:00Z</lastmod></url>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %s in\n%s", want, got)
+		}
+	}
+}
+
+func TestSitemapIndex(t *testing.T) {
+	site := SiteConfig{BaseURL: "https://www.darulabror.com", APIBaseURL: "https://api.darulabror.com"}
+	// one static page + articles: 2 full files and a third with 2 URLs
+	s := &sitemapService{articleRepo: sitemapArticleRepo{count: 2*MaxSitemapURLs + 1}, site: site, staticPaths: []string{"/"}}
+
+	body, err := s.Sitemap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(body)
+	if !strings.Contains(got, "<sitemapindex") || strings.Count(got, "<sitemap>") != 3 {
+		t.Fatalf("want an index of 3 files, got\n%s", got)
+	}
+	// the files are served by the API, not the website
+	if !strings.Contains(got, "<loc>https://api.darulabror.com/sitemaps/3.xml</loc>") {
+		t.Errorf("index entries not on API_PUBLIC_URL:\n%s", got)
+	}
+
+	tests := []struct {
+		page      int
+		urls      int
+		first     string
+		wantError error
+	}{
+		{1, MaxSitemapURLs, "https://www.darulabror.com/", nil},
+		{2, MaxSitemapURLs, "https://www.darulabror.com/articles/artikel-50000", nil},
+		{3, 2, "https://www.darulabror.com/articles/artikel-100000", nil},
+		{4, 0, "", ErrNotFoundSitemapPage},
+		{0, 0, "", ErrNotFoundSitemapPage},
+	}
+	for _, tt := range tests {
+		body, err := s.SitemapPage(tt.page)
+		if !errors.Is(err, tt.wantError) {
+			t.Errorf("page %d: err = %v, want %v", tt.page, err, tt.wantError)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		page := string(body)
+		if n := strings.Count(page, "<url>"); n != tt.urls {
+			t.Errorf("page %d: %d URLs, want %d", tt.page, n, tt.urls)
+		}
+		if !strings.Contains(page, "<url><loc>"+tt.first+"</loc>") {
+			t.Errorf("page %d doesn't start with %s", tt.page, tt.first)
+		}
+	}
+}
+
+func TestRobots(t *testing.T) {
+	tests := []struct {
+		site        SiteConfig
+		wantSitemap string
+	}{
+		{SiteConfig{BaseURL: "https://www.darulabror.com", APIBaseURL: "https://api.darulabror.com"},
+			"Sitemap: https://api.darulabror.com/sitemap.xml\n"},
+		{SiteConfig{BaseURL: "https://www.darulabror.com"}, // proxied by the website
+			"Sitemap: https://www.darulabror.com/sitemap.xml\n"},
+		{SiteConfig{}, ""},
+	}
+	for _, tt := range tests {
+		got := (&sitemapService{site: tt.site}).Robots()
+		if !strings.HasPrefix(got, "User-agent: *\nDisallow: /admin/\nDisallow: /articles/preview/\n") {
+			t.Errorf("rules missing:\n%s", got)
+		}
+		if tt.wantSitemap == "" && strings.Contains(got, "Sitemap:") || !strings.HasSuffix(got, tt.wantSitemap) {
+			t.Errorf("got\n%s\nwant the line %q", got, tt.wantSitemap)
+		}
+	}
+}
+
+func TestSitemapNotConfigured(t *testing.T) {
+	s := &sitemapService{articleRepo: sitemapArticleRepo{}}
+	if _, err := s.Sitemap(); !errors.Is(err, ErrSiteNotConfigured) {
+		t.Errorf("got %v, want ErrSiteNotConfigured", err)
+	}
+}
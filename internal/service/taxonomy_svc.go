@@ -0,0 +1,235 @@
+package service
+
+import (
+	"darulabror/internal/dto"
+	"darulabror/internal/repository"
+	"darulabror/internal/utils"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type TaxonomyService interface {
+	// Public + Admin (publishedOnly=true counts only published articles)
+	GetAllCategories(publishedOnly bool) ([]dto.CategoryDTO, error)
+	GetAllTags(publishedOnly bool) ([]dto.TagDTO, error)
+
+	// Admin
+	CreateCategory(categoryDTO dto.CategoryDTO) (dto.CategoryDTO, error)
+	UpdateCategory(id uint, categoryDTO dto.CategoryDTO) error
+	DeleteCategory(id uint) error
+
+	CreateTag(tagDTO dto.TagDTO) (dto.TagDTO, error)
+	UpdateTag(id uint, tagDTO dto.TagDTO) error
+	DeleteTag(id uint) error
+}
+
+type taxonomyService struct {
+	repo repository.TaxonomyRepo
+}
+
+func NewTaxonomyService(repo repository.TaxonomyRepo) TaxonomyService {
+	return &taxonomyService{repo: repo}
+}
+
+// ======================
+// Categories
+// ======================
+
+func (s *taxonomyService) GetAllCategories(publishedOnly bool) ([]dto.CategoryDTO, error) {
+	rows, err := s.repo.ListCategories(publishedOnly)
+	if err != nil {
+		logrus.WithError(err).Error("failed list categories")
+		return nil, err
+	}
+
+	out := make([]dto.CategoryDTO, 0, len(rows))
+	for _, r := range rows {
+		d := dto.CategoryModelToDTO(r.Category)
+		count := r.ArticleCount
+		d.ArticleCount = &count
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+func (s *taxonomyService) CreateCategory(categoryDTO dto.CategoryDTO) (dto.CategoryDTO, error) {
+	category := dto.CategoryDTOToModel(categoryDTO)
+	category.ID = 0
+
+	slug, err := s.categorySlug(categoryDTO.Slug, categoryDTO.Name, 0)
+	if err != nil {
+		return dto.CategoryDTO{}, err
+	}
+	category.Slug = slug
+
+	if err := s.repo.CreateCategory(&category); err != nil {
+		logrus.WithError(err).WithField("slug", category.Slug).Error("failed create category")
+		return dto.CategoryDTO{}, err
+	}
+
+	logrus.WithField("slug", category.Slug).Info("category created")
+	return dto.CategoryModelToDTO(category), nil
+}
+
+func (s *taxonomyService) UpdateCategory(id uint, categoryDTO dto.CategoryDTO) error {
+	category, err := s.repo.GetCategoryByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFoundCategory
+		}
+		logrus.WithError(err).WithField("id", id).Error("failed get category by id")
+		return err
+	}
+
+	slug, err := s.categorySlug(categoryDTO.Slug, categoryDTO.Name, id)
+	if err != nil {
+		return err
+	}
+
+	category.Name = categoryDTO.Name
+	category.Slug = slug
+	category.Description = categoryDTO.Description
+
+	if err := s.repo.UpdateCategory(category); err != nil {
+		logrus.WithError(err).WithField("id", id).Error("failed update category")
+		return err
+	}
+
+	logrus.WithField("id", id).Info("category updated")
+	return nil
+}
+
+func (s *taxonomyService) DeleteCategory(id uint) error {
+	if err := s.repo.DeleteCategory(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFoundCategory
+		}
+		logrus.WithError(err).WithField("id", id).Error("failed delete category")
+		return err
+	}
+	logrus.WithField("id", id).Info("category deleted")
+	return nil
+}
+
+// categorySlug picks the explicit slug (or one derived from name) and rejects duplicates.
+// Unlike articles there is no auto-suffix: two sections with the same URL is an editor mistake.
+func (s *taxonomyService) categorySlug(slug, name string, excludeID uint) (string, error) {
+	if slug == "" {
+		slug = name
+	}
+	slug = utils.Slugify(slug)
+	if slug == "" {
+		return "", ErrInvalidCategory
+	}
+
+	exists, err := s.repo.CategorySlugExists(slug, excludeID)
+	if err != nil {
+		logrus.WithError(err).WithField("slug", slug).Error("failed check category slug")
+		return "", err
+	}
+	if exists {
+		return "", ErrCategoryExists
+	}
+	return slug, nil
+}
+
+// ======================
+// Tags
+// ======================
+
+func (s *taxonomyService) GetAllTags(publishedOnly bool) ([]dto.TagDTO, error) {
+	rows, err := s.repo.ListTags(publishedOnly)
+	if err != nil {
+		logrus.WithError(err).Error("failed list tags")
+		return nil, err
+	}
+
+	out := make([]dto.TagDTO, 0, len(rows))
+	for _, r := range rows {
+		d := dto.TagModelToDTO(r.Tag)
+		count := r.ArticleCount
+		d.ArticleCount = &count
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+func (s *taxonomyService) CreateTag(tagDTO dto.TagDTO) (dto.TagDTO, error) {
+	tag := dto.TagDTOToModel(tagDTO)
+	tag.ID = 0
+
+	slug, err := s.tagSlug(tagDTO.Slug, tagDTO.Name, 0)
+	if err != nil {
+		return dto.TagDTO{}, err
+	}
+	tag.Slug = slug
+
+	if err := s.repo.CreateTag(&tag); err != nil {
+		logrus.WithError(err).WithField("slug", tag.Slug).Error("failed create tag")
+		return dto.TagDTO{}, err
+	}
+
+	logrus.WithField("slug", tag.Slug).Info("tag created")
+	return dto.TagModelToDTO(tag), nil
+}
+
+func (s *taxonomyService) UpdateTag(id uint, tagDTO dto.TagDTO) error {
+	tag, err := s.repo.GetTagByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFoundTag
+		}
+		logrus.WithError(err).WithField("id", id).Error("failed get tag by id")
+		return err
+	}
+
+	slug, err := s.tagSlug(tagDTO.Slug, tagDTO.Name, id)
+	if err != nil {
+		return err
+	}
+
+	tag.Name = tagDTO.Name
+	tag.Slug = slug
+
+	if err := s.repo.UpdateTag(tag); err != nil {
+		logrus.WithError(err).WithField("id", id).Error("failed update tag")
+		return err
+	}
+
+	logrus.WithField("id", id).Info("tag updated")
+	return nil
+}
+
+func (s *taxonomyService) DeleteTag(id uint) error {
+	if err := s.repo.DeleteTag(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFoundTag
+		}
+		logrus.WithError(err).WithField("id", id).Error("failed delete tag")
+		return err
+	}
+	logrus.WithField("id", id).Info("tag deleted")
+	return nil
+}
+
+func (s *taxonomyService) tagSlug(slug, name string, excludeID uint) (string, error) {
+	if slug == "" {
+		slug = name
+	}
+	slug = utils.Slugify(slug)
+	if slug == "" {
+		return "", ErrInvalidTag
+	}
+
+	exists, err := s.repo.TagSlugExists(slug, excludeID)
+	if err != nil {
+		logrus.WithError(err).WithField("slug", slug).Error("failed check tag slug")
+		return "", err
+	}
+	if exists {
+		return "", ErrTagExists
+	}
+	return slug, nil
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"darulabror/internal/dto"
+	"darulabror/internal/models"
+	"darulabror/internal/repository"
+	"errors"
+	"testing"
+)
+
+type fakeTaxonomyRepo struct {
+	repository.TaxonomyRepo
+	categories []models.Category
+	tags       []models.Tag
+}
+
+func (f *fakeTaxonomyRepo) CategorySlugExists(slug string, excludeID uint) (bool, error) {
+	for _, c := range f.categories {
+		if c.Slug == slug && c.ID != excludeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeTaxonomyRepo) TagSlugExists(slug string, excludeID uint) (bool, error) {
+	for _, t := range f.tags {
+		if t.Slug == slug && t.ID != excludeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeTaxonomyRepo) GetCategoriesByIDs(ids []uint) ([]models.Category, error) {
+	var out []models.Category
+	for _, c := range f.categories {
+		for _, id := range ids {
+			if c.ID == id {
+				out = append(out, c)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeTaxonomyRepo) GetTagsByIDs(ids []uint) ([]models.Tag, error) {
+	var out []models.Tag
+	for _, t := range f.tags {
+		for _, id := range ids {
+			if t.ID == id {
+				out = append(out, t)
+			}
+		}
+	}
+	return out, nil
+}
+
+func newFakeTaxonomyRepo() *fakeTaxonomyRepo {
+	return &fakeTaxonomyRepo{
+		categories: []models.Category{{ID: 1, Slug: "berita"}, {ID: 2, Slug: "pengumuman"}},
+		tags:       []models.Tag{{ID: 1, Slug: "ramadhan"}, {ID: 2, Slug: "santri-baru"}},
+	}
+}
+
+func TestTaxonomySlugs(t *testing.T) {
+	s := &taxonomyService{repo: newFakeTaxonomyRepo()}
+
+	tests := []struct {
+		slug, name string
+		excludeID  uint
+		want       string
+		wantErr    error
+	}{
+		{"", "Kegiatan Santri", 0, "kegiatan-santri", nil},
+		{"Info PSB", "Kegiatan Santri", 0, "info-psb", nil}, // explicit slug wins
+		{"", "Berita", 0, "", ErrCategoryExists},            // no auto-suffix
+		{"", "Berita", 1, "berita", nil},                    // its own slug
+		{"", "Berita", 2, "", ErrCategoryExists},
+		{"", "???", 0, "", ErrInvalidCategory},
+	}
+	for _, tt := range tests {
+		got, err := s.categorySlug(tt.slug, tt.name, tt.excludeID)
+		if !errors.Is(err, tt.wantErr) || got != tt.want {
+			t.Errorf("categorySlug(%q, %q, %d) = %q, %v; want %q, %v", tt.slug, tt.name, tt.excludeID, got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	if _, err := s.tagSlug("", "Santri Baru", 0); !errors.Is(err, ErrTagExists) {
+		t.Errorf("duplicate tag: got %v", err)
+	}
+	if got, err := s.tagSlug("", "Santri Baru", 2); err != nil || got != "santri-baru" {
+		t.Errorf("own tag slug: got %q, %v", got, err)
+	}
+	if _, err := s.tagSlug("", "-", 0); !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("empty tag slug: got %v", err)
+	}
+}
+
+func TestResolveTaxonomy(t *testing.T) {
+	s := &articleService{taxonomyRepo: newFakeTaxonomyRepo()}
+	current := []models.Category{{ID: 2, Slug: "pengumuman"}}
+
+	tests := []struct {
+		name           string
+		categoryIDs    []uint
+		tagIDs         []uint
+		wantCategories int
+		wantTags       int
+		wantErr        error
+	}{
+		{"nil keeps the assignment", nil, nil, 1, 0, nil},
+		{"empty clears it", []uint{}, []uint{}, 0, 0, nil},
+		{"duplicates and zero ignored", []uint{1, 1, 0, 2}, []uint{2, 2}, 2, 1, nil},
+		{"unknown category", []uint{1, 9}, nil, 0, 0, ErrInvalidCategory},
+		{"unknown tag", nil, []uint{7}, 0, 0, ErrInvalidTag},
+	}
+	for _, tt := range tests {
+		article := models.Article{Categories: current}
+		err := s.resolveTaxonomy(&article, dto.ArticleDTO{CategoryIDs: tt.categoryIDs, TagIDs: tt.tagIDs})
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if len(article.Categories) != tt.wantCategories || len(article.Tags) != tt.wantTags {
+			t.Errorf("%s: %d categories, %d tags; want %d, %d", tt.name,
+				len(article.Categories), len(article.Tags), tt.wantCategories, tt.wantTags)
+		}
+	}
+}
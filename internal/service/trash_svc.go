@@ -0,0 +1,175 @@
+package service
+
+import (
+	"darulabror/internal/dto"
+	"darulabror/internal/repository"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// TrashService manages soft-deleted articles, registrations and contacts.
+type TrashService interface {
+	ListTrash(trashType string, page, limit int) ([]dto.TrashItemDTO, int64, error)
+	RestoreTrash(trashType string, id uint) error
+	PurgeTrash(trashType string, id uint) error
+
+	// Retention job: permanently deletes items trashed longer than the retention.
+	PurgeExpired(now time.Time) (int64, error)
+}
+
+type trashService struct {
+	articleRepo      repository.ArticleRepo
+	registrationRepo repository.RegistrationRepo
+	contactRepo      repository.ContactRepository
+	retention        time.Duration // 0 = keep trashed items until purged by hand
+}
+
+func NewTrashService(
+	articleRepo repository.ArticleRepo,
+	registrationRepo repository.RegistrationRepo,
+	contactRepo repository.ContactRepository,
+	retention time.Duration,
+) TrashService {
+	return &trashService{
+		articleRepo:      articleRepo,
+		registrationRepo: registrationRepo,
+		contactRepo:      contactRepo,
+		retention:        retention,
+	}
+}
+
+func (s *trashService) ListTrash(trashType string, page, limit int) ([]dto.TrashItemDTO, int64, error) {
+	var (
+		items []dto.TrashItemDTO
+		total int64
+		err   error
+	)
+
+	switch trashType {
+	case dto.TrashTypeArticles:
+		articles, n, e := s.articleRepo.GetTrashed(page, limit)
+		total, err = n, e
+		for _, a := range articles {
+			items = append(items, s.item(trashType, a.ID, a.Title, a.DeletedAt, dto.ArticleModelToDTO(a)))
+		}
+	case dto.TrashTypeRegistrations:
+		regs, n, e := s.registrationRepo.GetTrashed(page, limit)
+		total, err = n, e
+		for _, r := range regs {
+			items = append(items, s.item(trashType, r.ID, r.FullName, r.DeletedAt, dto.RegistrationModelToDTO(r)))
+		}
+	case dto.TrashTypeContacts:
+		contacts, n, e := s.contactRepo.GetTrashedContacts(page, limit)
+		total, err = n, e
+		for _, c := range contacts {
+			items = append(items, s.item(trashType, c.ID, c.Subject, c.DeletedAt, c))
+		}
+	default:
+		return nil, 0, ErrInvalidTrashType
+	}
+
+	if err != nil {
+		logrus.WithError(err).WithField("type", trashType).Error("failed list trash")
+		return nil, 0, err
+	}
+	if items == nil {
+		items = []dto.TrashItemDTO{}
+	}
+	return items, total, nil
+}
+
+func (s *trashService) RestoreTrash(trashType string, id uint) error {
+	var err error
+	switch trashType {
+	case dto.TrashTypeArticles:
+		err = s.articleRepo.Restore(id)
+	case dto.TrashTypeRegistrations:
+		err = s.registrationRepo.Restore(id)
+	case dto.TrashTypeContacts:
+		err = s.contactRepo.RestoreContact(id)
+	default:
+		return ErrInvalidTrashType
+	}
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFoundTrashItem
+		}
+		logrus.WithError(err).WithFields(logrus.Fields{"type": trashType, "id": id}).Error("failed restore from trash")
+		return err
+	}
+	logrus.WithFields(logrus.Fields{"type": trashType, "id": id}).Info("restored from trash")
+	return nil
+}
+
+func (s *trashService) PurgeTrash(trashType string, id uint) error {
+	var err error
+	switch trashType {
+	case dto.TrashTypeArticles:
+		err = s.articleRepo.Purge(id)
+	case dto.TrashTypeRegistrations:
+		err = s.registrationRepo.Purge(id)
+	case dto.TrashTypeContacts:
+		err = s.contactRepo.PurgeContact(id)
+	default:
+		return ErrInvalidTrashType
+	}
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFoundTrashItem
+		}
+		logrus.WithError(err).WithFields(logrus.Fields{"type": trashType, "id": id}).Error("failed purge from trash")
+		return err
+	}
+	logrus.WithFields(logrus.Fields{"type": trashType, "id": id}).Info("purged from trash")
+	return nil
+}
+
+func (s *trashService) PurgeExpired(now time.Time) (int64, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+	cutoff := now.Add(-s.retention)
+
+	purges := []struct {
+		trashType string
+		purge     func(time.Time) (int64, error)
+	}{
+		{dto.TrashTypeArticles, s.articleRepo.PurgeTrashedBefore},
+		{dto.TrashTypeRegistrations, s.registrationRepo.PurgeTrashedBefore},
+		{dto.TrashTypeContacts, s.contactRepo.PurgeTrashedContactsBefore},
+	}
+
+	var total int64
+	for _, p := range purges {
+		n, err := p.purge(cutoff)
+		if err != nil {
+			logrus.WithError(err).WithField("type", p.trashType).Error("failed purge expired trash")
+			return total, err
+		}
+		if n > 0 {
+			logrus.WithFields(logrus.Fields{"type": p.trashType, "count": n}).Info("expired trash purged")
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (s *trashService) item(trashType string, id uint, label string, deletedAt gorm.DeletedAt, entity interface{}) dto.TrashItemDTO {
+	item := dto.TrashItemDTO{
+		Type:      trashType,
+		ID:        id,
+		Label:     label,
+		DeletedAt: deletedAt.Time,
+		Item:      entity,
+	}
+	if s.retention > 0 {
+		purgeAt := deletedAt.Time.Add(s.retention)
+		item.PurgeAt = &purgeAt
+	}
+	return item
+}
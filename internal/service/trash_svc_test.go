@@ -0,0 +1,134 @@
+package service
+
+import (
+	"darulabror/internal/dto"
+	"darulabror/internal/repository"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// fakeTrash backs the trash methods of the three repositories.
+type fakeTrash struct {
+	trashed map[uint]bool
+	cutoff  time.Time
+	expired int64
+}
+
+func (f *fakeTrash) restore(id uint) error {
+	if !f.trashed[id] {
+		return gorm.ErrRecordNotFound
+	}
+	delete(f.trashed, id)
+	return nil
+}
+
+func (f *fakeTrash) purgeBefore(cutoff time.Time) (int64, error) {
+	f.cutoff = cutoff
+	return f.expired, nil
+}
+
+type trashArticleRepo struct {
+	repository.ArticleRepo
+	*fakeTrash
+}
+
+func (r trashArticleRepo) Restore(id uint) error { return r.restore(id) }
+func (r trashArticleRepo) PurgeTrashedBefore(c time.Time) (int64, error) {
+	return r.purgeBefore(c)
+}
+
+type trashRegistrationRepo struct {
+	repository.RegistrationRepo
+	*fakeTrash
+}
+
+func (r trashRegistrationRepo) Restore(id uint) error { return r.restore(id) }
+func (r trashRegistrationRepo) PurgeTrashedBefore(c time.Time) (int64, error) {
+	return r.purgeBefore(c)
+}
+
+type trashContactRepo struct {
+	repository.ContactRepository
+	*fakeTrash
+}
+
+func (r trashContactRepo) RestoreContact(id uint) error { return r.restore(id) }
+func (r trashContactRepo) PurgeTrashedContactsBefore(c time.Time) (int64, error) {
+	return r.purgeBefore(c)
+}
+
+func newTrashService(retention time.Duration) (*trashService, [3]*fakeTrash) {
+	var fakes [3]*fakeTrash
+	for i := range fakes {
+		fakes[i] = &fakeTrash{trashed: map[uint]bool{1: true}, expired: int64(i + 1)}
+	}
+	return &trashService{
+		articleRepo:      trashArticleRepo{fakeTrash: fakes[0]},
+		registrationRepo: trashRegistrationRepo{fakeTrash: fakes[1]},
+		contactRepo:      trashContactRepo{fakeTrash: fakes[2]},
+		retention:        retention,
+	}, fakes
+}
+
+func TestRestoreTrash(t *testing.T) {
+	tests := []struct {
+		trashType string
+		id        uint
+		wantErr   error
+	}{
+		{dto.TrashTypeArticles, 1, nil},
+		{dto.TrashTypeRegistrations, 1, nil},
+		{dto.TrashTypeContacts, 1, nil},
+		{dto.TrashTypeArticles, 2, ErrNotFoundTrashItem}, // live or missing
+		{"admins", 1, ErrInvalidTrashType},
+	}
+	for _, tt := range tests {
+		s, _ := newTrashService(0)
+		if err := s.RestoreTrash(tt.trashType, tt.id); !errors.Is(err, tt.wantErr) {
+			t.Errorf("RestoreTrash(%q, %d) = %v, want %v", tt.trashType, tt.id, err, tt.wantErr)
+		}
+	}
+}
+
+func TestPurgeExpired(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	s, fakes := newTrashService(30 * 24 * time.Hour)
+	n, err := s.PurgeExpired(now)
+	if err != nil || n != 1+2+3 {
+		t.Fatalf("PurgeExpired = %d, %v; want 6", n, err)
+	}
+	want := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i, f := range fakes {
+		if !f.cutoff.Equal(want) {
+			t.Errorf("repo %d: cutoff %v, want %v", i, f.cutoff, want)
+		}
+	}
+
+	// retention off: nothing is purged
+	s, fakes = newTrashService(0)
+	if n, err := s.PurgeExpired(now); n != 0 || err != nil {
+		t.Errorf("retention off: %d, %v", n, err)
+	}
+	if !fakes[0].cutoff.IsZero() {
+		t.Error("retention off: repository called")
+	}
+}
+
+func TestTrashItemPurgeAt(t *testing.T) {
+	deleted := gorm.DeletedAt{Time: time.Date(2025, 1, 10, 8, 0, 0, 0, time.UTC), Valid: true}
+
+	s, _ := newTrashService(7 * 24 * time.Hour)
+	item := s.item(dto.TrashTypeContacts, 4, "Pertanyaan", deleted, nil)
+	if item.PurgeAt == nil || !item.PurgeAt.Equal(deleted.Time.AddDate(0, 0, 7)) {
+		t.Errorf("purge_at = %v", item.PurgeAt)
+	}
+
+	s, _ = newTrashService(0)
+	if item := s.item(dto.TrashTypeContacts, 4, "Pertanyaan", deleted, nil); item.PurgeAt != nil {
+		t.Errorf("retention off: purge_at = %v", item.PurgeAt)
+	}
+}
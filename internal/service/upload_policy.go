@@ -0,0 +1,127 @@
+package service
+
+import (
+	"darulabror/internal/content"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// UploadSlot is where an uploaded file is used. Each slot has its own storage
+// folder and its own list of allowed file types.
+type UploadSlot string
+
+const (
+	UploadSlotLibrary        UploadSlot = "library"         // POST /admin/media
+	UploadSlotArticleHeader  UploadSlot = "article_header"  // photo_header_file
+	UploadSlotArticleContent UploadSlot = "article_content" // content_files[<key>]
+	UploadSlotRegistration   UploadSlot = "registration"    // registration documents (private store)
+)
+
+// folder is the storage folder of the slot.
+func (s UploadSlot) folder() string {
+	switch s {
+	case UploadSlotArticleHeader:
+		return MediaFolderArticleHeader
+	case UploadSlotArticleContent:
+		return MediaFolderArticleContent
+	default:
+		return MediaFolderLibrary
+	}
+}
+
+// UploadPolicy decides which uploads are accepted. Types are always sniffed from
+// the file content; the file name and the client's Content-Type are ignored.
+type UploadPolicy struct {
+	// Allowed lists the MIME types per slot ("image/*" matches a family). A slot
+	// without an entry accepts nothing.
+	Allowed map[UploadSlot][]string
+
+	// Size limits in bytes per kind of file (0 = no limit besides the request body limit).
+	MaxImageSize int64
+	MaxVideoSize int64
+	MaxFileSize  int64 // everything else (PDF, audio, ...)
+}
+
+var (
+	uploadImageTypes = []string{"image/jpeg", "image/png", "image/webp", "image/gif"}
+	uploadVideoTypes = []string{"video/mp4", "video/webm", "video/quicktime"}
+)
+
+// DefaultUploadPolicy allows images as article headers, images, video and PDF in
+// article content and the media library, and scans (JPEG, PNG, PDF) as
+// registration documents.
+func DefaultUploadPolicy() UploadPolicy {
+	media := append(append(append([]string{}, uploadImageTypes...), uploadVideoTypes...), "application/pdf")
+	return UploadPolicy{
+		Allowed: map[UploadSlot][]string{
+			UploadSlotLibrary:        media,
+			UploadSlotArticleHeader:  append([]string{}, uploadImageTypes...),
+			UploadSlotArticleContent: media,
+			UploadSlotRegistration:   {"image/jpeg", "image/png", "application/pdf"},
+		},
+		MaxImageSize: 10 << 20,
+		MaxVideoSize: 20 << 20,
+		MaxFileSize:  10 << 20,
+	}
+}
+
+// check returns ErrUnsupportedMediaType or ErrMediaTooLarge (wrapped with a
+// message for the client) when a file of this type and size can't go into slot.
+func (p UploadPolicy) check(slot UploadSlot, mimeType string, size int64) error {
+	allowed := p.Allowed[slot]
+	if !content.AcceptsMIME(allowed, mimeType) {
+		return fmt.Errorf("%w: %s is not allowed here (allowed: %s)", ErrUnsupportedMediaType, mimeType, strings.Join(allowed, ", "))
+	}
+
+	kind, limit := "file", p.MaxFileSize
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		kind, limit = "image", p.MaxImageSize
+	case strings.HasPrefix(mimeType, "video/"):
+		kind, limit = "video", p.MaxVideoSize
+	}
+	if limit > 0 && size > limit {
+		return fmt.Errorf("%w: %s is %s, %s uploads are limited to %s", ErrMediaTooLarge, mimeType, formatBytes(size), kind, formatBytes(limit))
+	}
+	return nil
+}
+
+// inspect returns the sniffed type and size of file and checks them against the
+// policy of slot. Leaves file rewound.
+func (p UploadPolicy) inspect(file io.ReadSeeker, slot UploadSlot) (string, int64, error) {
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", 0, err
+	}
+	mimeType, err := detectMimeType(file)
+	if err != nil {
+		return "", 0, err
+	}
+	if err := p.check(slot, mimeType, size); err != nil {
+		logrus.WithFields(logrus.Fields{"slot": slot, "mime_type": mimeType, "size": size}).Info("upload rejected")
+		return mimeType, size, err
+	}
+	return mimeType, size, nil
+}
+
+// formatBytes renders a size like "2.5 MB".
+func formatBytes(n int64) string {
+	const unit = 1 << 10
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, suffix := float64(n)/unit, "KB"
+	for _, next := range []string{"MB", "GB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, next
+	}
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", value), ".0") + " " + suffix
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestUploadPolicyCheck(t *testing.T) {
+	policy := DefaultUploadPolicy()
+	policy.Allowed[UploadSlotLibrary] = []string{"image/*", "application/pdf"}
+
+	tests := []struct {
+		slot     UploadSlot
+		mimeType string
+		size     int64
+		want     error
+	}{
+		{UploadSlotArticleHeader, "image/jpeg", 1 << 20, nil},
+		{UploadSlotArticleHeader, "image/gif", 1 << 20, nil},
+		{UploadSlotArticleHeader, "video/mp4", 1 << 20, ErrUnsupportedMediaType},
+		{UploadSlotArticleHeader, "application/pdf", 1 << 20, ErrUnsupportedMediaType},
+		{UploadSlotArticleHeader, "image/svg+xml", 1 << 10, ErrUnsupportedMediaType}, // scripts in SVG
+		{UploadSlotArticleContent, "video/webm", 1 << 20, nil},
+		{UploadSlotArticleContent, "application/pdf", 1 << 20, nil},
+		{UploadSlotArticleContent, "text/html", 1 << 10, ErrUnsupportedMediaType},
+		{UploadSlotRegistration, "image/png", 1 << 20, nil},
+		{UploadSlotRegistration, "image/webp", 1 << 20, ErrUnsupportedMediaType},
+		{UploadSlotLibrary, "image/bmp", 1 << 20, nil}, // family wildcard
+		{UploadSlotLibrary, "video/mp4", 1 << 20, ErrUnsupportedMediaType},
+		{UploadSlot("unknown"), "image/jpeg", 1 << 10, ErrUnsupportedMediaType}, // no entry: nothing allowed
+
+		{UploadSlotArticleHeader, "image/png", 10 << 20, nil},
+		{UploadSlotArticleHeader, "image/png", 10<<20 + 1, ErrMediaTooLarge},
+		{UploadSlotArticleContent, "video/mp4", 20 << 20, nil},
+		{UploadSlotArticleContent, "video/mp4", 20<<20 + 1, ErrMediaTooLarge},
+		{UploadSlotArticleContent, "application/pdf", 10<<20 + 1, ErrMediaTooLarge},
+	}
+	for _, tt := range tests {
+		if err := policy.check(tt.slot, tt.mimeType, tt.size); !errors.Is(err, tt.want) {
+			t.Errorf("%s %s (%d bytes): got %v, want %v", tt.slot, tt.mimeType, tt.size, err, tt.want)
+		}
+	}
+
+	// no limit configured: only the allowlist applies
+	policy.MaxImageSize = 0
+	if err := policy.check(UploadSlotArticleHeader, "image/png", 1<<30); err != nil {
+		t.Errorf("unlimited: %v", err)
+	}
+}
+
+func TestUploadPolicyInspect(t *testing.T) {
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, image.NewNRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		slot     UploadSlot
+		wantMIME string
+		want     error
+	}{
+		{"png", pngData.Bytes(), UploadSlotArticleHeader, "image/png", nil},
+		{"jpeg", []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00"), UploadSlotArticleHeader, "image/jpeg", nil},
+		{"pdf", []byte("%PDF-1.7\n%âãÏÓ\n"), UploadSlotRegistration, "application/pdf", nil},
+		{"pdf as header", []byte("%PDF-1.7\n"), UploadSlotArticleHeader, "application/pdf", ErrUnsupportedMediaType},
+		{"html", []byte("<!DOCTYPE html><html><script>alert(1)</script></html>"), UploadSlotArticleContent, "text/html", ErrUnsupportedMediaType},
+		{"svg", []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`), UploadSlotArticleHeader, "image/svg+xml", ErrUnsupportedMediaType},
+		{"plain text", []byte("just some notes"), UploadSlotLibrary, "text/plain", ErrUnsupportedMediaType},
+		{"empty", nil, UploadSlotLibrary, "text/plain", ErrUnsupportedMediaType},
+	}
+	policy := DefaultUploadPolicy()
+	for _, tt := range tests {
+		file := bytes.NewReader(tt.data)
+		file.Seek(3, io.SeekStart) // callers may hand over a file that was already read from
+
+		mimeType, size, err := policy.inspect(file, tt.slot)
+		if mimeType != tt.wantMIME || !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %s, %v; want %s, %v", tt.name, mimeType, err, tt.wantMIME, tt.want)
+		}
+		if size != int64(len(tt.data)) && err == nil {
+			t.Errorf("%s: size %d, want %d", tt.name, size, len(tt.data))
+		}
+		if pos, _ := file.Seek(0, io.SeekCurrent); pos != 0 {
+			t.Errorf("%s: left at offset %d, want rewound", tt.name, pos)
+		}
+	}
+}
+
+func TestUploadPolicyMessages(t *testing.T) {
+	policy := DefaultUploadPolicy()
+	err := policy.check(UploadSlotArticleHeader, "video/mp4", 1)
+	if err == nil || !strings.Contains(err.Error(), "allowed: image/jpeg, image/png, image/webp, image/gif") {
+		t.Errorf("got %v", err)
+	}
+	err = policy.check(UploadSlotArticleContent, "video/mp4", 25<<20)
+	if err == nil || !strings.Contains(err.Error(), "video/mp4 is 25 MB, video uploads are limited to 20 MB") {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1 KB"},
+		{1536, "1.5 KB"},
+		{10 << 20, "10 MB"},
+		{5<<30 + 1<<29, "5.5 GB"},
+	}
+	for _, tt := range tests {
+		if got := formatBytes(tt.n); got != tt.want {
+			t.Errorf("%d: got %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
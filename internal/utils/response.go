@@ -126,10 +126,26 @@ func ConflictResponse(c echo.Context, message string) error {
 	return sendResponse(c, http.StatusConflict, "error", message, nil)
 }
 
+func RequestEntityTooLargeResponse(c echo.Context, message string) error {
+	return sendResponse(c, http.StatusRequestEntityTooLarge, "error", message, nil)
+}
+
+func UnsupportedMediaTypeResponse(c echo.Context, message string) error {
+	return sendResponse(c, http.StatusUnsupportedMediaType, "error", message, nil)
+}
+
 func UnprocessableEntityResponse(c echo.Context, message string) error {
 	return sendResponse(c, http.StatusUnprocessableEntity, "error", message, nil)
 }
 
+func TooManyRequestsResponse(c echo.Context, message string) error {
+	return sendResponse(c, http.StatusTooManyRequests, "error", message, nil)
+}
+
 func InternalServerErrorResponse(c echo.Context, message string) error {
 	return sendResponse(c, http.StatusInternalServerError, "error", message, nil)
 }
+
+func ServiceUnavailableResponse(c echo.Context, message string) error {
+	return sendResponse(c, http.StatusServiceUnavailable, "error", message, nil)
+}
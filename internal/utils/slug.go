@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const MaxSlugLength = 100
+
+// slugReplacer handles symbols that carry meaning in Indonesian titles.
+// Apostrophes / ayn-hamza marks are dropped so "Jum'at" -> "jumat", "Qur'an" -> "quran".
+var slugReplacer = strings.NewReplacer(
+	"&", " dan ",
+	"+", " plus ",
+	"%", " persen ",
+	"@", " at ",
+	"'", "",
+	"’", "",
+	"‘", "",
+	"`", "",
+	"ʼ", "",
+	"ʾ", "",
+	"ʿ", "",
+	"ß", "ss",
+	"æ", "ae",
+	"œ", "oe",
+	"ø", "o",
+	"đ", "d",
+	"ł", "l",
+	"ı", "i",
+)
+
+// Slugify converts a title into a URL-safe slug, e.g.
+// "Pendaftaran Santri Baru 2025/2026 & Beasiswa" -> "pendaftaran-santri-baru-2025-2026-dan-beasiswa".
+// Diacritics from Arabic transliteration (ṣalāt, ḥadīṡ) are folded into plain ASCII.
+func Slugify(s string) string {
+	s = slugReplacer.Replace(strings.ToLower(strings.TrimSpace(s)))
+
+	var b strings.Builder
+	b.Grow(len(s))
+	lastDash := true // avoid leading dash
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// combining mark (accent) -> drop
+			continue
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			lastDash = false
+		default:
+			if !lastDash {
+				b.WriteByte('-')
+				lastDash = true
+			}
+		}
+	}
+
+	out := strings.TrimSuffix(b.String(), "-")
+	if len(out) > MaxSlugLength {
+		out = out[:MaxSlugLength]
+		// cut at the last word boundary so we don't leave half a word
+		if i := strings.LastIndexByte(out, '-'); i > MaxSlugLength/2 {
+			out = out[:i]
+		}
+		out = strings.TrimSuffix(out, "-")
+	}
+	return out
+}
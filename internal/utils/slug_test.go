@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Pendaftaran Santri Baru 2025/2026 & Beasiswa", "pendaftaran-santri-baru-2025-2026-dan-beasiswa"},
+		{"Jum'at Berkah", "jumat-berkah"},
+		{"Qur’an dan Ḥadīṡ", "quran-dan-hadis"},
+		{"Ṣalāt 5 Waktu", "salat-5-waktu"},
+		{"Diskon 50% + Gratis", "diskon-50-persen-plus-gratis"},
+		{"Straße Œuvre", "strasse-oeuvre"},
+		{"  --Halo, Dunia!--  ", "halo-dunia"},
+		{"pendaftaran-santri", "pendaftaran-santri"}, // already a slug
+		{"!!!", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Slugify(tt.in); got != tt.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSlugifyLength(t *testing.T) {
+	got := Slugify(strings.Repeat("kata ", 30))
+	if len(got) > MaxSlugLength {
+		t.Fatalf("len = %d, want <= %d", len(got), MaxSlugLength)
+	}
+	// cut between words, not inside one
+	if !strings.HasSuffix(got, "-kata") || strings.HasSuffix(got, "-") {
+		t.Errorf("got %q", got)
+	}
+}
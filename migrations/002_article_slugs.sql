@@ -0,0 +1,19 @@
+-- Article slugs (public URLs by slug instead of sequential id)
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS slug TEXT;
+
+-- Backfill existing rows: lowercase title, non-alnum -> '-', suffixed with id to stay unique
+UPDATE articles
+SET slug = trim(both '-' from regexp_replace(lower(title), '[^a-z0-9]+', '-', 'g')) || '-' || id
+WHERE slug IS NULL OR slug = '';
+
+ALTER TABLE articles ALTER COLUMN slug SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_articles_slug ON articles (slug);
+
+-- Table: article_slug_redirects (old slug -> article, kept after rename)
+CREATE TABLE IF NOT EXISTS article_slug_redirects (
+    id BIGSERIAL PRIMARY KEY,
+    old_slug TEXT NOT NULL UNIQUE,
+    article_id BIGINT NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    created_at BIGINT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_article_slug_redirects_article_id ON article_slug_redirects (article_id);
@@ -0,0 +1,39 @@
+-- Table: categories (site sections: Berita, Kegiatan, Pengumuman, ...)
+CREATE TABLE IF NOT EXISTS categories (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    description TEXT,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+-- Table: tags
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+-- Join tables (many-to-many with articles)
+CREATE TABLE IF NOT EXISTS article_categories (
+    article_id BIGINT NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    category_id BIGINT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    PRIMARY KEY (article_id, category_id)
+);
+CREATE INDEX IF NOT EXISTS idx_article_categories_category_id ON article_categories (category_id);
+
+CREATE TABLE IF NOT EXISTS article_tags (
+    article_id BIGINT NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    tag_id BIGINT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (article_id, tag_id)
+);
+CREATE INDEX IF NOT EXISTS idx_article_tags_tag_id ON article_tags (tag_id);
+
+INSERT INTO categories (name, slug, description, created_at, updated_at) VALUES
+    ('Berita', 'berita', 'Berita seputar pondok', EXTRACT(EPOCH FROM NOW())::BIGINT, EXTRACT(EPOCH FROM NOW())::BIGINT),
+    ('Kegiatan', 'kegiatan', 'Kegiatan santri dan pondok', EXTRACT(EPOCH FROM NOW())::BIGINT, EXTRACT(EPOCH FROM NOW())::BIGINT),
+    ('Pengumuman', 'pengumuman', 'Pengumuman resmi', EXTRACT(EPOCH FROM NOW())::BIGINT, EXTRACT(EPOCH FROM NOW())::BIGINT)
+ON CONFLICT (slug) DO NOTHING;
@@ -0,0 +1,28 @@
+-- Full-text search for articles (Postgres 12+ for the 'indonesian' config).
+
+-- Plain text of the flexible content JSONB: text / title / caption strings and string list items
+-- from every object at any depth (blocks, nested columns, ...), in document order.
+-- URLs, block types and other metadata are not indexed.
+CREATE OR REPLACE FUNCTION article_content_text(content JSONB) RETURNS TEXT
+LANGUAGE SQL IMMUTABLE PARALLEL SAFE AS $$
+    SELECT COALESCE(string_agg(NULLIF(concat_ws(' ',
+        CASE WHEN jsonb_typeof(o -> 'title') = 'string' THEN o ->> 'title' END,
+        CASE WHEN jsonb_typeof(o -> 'text') = 'string' THEN o ->> 'text' END,
+        CASE WHEN jsonb_typeof(o -> 'caption') = 'string' THEN o ->> 'caption' END,
+        CASE WHEN jsonb_typeof(o -> 'items') = 'array' THEN (
+            SELECT string_agg(i #>> '{}', ' ')
+            FROM jsonb_array_elements(o -> 'items') AS i
+            WHERE jsonb_typeof(i) = 'string'
+        ) END
+    ), ''), ' '), '')
+    FROM jsonb_path_query(content, 'strict $.** ? (@.type() == "object")') AS o
+$$;
+
+-- Title is weighted A, body B (title matches rank higher).
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('indonesian', COALESCE(title, '')), 'A') ||
+        setweight(to_tsvector('indonesian', article_content_text(content)), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_articles_search_vector ON articles USING GIN (search_vector);
@@ -0,0 +1,15 @@
+-- Scheduled publishing: status 'scheduled' + publish_at / unpublish_at (unix seconds)
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS publish_at BIGINT;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS unpublish_at BIGINT;
+
+ALTER TABLE articles DROP CONSTRAINT IF EXISTS articles_status_check;
+ALTER TABLE articles ADD CONSTRAINT articles_status_check
+    CHECK (status IN ('draft','published','scheduled'));
+
+ALTER TABLE articles DROP CONSTRAINT IF EXISTS articles_scheduled_publish_at_check;
+ALTER TABLE articles ADD CONSTRAINT articles_scheduled_publish_at_check
+    CHECK (status <> 'scheduled' OR publish_at IS NOT NULL);
+
+-- Scheduler scans
+CREATE INDEX IF NOT EXISTS idx_articles_scheduled_publish_at ON articles (publish_at) WHERE status = 'scheduled';
+CREATE INDEX IF NOT EXISTS idx_articles_unpublish_at ON articles (unpublish_at) WHERE unpublish_at IS NOT NULL;
@@ -0,0 +1,18 @@
+-- Immutable article snapshots, one per create/update/restore.
+-- Articles that existed before this migration get revision 1 on their first edit.
+CREATE TABLE IF NOT EXISTS article_revisions (
+    id            BIGSERIAL PRIMARY KEY,
+    article_id    BIGINT NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    revision      INTEGER NOT NULL,
+    title         TEXT NOT NULL,
+    slug          TEXT NOT NULL,
+    photo_header  TEXT,
+    content       JSONB NOT NULL,
+    author        TEXT NOT NULL,
+    status        TEXT NOT NULL,
+    admin_id      BIGINT REFERENCES admins(id) ON DELETE SET NULL,
+    restored_from INTEGER,
+    created_at    BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM now())::BIGINT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_article_revisions_article_revision ON article_revisions (article_id, revision);
@@ -0,0 +1,9 @@
+-- Soft delete: deleted rows move to the trash (deleted_at set) and are purged
+-- after the configured retention (TRASH_RETENTION) or by a superadmin.
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE registrations ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_articles_deleted_at ON articles (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_registrations_deleted_at ON registrations (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_contacts_deleted_at ON contacts (deleted_at);
@@ -0,0 +1,8 @@
+-- Stored plain-text excerpt of the content (refreshed by the API on every save).
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS excerpt TEXT NOT NULL DEFAULT '';
+
+-- Backfill existing rows from the same text the search index uses (approximation of
+-- the API's excerpt: first 200 characters, whitespace collapsed).
+UPDATE articles
+SET excerpt = left(regexp_replace(btrim(article_content_text(content)), '\s+', ' ', 'g'), 200)
+WHERE excerpt = '';
@@ -0,0 +1,12 @@
+-- Expiring, revocable preview links for unpublished articles (only the token's SHA-256 is stored)
+CREATE TABLE IF NOT EXISTS article_preview_links (
+    id         BIGSERIAL PRIMARY KEY,
+    article_id BIGINT NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    note       TEXT NOT NULL DEFAULT '',
+    created_by BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    revoked_at BIGINT,
+    created_at BIGINT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_article_preview_links_article_id ON article_preview_links (article_id);
@@ -0,0 +1,25 @@
+-- Media library: one row per uploaded file, plus which articles use which item.
+-- Files uploaded before this migration are not listed (they were never recorded).
+CREATE TABLE IF NOT EXISTS media (
+    id          BIGSERIAL PRIMARY KEY,
+    object_name TEXT NOT NULL UNIQUE,
+    url         TEXT NOT NULL,
+    file_name   TEXT NOT NULL DEFAULT '',
+    mime_type   TEXT NOT NULL,
+    size        BIGINT NOT NULL,
+    width       INTEGER,
+    height      INTEGER,
+    uploaded_by BIGINT NOT NULL,
+    created_at  BIGINT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_media_url ON media (url);
+CREATE INDEX IF NOT EXISTS idx_media_created_at ON media (created_at DESC);
+
+-- Rebuilt on every article save from media_id / URL references in content and photo_header.
+-- RESTRICT: an item can't be deleted while an article (trashed ones included) uses it.
+CREATE TABLE IF NOT EXISTS article_media (
+    article_id BIGINT NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    media_id   BIGINT NOT NULL REFERENCES media(id) ON DELETE RESTRICT,
+    PRIMARY KEY (article_id, media_id)
+);
+CREATE INDEX IF NOT EXISTS idx_article_media_media_id ON article_media (media_id);
@@ -0,0 +1,3 @@
+-- Resized image variants: media items list their renditions, articles the header's srcset
+ALTER TABLE media ADD COLUMN IF NOT EXISTS variants JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS photo_header_srcset JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,24 @@
+-- Registration documents (akta kelahiran, kartu keluarga, rapor, pas foto), one per
+-- type, stored in the private bucket. Applicants upload them with the token handed
+-- out on registration (only its SHA-256 is stored).
+ALTER TABLE registrations ADD COLUMN IF NOT EXISTS upload_token_hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE registrations ADD COLUMN IF NOT EXISTS upload_token_expires_at TIMESTAMPTZ;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_registrations_upload_token_hash
+    ON registrations (upload_token_hash) WHERE upload_token_hash <> '';
+
+CREATE TABLE IF NOT EXISTS registration_documents (
+    id              BIGSERIAL PRIMARY KEY,
+    registration_id BIGINT NOT NULL REFERENCES registrations(id) ON DELETE CASCADE,
+    type            TEXT NOT NULL CHECK (type IN ('akta_kelahiran','kartu_keluarga','rapor','pas_foto')),
+    object_name     TEXT NOT NULL,
+    file_name       TEXT NOT NULL,
+    mime_type       TEXT NOT NULL,
+    size            BIGINT NOT NULL,
+    status          TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending','verified','rejected')),
+    reject_reason   TEXT NOT NULL DEFAULT '',
+    reviewed_by     BIGINT,
+    reviewed_at     TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_registration_documents_type ON registration_documents (registration_id, type);
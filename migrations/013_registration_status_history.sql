@@ -0,0 +1,24 @@
+-- Registration status state machine: terminal states 'rejected' and 'withdrawn',
+-- and a history row for every status change (who, from/to, note).
+ALTER TABLE registrations DROP CONSTRAINT IF EXISTS registrations_status_check;
+ALTER TABLE registrations ADD CONSTRAINT registrations_status_check
+    CHECK (status IN ('new','validate','process','done','rejected','withdrawn'));
+
+CREATE TABLE IF NOT EXISTS registration_status_history (
+    id              BIGSERIAL PRIMARY KEY,
+    registration_id BIGINT NOT NULL REFERENCES registrations(id) ON DELETE CASCADE,
+    from_status     TEXT NOT NULL DEFAULT '',
+    to_status       TEXT NOT NULL,
+    admin_id        BIGINT REFERENCES admins(id) ON DELETE SET NULL,
+    note            TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_registration_status_history_registration_id
+    ON registration_status_history (registration_id, created_at);
+
+-- Existing registrations start their timeline at their creation, in the status
+-- they have now (earlier changes weren't recorded)
+INSERT INTO registration_status_history (registration_id, from_status, to_status, created_at)
+SELECT r.id, '', r.status, r.created_at
+FROM registrations r
+WHERE NOT EXISTS (SELECT 1 FROM registration_status_history h WHERE h.registration_id = r.id);
@@ -0,0 +1,23 @@
+-- Admission periods (gelombang): new registrations are assigned to the period
+-- open at that moment and count against its quotas (NULL = no limit).
+CREATE TABLE IF NOT EXISTS admission_periods (
+    id             BIGSERIAL PRIMARY KEY,
+    academic_year  TEXT NOT NULL,
+    wave_name      TEXT NOT NULL,
+    opens_at       TIMESTAMPTZ NOT NULL,
+    closes_at      TIMESTAMPTZ NOT NULL,
+    quota_male     INTEGER CHECK (quota_male >= 0),
+    quota_female   INTEGER CHECK (quota_female >= 0),
+    quota_new      INTEGER CHECK (quota_new >= 0),
+    quota_transfer INTEGER CHECK (quota_transfer >= 0),
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (closes_at > opens_at)
+);
+CREATE INDEX IF NOT EXISTS idx_admission_periods_academic_year ON admission_periods (academic_year);
+CREATE INDEX IF NOT EXISTS idx_admission_periods_opens_at ON admission_periods (opens_at, closes_at);
+
+-- Registrations made before this migration keep no period
+ALTER TABLE registrations ADD COLUMN IF NOT EXISTS admission_period_id BIGINT
+    REFERENCES admission_periods(id) ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS idx_registrations_admission_period_id ON registrations (admission_period_id);
@@ -0,0 +1,10 @@
+-- Reference numbers handed to applicants on registration (public status lookup
+-- with the date of birth). Existing registrations get one too.
+ALTER TABLE registrations ADD COLUMN IF NOT EXISTS reference_number TEXT;
+
+UPDATE registrations
+SET reference_number = 'DA' || to_char(created_at, 'YY') || '-' || upper(substr(md5(random()::text || id::text), 1, 6))
+WHERE reference_number IS NULL;
+
+ALTER TABLE registrations ALTER COLUMN reference_number SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_registrations_reference_number ON registrations (reference_number);
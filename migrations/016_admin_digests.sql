@@ -0,0 +1,6 @@
+-- Admin digest mails: one row per sent window (claimed before sending, so each
+-- window is mailed once across instances and restarts).
+CREATE TABLE IF NOT EXISTS admin_digests (
+    period_end TIMESTAMPTZ PRIMARY KEY,
+    sent_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,20 @@
+-- WhatsApp/SMS delivery log: one row per message and phone number, with its
+-- retry state (pending rows are sent when next_attempt_at is due).
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id              BIGSERIAL PRIMARY KEY,
+    channel         TEXT NOT NULL,
+    event           TEXT NOT NULL,
+    registration_id BIGINT REFERENCES registrations(id) ON DELETE CASCADE,
+    recipient       TEXT NOT NULL,
+    message         TEXT NOT NULL,
+    status          TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending','sent','failed')),
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    last_error      TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ,
+    sent_at         TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_registration_id ON notification_deliveries (registration_id);
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_due ON notification_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_status ON notification_deliveries (status, id);